@@ -4,6 +4,7 @@ package appsec
 import (
 	"errors"
 
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/poll"
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/session"
 )
 
@@ -83,6 +84,7 @@ type (
 
 	appsec struct {
 		session.Session
+		clock poll.Clock
 	}
 
 	// Option defines a PAPI option
@@ -92,10 +94,79 @@ type (
 	ClientFunc func(sess session.Session, opts ...Option) APPSEC
 )
 
+// appsec implements each of the interfaces composing APPSEC
+var (
+	_ Activations                      = (*appsec)(nil)
+	_ AdvancedSettingsEvasivePathMatch = (*appsec)(nil)
+	_ AdvancedSettingsLogging          = (*appsec)(nil)
+	_ AdvancedSettingsPragma           = (*appsec)(nil)
+	_ AdvancedSettingsPrefetch         = (*appsec)(nil)
+	_ ApiConstraintsProtection         = (*appsec)(nil)
+	_ ApiEndpoints                     = (*appsec)(nil)
+	_ ApiHostnameCoverage              = (*appsec)(nil)
+	_ ApiHostnameCoverageMatchTargets  = (*appsec)(nil)
+	_ ApiHostnameCoverageOverlapping   = (*appsec)(nil)
+	_ ApiRequestConstraints            = (*appsec)(nil)
+	_ AttackGroup                      = (*appsec)(nil)
+	_ BypassNetworkLists               = (*appsec)(nil)
+	_ Configuration                    = (*appsec)(nil)
+	_ ConfigurationClone               = (*appsec)(nil)
+	_ ConfigurationVersion             = (*appsec)(nil)
+	_ ConfigurationVersionClone        = (*appsec)(nil)
+	_ ContractsGroups                  = (*appsec)(nil)
+	_ CustomDeny                       = (*appsec)(nil)
+	_ CustomRule                       = (*appsec)(nil)
+	_ CustomRuleAction                 = (*appsec)(nil)
+	_ Eval                             = (*appsec)(nil)
+	_ EvalGroup                        = (*appsec)(nil)
+	_ EvalHost                         = (*appsec)(nil)
+	_ EvalPenaltyBox                   = (*appsec)(nil)
+	_ EvalProtectHost                  = (*appsec)(nil)
+	_ EvalRule                         = (*appsec)(nil)
+	_ ExportConfiguration              = (*appsec)(nil)
+	_ FailoverHostnames                = (*appsec)(nil)
+	_ IPGeo                            = (*appsec)(nil)
+	_ IPGeoProtection                  = (*appsec)(nil)
+	_ MalwareContentTypes              = (*appsec)(nil)
+	_ MalwarePolicy                    = (*appsec)(nil)
+	_ MalwarePolicyAction              = (*appsec)(nil)
+	_ MalwareProtection                = (*appsec)(nil)
+	_ MatchTarget                      = (*appsec)(nil)
+	_ MatchTargetSequence              = (*appsec)(nil)
+	_ NetworkLayerProtection           = (*appsec)(nil)
+	_ PenaltyBox                       = (*appsec)(nil)
+	_ PolicyProtections                = (*appsec)(nil)
+	_ RatePolicy                       = (*appsec)(nil)
+	_ RatePolicyAction                 = (*appsec)(nil)
+	_ RateProtection                   = (*appsec)(nil)
+	_ ReputationAnalysis               = (*appsec)(nil)
+	_ ReputationProfile                = (*appsec)(nil)
+	_ ReputationProfileAction          = (*appsec)(nil)
+	_ ReputationProtection             = (*appsec)(nil)
+	_ Rule                             = (*appsec)(nil)
+	_ RuleUpgrade                      = (*appsec)(nil)
+	_ SecurityPolicy                   = (*appsec)(nil)
+	_ SecurityPolicyClone              = (*appsec)(nil)
+	_ SelectableHostnames              = (*appsec)(nil)
+	_ SelectedHostname                 = (*appsec)(nil)
+	_ SiemDefinitions                  = (*appsec)(nil)
+	_ SiemSettings                     = (*appsec)(nil)
+	_ SlowPostProtection               = (*appsec)(nil)
+	_ SlowPostProtectionSetting        = (*appsec)(nil)
+	_ ThreatIntel                      = (*appsec)(nil)
+	_ TuningRecommendations            = (*appsec)(nil)
+	_ VersionNotes                     = (*appsec)(nil)
+	_ WAFMode                          = (*appsec)(nil)
+	_ WAFProtection                    = (*appsec)(nil)
+	_ WAPBypassNetworkLists            = (*appsec)(nil)
+	_ WAPSelectedHostnames             = (*appsec)(nil)
+)
+
 // Client returns a new appsec Client instance with the specified controller
 func Client(sess session.Session, opts ...Option) APPSEC {
 	p := &appsec{
 		Session: sess,
+		clock:   poll.SystemClock(),
 	}
 
 	for _, opt := range opts {
@@ -103,3 +174,10 @@ func Client(sess session.Session, opts ...Option) APPSEC {
 	}
 	return p
 }
+
+// WithClock sets the clock used by the polling helpers, e.g. to use a fake clock in tests
+func WithClock(clock poll.Clock) Option {
+	return func(p *appsec) {
+		p.clock = clock
+	}
+}
@@ -11,14 +11,16 @@ import (
 	"net/http/httptest"
 	"net/url"
 	"testing"
+	"time"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/edgegrid"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/poll"
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/session"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
-func mockAPIClient(t *testing.T, mockServer *httptest.Server) APPSEC {
+func mockAPIClient(t *testing.T, mockServer *httptest.Server, opts ...Option) APPSEC {
 	serverURL, err := url.Parse(mockServer.URL)
 	require.NoError(t, err)
 	certPool := x509.NewCertPool()
@@ -32,7 +34,7 @@ func mockAPIClient(t *testing.T, mockServer *httptest.Server) APPSEC {
 	}
 	s, err := session.New(session.WithClient(httpClient), session.WithSigner(&edgegrid.Config{Host: serverURL.Host}))
 	assert.NoError(t, err)
-	return Client(s)
+	return Client(s, opts...)
 }
 
 func dummyOpt() Option {
@@ -63,6 +65,7 @@ func compactJSON(encoded []byte) string {
 func TestClient(t *testing.T) {
 	sess, err := session.New()
 	require.NoError(t, err)
+	clock := poll.NewFakeClock(time.Now())
 	tests := map[string]struct {
 		options  []Option
 		expected *appsec
@@ -71,12 +74,21 @@ func TestClient(t *testing.T) {
 			options: nil,
 			expected: &appsec{
 				Session: sess,
+				clock:   poll.SystemClock(),
 			},
 		},
 		"dummy option": {
 			options: []Option{dummyOpt()},
 			expected: &appsec{
 				Session: sess,
+				clock:   poll.SystemClock(),
+			},
+		},
+		"clock": {
+			options: []Option{WithClock(clock)},
+			expected: &appsec{
+				Session: sess,
+				clock:   clock,
 			},
 		},
 	}
@@ -1,10 +1,14 @@
 package appsec
 
+import "strings"
+
 type (
 	// RulesetType is a ruleset type value.
 	RulesetType string
 	// ActionType is an action type value.
 	ActionType string
+	// VersionStatus is the status of a configuration version on a network.
+	VersionStatus string
 )
 
 const (
@@ -20,4 +24,40 @@ const (
 	ActionTypeAlert ActionType = "alert"
 	// ActionTypeNone firewall no action.
 	ActionTypeNone ActionType = "none"
+
+	// VersionStatusActive for versions active on the network.
+	VersionStatusActive VersionStatus = "Active"
+	// VersionStatusInactive for versions which were never activated on the network.
+	VersionStatusInactive VersionStatus = "Inactive"
+	// VersionStatusPending for versions being activated or deactivated on the network.
+	VersionStatusPending VersionStatus = "Pending"
+	// VersionStatusDeactivated for versions which were deactivated on the network.
+	VersionStatusDeactivated VersionStatus = "Deactivated"
+	// VersionStatusFailed for versions which failed to activate on the network.
+	VersionStatusFailed VersionStatus = "Failed"
 )
+
+// IsActive reports whether the status is ACTIVE.
+func (s VersionStatus) IsActive() bool {
+	return strings.EqualFold(string(s), string(VersionStatusActive))
+}
+
+// IsInactive reports whether the status is INACTIVE.
+func (s VersionStatus) IsInactive() bool {
+	return strings.EqualFold(string(s), string(VersionStatusInactive))
+}
+
+// IsPending reports whether the status is PENDING.
+func (s VersionStatus) IsPending() bool {
+	return strings.EqualFold(string(s), string(VersionStatusPending))
+}
+
+// IsDeactivated reports whether the status is DEACTIVATED.
+func (s VersionStatus) IsDeactivated() bool {
+	return strings.EqualFold(string(s), string(VersionStatusDeactivated))
+}
+
+// IsFailed reports whether the status is FAILED.
+func (s VersionStatus) IsFailed() bool {
+	return strings.EqualFold(string(s), string(VersionStatusFailed))
+}
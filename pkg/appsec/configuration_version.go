@@ -32,10 +32,10 @@ type (
 		VersionList        []struct {
 			ConfigID   int `json:"configId,omitempty"`
 			Production struct {
-				Status string `json:"status,omitempty"`
+				Status VersionStatus `json:"status,omitempty"`
 			} `json:"production,omitempty"`
 			Staging struct {
-				Status string `json:"status,omitempty"`
+				Status VersionStatus `json:"status,omitempty"`
 			} `json:"staging,omitempty"`
 			Version int `json:"version,omitempty"`
 			BasedOn int `json:"basedOn,omitempty"`
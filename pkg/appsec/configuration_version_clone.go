@@ -7,6 +7,7 @@ import (
 
 	"time"
 
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/poll"
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 )
 
@@ -24,67 +25,87 @@ type (
 
 		// https://developer.akamai.com/api/cloud_security/application_security/v1.html#deleteconfigurationversion
 		RemoveConfigurationVersionClone(ctx context.Context, params RemoveConfigurationVersionCloneRequest) (*RemoveConfigurationVersionCloneResponse, error)
+
+		// GetVersionLineage follows the basedOn chain of a configuration version back to the version it was originally derived from.
+		GetVersionLineage(ctx context.Context, params GetVersionLineageRequest) (*GetVersionLineageResponse, error)
+
+		// CloneAndWaitForRuleUpdate clones a configuration version with the rule update enabled and waits until
+		// the rule sets of the new version are updated.
+		CloneAndWaitForRuleUpdate(ctx context.Context, params CloneAndWaitForRuleUpdateRequest) (*CreateConfigurationVersionCloneResponse, error)
+	}
+
+	// ProductionVersionStatus describes the state of a configuration version on the production network.
+	ProductionVersionStatus struct {
+		Status VersionStatus `json:"status"`
+		Time   time.Time     `json:"time"`
+	}
+
+	// StagingVersionStatus describes the state of a configuration version on the staging network.
+	StagingVersionStatus struct {
+		Status VersionStatus `json:"status"`
 	}
 
 	// GetConfigurationVersionCloneRequest is used to retrieve information about an existing configuration version.
 	GetConfigurationVersionCloneRequest struct {
-		ConfigID     int       `json:"configId"`
-		ConfigName   string    `json:"configName"`
-		Version      int       `json:"version"`
-		VersionNotes string    `json:"versionNotes"`
-		CreateDate   time.Time `json:"createDate"`
-		CreatedBy    string    `json:"createdBy"`
-		BasedOn      int       `json:"basedOn"`
-		Production   struct {
-			Status string    `json:"status"`
-			Time   time.Time `json:"time"`
-		} `json:"production"`
-		Staging struct {
-			Status string `json:"status"`
-		} `json:"staging"`
+		ConfigID     int                     `json:"configId"`
+		ConfigName   string                  `json:"configName"`
+		Version      int                     `json:"version"`
+		VersionNotes string                  `json:"versionNotes"`
+		CreateDate   time.Time               `json:"createDate"`
+		CreatedBy    string                  `json:"createdBy"`
+		BasedOn      int                     `json:"basedOn"`
+		Production   ProductionVersionStatus `json:"production"`
+		Staging      StagingVersionStatus    `json:"staging"`
 	}
 
 	// GetConfigurationVersionCloneResponse is returned from a call to GetConfigurationVersionClone.
 	GetConfigurationVersionCloneResponse struct {
-		ConfigID     int       `json:"configId"`
-		ConfigName   string    `json:"configName"`
-		Version      int       `json:"version"`
-		VersionNotes string    `json:"versionNotes"`
-		CreateDate   time.Time `json:"createDate"`
-		CreatedBy    string    `json:"createdBy"`
-		BasedOn      int       `json:"basedOn"`
-		Production   struct {
-			Status string    `json:"status"`
-			Time   time.Time `json:"time"`
-		} `json:"production"`
-		Staging struct {
-			Status string `json:"status"`
-		} `json:"staging"`
+		ConfigID     int                     `json:"configId"`
+		ConfigName   string                  `json:"configName"`
+		Version      int                     `json:"version"`
+		VersionNotes string                  `json:"versionNotes"`
+		CreateDate   time.Time               `json:"createDate"`
+		CreatedBy    string                  `json:"createdBy"`
+		BasedOn      int                     `json:"basedOn"`
+		Production   ProductionVersionStatus `json:"production"`
+		Staging      StagingVersionStatus    `json:"staging"`
 	}
 
 	// CreateConfigurationVersionCloneRequest is used to clone an existing configuration version.
+	// The rule sets of the clone are only updated if RuleUpdate is set, or RuleUpdateMode is RuleUpdateModeLatest.
 	CreateConfigurationVersionCloneRequest struct {
 		ConfigID          int  `json:"-"`
 		CreateFromVersion int  `json:"createFromVersion"`
 		RuleUpdate        bool `json:"ruleUpdate"`
+		// RuleUpdateMode states explicitly whether the rule sets of the clone are updated. If it is not set,
+		// RuleUpdate is used as is.
+		RuleUpdateMode RuleUpdateMode `json:"-"`
+		// DryRun only checks that the version to clone from exists, without creating the clone.
+		// The outcome of the checks is returned in the DryRun field of the response, the other fields are left unset.
+		// The API does not report a lock state of configuration versions, any existing version can be cloned,
+		// so there is no lock to check.
+		DryRun bool `json:"-"`
 	}
 
 	// CreateConfigurationVersionCloneResponse is returned from a call to CreateConfigurationVersionClone.
 	CreateConfigurationVersionCloneResponse struct {
-		ConfigID     int       `json:"configId"`
-		ConfigName   string    `json:"configName"`
-		Version      int       `json:"version"`
-		VersionNotes string    `json:"versionNotes"`
-		CreateDate   time.Time `json:"createDate"`
-		CreatedBy    string    `json:"createdBy"`
-		BasedOn      int       `json:"basedOn"`
-		Production   struct {
-			Status string    `json:"status"`
-			Time   time.Time `json:"time"`
-		} `json:"production"`
-		Staging struct {
-			Status string `json:"status"`
-		} `json:"staging"`
+		ConfigID     int                     `json:"configId"`
+		ConfigName   string                  `json:"configName"`
+		Version      int                     `json:"version"`
+		VersionNotes string                  `json:"versionNotes"`
+		CreateDate   time.Time               `json:"createDate"`
+		CreatedBy    string                  `json:"createdBy"`
+		BasedOn      int                     `json:"basedOn"`
+		Production   ProductionVersionStatus `json:"production"`
+		Staging      StagingVersionStatus    `json:"staging"`
+		// DryRun is the outcome of the checks, only set if the clone was requested with DryRun.
+		DryRun *ConfigurationVersionCloneDryRun `json:"-"`
+	}
+
+	// ConfigurationVersionCloneDryRun is the outcome of the checks done by CreateConfigurationVersionClone in dry run.
+	ConfigurationVersionCloneDryRun struct {
+		// Source is the configuration version the clone would be created from.
+		Source GetConfigurationVersionCloneResponse
 	}
 
 	// RemoveConfigurationVersionCloneRequest is used to remove an existing configuration version.
@@ -93,12 +114,47 @@ type (
 		Version  int `json:"-"`
 	}
 
+	// GetVersionLineageRequest is used to retrieve the lineage of a configuration version.
+	GetVersionLineageRequest struct {
+		ConfigID int
+		Version  int
+	}
+
+	// GetVersionLineageResponse is returned from a call to GetVersionLineage.
+	// Versions starts with the requested version and is followed by its ancestors, the root version being the last one.
+	GetVersionLineageResponse struct {
+		Versions []GetConfigurationVersionCloneResponse
+	}
+
+	// CloneAndWaitForRuleUpdateRequest is used to clone a configuration version and wait for its rule update.
+	// The rule update is checked on the security policy identified by PolicyID.
+	CloneAndWaitForRuleUpdateRequest struct {
+		ConfigID          int
+		CreateFromVersion int
+		PolicyID          string
+		// PollInterval is the time between rule update checks, DefaultRuleUpdatePollInterval is used if not set.
+		PollInterval time.Duration
+	}
+
+	// RuleUpdateMode tells whether the rule sets of a cloned configuration version are updated.
+	RuleUpdateMode string
+
 	// RemoveConfigurationVersionCloneResponse is returned from a call to RemoveConfigurationVersionClone.
 	RemoveConfigurationVersionCloneResponse struct {
 		Empty string `json:"-"`
 	}
 )
 
+const (
+	// RuleUpdateModeNone clones the configuration version keeping its rule sets as they are.
+	RuleUpdateModeNone RuleUpdateMode = "NONE"
+	// RuleUpdateModeLatest clones the configuration version updating its rule sets to the latest ones.
+	RuleUpdateModeLatest RuleUpdateMode = "LATEST"
+)
+
+// DefaultRuleUpdatePollInterval is the default time between rule update checks of CloneAndWaitForRuleUpdate.
+const DefaultRuleUpdatePollInterval = 10 * time.Second
+
 // Validate validates a GetConfigurationCloneRequest.
 func (v GetConfigurationVersionCloneRequest) Validate() error {
 	return validation.Errors{
@@ -110,8 +166,11 @@ func (v GetConfigurationVersionCloneRequest) Validate() error {
 // Validate validates a CreateConfigurationCloneRequest.
 func (v CreateConfigurationVersionCloneRequest) Validate() error {
 	return validation.Errors{
-		"ConfigID": validation.Validate(v.ConfigID, validation.Required),
-		"Version":  validation.Validate(v.CreateFromVersion, validation.Required),
+		"ConfigID":       validation.Validate(v.ConfigID, validation.Required),
+		"Version":        validation.Validate(v.CreateFromVersion, validation.Required),
+		"RuleUpdateMode": validation.Validate(v.RuleUpdateMode, validation.In(RuleUpdateModeNone, RuleUpdateModeLatest)),
+		"RuleUpdate": validation.Validate(v.RuleUpdate,
+			validation.When(v.RuleUpdateMode == RuleUpdateModeNone, validation.Empty.Error("must not be set with rule update mode NONE"))),
 	}.Filter()
 }
 
@@ -123,6 +182,24 @@ func (v RemoveConfigurationVersionCloneRequest) Validate() error {
 	}.Filter()
 }
 
+// Validate validates a GetVersionLineageRequest.
+func (v GetVersionLineageRequest) Validate() error {
+	return validation.Errors{
+		"ConfigID": validation.Validate(v.ConfigID, validation.Required),
+		"Version":  validation.Validate(v.Version, validation.Required),
+	}.Filter()
+}
+
+// Validate validates a CloneAndWaitForRuleUpdateRequest.
+func (v CloneAndWaitForRuleUpdateRequest) Validate() error {
+	return validation.Errors{
+		"ConfigID":     validation.Validate(v.ConfigID, validation.Required),
+		"Version":      validation.Validate(v.CreateFromVersion, validation.Required),
+		"PolicyID":     validation.Validate(v.PolicyID, validation.Required),
+		"PollInterval": validation.Validate(v.PollInterval, validation.Min(time.Duration(0))),
+	}.Filter()
+}
+
 func (p *appsec) GetConfigurationVersionClone(ctx context.Context, params GetConfigurationVersionCloneRequest) (*GetConfigurationVersionCloneResponse, error) {
 	logger := p.Log(ctx)
 	logger.Debug("GetConfigurationVersionClone")
@@ -161,6 +238,30 @@ func (p *appsec) CreateConfigurationVersionClone(ctx context.Context, params Cre
 		return nil, fmt.Errorf("%w: %s", ErrStructValidation, err.Error())
 	}
 
+	if params.DryRun {
+		source, err := p.GetConfigurationVersionClone(ctx, GetConfigurationVersionCloneRequest{
+			ConfigID: params.ConfigID,
+			Version:  params.CreateFromVersion,
+		})
+		if err != nil {
+			return nil, err
+		}
+		logger.Debugf("dry run: version %d of configuration %d can be cloned", params.CreateFromVersion, params.ConfigID)
+		return &CreateConfigurationVersionCloneResponse{
+			DryRun: &ConfigurationVersionCloneDryRun{
+				Source: *source,
+			},
+		}, nil
+	}
+
+	switch {
+	case params.RuleUpdateMode == RuleUpdateModeLatest:
+		params.RuleUpdate = true
+	case params.RuleUpdateMode == "" && !params.RuleUpdate:
+		logger.Debugf("cloning version %d of configuration %d without rule update, RuleUpdateMode is not set",
+			params.CreateFromVersion, params.ConfigID)
+	}
+
 	uri := fmt.Sprintf("/appsec/v1/configs/%d/versions", params.ConfigID)
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, uri, nil)
@@ -205,3 +306,81 @@ func (p *appsec) RemoveConfigurationVersionClone(ctx context.Context, params Rem
 
 	return &result, nil
 }
+
+func (p *appsec) GetVersionLineage(ctx context.Context, params GetVersionLineageRequest) (*GetVersionLineageResponse, error) {
+	logger := p.Log(ctx)
+	logger.Debug("GetVersionLineage")
+
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrStructValidation, err.Error())
+	}
+
+	var result GetVersionLineageResponse
+	visited := make(map[int]bool)
+	// version numbers start at 1, basedOn is 0 for the root version
+	for version := params.Version; version > 0; {
+		if visited[version] {
+			// the API should never report a cycle, but stop instead of looping forever if it does
+			logger.Warnf("configuration version %d is based on itself through its descendants, stopping lineage", version)
+			break
+		}
+		visited[version] = true
+
+		clone, err := p.GetConfigurationVersionClone(ctx, GetConfigurationVersionCloneRequest{
+			ConfigID: params.ConfigID,
+			Version:  version,
+		})
+		if err != nil {
+			return nil, err
+		}
+		result.Versions = append(result.Versions, *clone)
+		version = clone.BasedOn
+	}
+
+	return &result, nil
+}
+
+func (p *appsec) CloneAndWaitForRuleUpdate(ctx context.Context, params CloneAndWaitForRuleUpdateRequest) (*CreateConfigurationVersionCloneResponse, error) {
+	logger := p.Log(ctx)
+	logger.Debug("CloneAndWaitForRuleUpdate")
+
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrStructValidation, err.Error())
+	}
+
+	clone, err := p.CreateConfigurationVersionClone(ctx, CreateConfigurationVersionCloneRequest{
+		ConfigID:          params.ConfigID,
+		CreateFromVersion: params.CreateFromVersion,
+		RuleUpdateMode:    RuleUpdateModeLatest,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cloning version %d of configuration %d: %w", params.CreateFromVersion, params.ConfigID, err)
+	}
+
+	interval := params.PollInterval
+	if interval == 0 {
+		interval = DefaultRuleUpdatePollInterval
+	}
+
+	checkRuleUpdate := func(ctx context.Context) (bool, error) {
+		upgrade, err := p.GetRuleUpgrade(ctx, GetRuleUpgradeRequest{
+			ConfigID: params.ConfigID,
+			Version:  clone.Version,
+			PolicyID: params.PolicyID,
+		})
+		if err != nil {
+			return false, err
+		}
+		// the rule update is complete once the version uses the latest rule set
+		if upgrade.Current != "" && upgrade.Current == upgrade.Latest {
+			return true, nil
+		}
+		logger.Debugf("rule update of version %d is pending (current: %q, latest: %q), checking again in %s", clone.Version, upgrade.Current, upgrade.Latest, interval)
+		return false, nil
+	}
+
+	if err := poll.Until(ctx, checkRuleUpdate, poll.WithClock(p.clock), poll.WithInterval(interval)); err != nil {
+		return nil, fmt.Errorf("waiting for rule update of version %d: %w", clone.Version, err)
+	}
+	return clone, nil
+}
@@ -4,11 +4,17 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/poll"
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/session"
+	"github.com/apex/log"
+	"github.com/apex/log/handlers/memory"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -244,3 +250,539 @@ func TestAppSec_CreateConfigurationVersionClone(t *testing.T) {
 		})
 	}
 }
+
+func TestAppSec_GetVersionLineage(t *testing.T) {
+
+	var lineage []GetConfigurationVersionCloneResponse
+	require.NoError(t, json.Unmarshal(loadFixtureBytes("testdata/TestConfigurationVersionClone/VersionLineage.json"), &lineage))
+
+	cycle := []GetConfigurationVersionCloneResponse{lineage[0], lineage[1], lineage[2]}
+	cycle[2].BasedOn = 5
+
+	tests := map[string]struct {
+		params           GetVersionLineageRequest
+		versions         []GetConfigurationVersionCloneResponse
+		responseStatus   int
+		responseBody     string
+		expectedPaths    []string
+		expectedResponse *GetVersionLineageResponse
+		withError        error
+	}{
+		"200 OK": {
+			params:         GetVersionLineageRequest{ConfigID: 43253, Version: 5},
+			versions:       lineage,
+			responseStatus: http.StatusOK,
+			expectedPaths: []string{
+				"/appsec/v1/configs/43253/versions/5",
+				"/appsec/v1/configs/43253/versions/3",
+				"/appsec/v1/configs/43253/versions/1",
+			},
+			expectedResponse: &GetVersionLineageResponse{Versions: lineage},
+		},
+		"root version": {
+			params:           GetVersionLineageRequest{ConfigID: 43253, Version: 1},
+			versions:         lineage,
+			responseStatus:   http.StatusOK,
+			expectedPaths:    []string{"/appsec/v1/configs/43253/versions/1"},
+			expectedResponse: &GetVersionLineageResponse{Versions: lineage[2:]},
+		},
+		"cycle": {
+			params:         GetVersionLineageRequest{ConfigID: 43253, Version: 5},
+			versions:       cycle,
+			responseStatus: http.StatusOK,
+			expectedPaths: []string{
+				"/appsec/v1/configs/43253/versions/5",
+				"/appsec/v1/configs/43253/versions/3",
+				"/appsec/v1/configs/43253/versions/1",
+			},
+			expectedResponse: &GetVersionLineageResponse{Versions: cycle},
+		},
+		"500 internal server error": {
+			params:         GetVersionLineageRequest{ConfigID: 43253, Version: 5},
+			responseStatus: http.StatusInternalServerError,
+			responseBody: `
+{
+    "type": "internal_error",
+    "title": "Internal Server Error",
+    "detail": "Error fetching version",
+    "status": 500
+}`,
+			expectedPaths: []string{"/appsec/v1/configs/43253/versions/5"},
+			withError: &Error{
+				Type:       "internal_error",
+				Title:      "Internal Server Error",
+				Detail:     "Error fetching version",
+				StatusCode: http.StatusInternalServerError,
+			},
+		},
+		"validation error": {
+			params:    GetVersionLineageRequest{ConfigID: 43253},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var paths []string
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodGet, r.Method)
+				paths = append(paths, r.URL.String())
+				w.WriteHeader(test.responseStatus)
+				body := []byte(test.responseBody)
+				for _, version := range test.versions {
+					if r.URL.Path == fmt.Sprintf("/appsec/v1/configs/43253/versions/%d", version.Version) {
+						var err error
+						body, err = json.Marshal(version)
+						assert.NoError(t, err)
+					}
+				}
+				_, err := w.Write(body)
+				assert.NoError(t, err)
+			}))
+			defer mockServer.Close()
+			client := mockAPIClient(t, mockServer)
+			result, err := client.GetVersionLineage(context.Background(), test.params)
+			assert.Equal(t, test.expectedPaths, paths)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestAppSec_ConfigurationVersionCloneJSON(t *testing.T) {
+	// legacy shape of the clone responses, with inline network status structs
+	type legacyVersionClone struct {
+		ConfigID     int       `json:"configId"`
+		ConfigName   string    `json:"configName"`
+		Version      int       `json:"version"`
+		VersionNotes string    `json:"versionNotes"`
+		CreateDate   time.Time `json:"createDate"`
+		CreatedBy    string    `json:"createdBy"`
+		BasedOn      int       `json:"basedOn"`
+		Production   struct {
+			Status VersionStatus `json:"status"`
+			Time   time.Time     `json:"time"`
+		} `json:"production"`
+		Staging struct {
+			Status VersionStatus `json:"status"`
+		} `json:"staging"`
+	}
+
+	body := `
+{
+    "basedOn": 3,
+    "configId": 43253,
+    "configName": "Akamai Tools",
+    "createDate": "2020-10-06T18:00:20Z",
+    "createdBy": "akava-terraform",
+    "production": {
+        "status": "Active",
+        "time": "2020-10-07T09:30:00Z"
+    },
+    "staging": {
+        "status": "Inactive"
+    },
+    "version": 15
+}`
+
+	var legacy legacyVersionClone
+	require.NoError(t, json.Unmarshal([]byte(body), &legacy))
+	expected, err := json.Marshal(legacy)
+	require.NoError(t, err)
+
+	tests := map[string]interface{}{
+		"GetConfigurationVersionCloneRequest":     &GetConfigurationVersionCloneRequest{},
+		"GetConfigurationVersionCloneResponse":    &GetConfigurationVersionCloneResponse{},
+		"CreateConfigurationVersionCloneResponse": &CreateConfigurationVersionCloneResponse{},
+	}
+	for name, target := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.NoError(t, json.Unmarshal([]byte(body), target))
+			res, err := json.Marshal(target)
+			require.NoError(t, err)
+			assert.JSONEq(t, string(expected), string(res))
+		})
+	}
+
+	var response GetConfigurationVersionCloneResponse
+	require.NoError(t, json.Unmarshal([]byte(body), &response))
+	assert.Equal(t, ProductionVersionStatus{
+		Status: VersionStatusActive,
+		Time:   time.Date(2020, 10, 7, 9, 30, 0, 0, time.UTC),
+	}, response.Production)
+	assert.Equal(t, StagingVersionStatus{Status: VersionStatusInactive}, response.Staging)
+}
+
+func TestAppSec_VersionStatus(t *testing.T) {
+	tests := map[string]struct {
+		status        VersionStatus
+		isActive      bool
+		isInactive    bool
+		isPending     bool
+		isDeactivated bool
+		isFailed      bool
+	}{
+		"Active":      {status: "Active", isActive: true},
+		"ACTIVE":      {status: "ACTIVE", isActive: true},
+		"Inactive":    {status: "Inactive", isInactive: true},
+		"INACTIVE":    {status: "INACTIVE", isInactive: true},
+		"Pending":     {status: "Pending", isPending: true},
+		"PENDING":     {status: "PENDING", isPending: true},
+		"Deactivated": {status: "Deactivated", isDeactivated: true},
+		"DEACTIVATED": {status: "DEACTIVATED", isDeactivated: true},
+		"Failed":      {status: "Failed", isFailed: true},
+		"FAILED":      {status: "FAILED", isFailed: true},
+		"unknown":     {status: "Aborted"},
+		"empty":       {status: ""},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.isActive, test.status.IsActive())
+			assert.Equal(t, test.isInactive, test.status.IsInactive())
+			assert.Equal(t, test.isPending, test.status.IsPending())
+			assert.Equal(t, test.isDeactivated, test.status.IsDeactivated())
+			assert.Equal(t, test.isFailed, test.status.IsFailed())
+		})
+	}
+
+	t.Run("unknown status is preserved when unmarshaling", func(t *testing.T) {
+		var result GetConfigurationVersionCloneResponse
+		err := json.Unmarshal([]byte(`{"production":{"status":"Aborted"},"staging":{"status":"Active"}}`), &result)
+		require.NoError(t, err)
+		assert.Equal(t, VersionStatus("Aborted"), result.Production.Status)
+		assert.True(t, result.Staging.Status.IsActive())
+	})
+}
+
+func TestAppSec_CloneAndWaitForRuleUpdate(t *testing.T) {
+	cloneBody := `
+{
+    "configId": 43253,
+    "configName": "Example",
+    "version": 6,
+    "basedOn": 5,
+    "production": {"status": "Inactive"},
+    "staging": {"status": "Inactive"}
+}`
+	pending := `{"current": "ASE_AUTO_v1", "latest": "ASE_AUTO_v2"}`
+	complete := `{"current": "ASE_AUTO_v2", "latest": "ASE_AUTO_v2"}`
+
+	tests := map[string]struct {
+		params           CloneAndWaitForRuleUpdateRequest
+		upgradeBodies    []string
+		cancelAfterCalls int
+		failingMethod    string
+		expectedInterval time.Duration
+		expectedPaths    []string
+		expectedResponse *CreateConfigurationVersionCloneResponse
+		withError        error
+		expectedMessage  string
+	}{
+		"pending then complete": {
+			params:           CloneAndWaitForRuleUpdateRequest{ConfigID: 43253, CreateFromVersion: 5, PolicyID: "AAAA_81230", PollInterval: time.Minute},
+			upgradeBodies:    []string{pending, pending, complete},
+			expectedInterval: time.Minute,
+			expectedPaths: []string{
+				"/appsec/v1/configs/43253/versions",
+				"/appsec/v1/configs/43253/versions/6/security-policies/AAAA_81230/rules/upgrade-details",
+				"/appsec/v1/configs/43253/versions/6/security-policies/AAAA_81230/rules/upgrade-details",
+				"/appsec/v1/configs/43253/versions/6/security-policies/AAAA_81230/rules/upgrade-details",
+			},
+			expectedResponse: &CreateConfigurationVersionCloneResponse{
+				ConfigID:   43253,
+				ConfigName: "Example",
+				Version:    6,
+				BasedOn:    5,
+				Production: ProductionVersionStatus{Status: "Inactive"},
+				Staging:    StagingVersionStatus{Status: "Inactive"},
+			},
+		},
+		"default poll interval": {
+			params:           CloneAndWaitForRuleUpdateRequest{ConfigID: 43253, CreateFromVersion: 5, PolicyID: "AAAA_81230"},
+			upgradeBodies:    []string{pending, complete},
+			expectedInterval: DefaultRuleUpdatePollInterval,
+			expectedPaths: []string{
+				"/appsec/v1/configs/43253/versions",
+				"/appsec/v1/configs/43253/versions/6/security-policies/AAAA_81230/rules/upgrade-details",
+				"/appsec/v1/configs/43253/versions/6/security-policies/AAAA_81230/rules/upgrade-details",
+			},
+			expectedResponse: &CreateConfigurationVersionCloneResponse{
+				ConfigID:   43253,
+				ConfigName: "Example",
+				Version:    6,
+				BasedOn:    5,
+				Production: ProductionVersionStatus{Status: "Inactive"},
+				Staging:    StagingVersionStatus{Status: "Inactive"},
+			},
+		},
+		"context canceled while pending": {
+			params:           CloneAndWaitForRuleUpdateRequest{ConfigID: 43253, CreateFromVersion: 5, PolicyID: "AAAA_81230", PollInterval: time.Hour},
+			upgradeBodies:    []string{pending},
+			cancelAfterCalls: 2,
+			expectedPaths: []string{
+				"/appsec/v1/configs/43253/versions",
+				"/appsec/v1/configs/43253/versions/6/security-policies/AAAA_81230/rules/upgrade-details",
+			},
+			withError:       context.Canceled,
+			expectedMessage: "waiting for rule update of version 6",
+		},
+		"clone fails": {
+			params:        CloneAndWaitForRuleUpdateRequest{ConfigID: 43253, CreateFromVersion: 5, PolicyID: "AAAA_81230"},
+			failingMethod: http.MethodPost,
+			expectedPaths: []string{"/appsec/v1/configs/43253/versions"},
+			withError: &Error{
+				Type:       "internal_error",
+				Title:      "Internal Server Error",
+				Detail:     "Error processing request",
+				StatusCode: http.StatusInternalServerError,
+			},
+			expectedMessage: "cloning version 5 of configuration 43253",
+		},
+		"rule update check fails": {
+			params:        CloneAndWaitForRuleUpdateRequest{ConfigID: 43253, CreateFromVersion: 5, PolicyID: "AAAA_81230"},
+			failingMethod: http.MethodGet,
+			expectedPaths: []string{
+				"/appsec/v1/configs/43253/versions",
+				"/appsec/v1/configs/43253/versions/6/security-policies/AAAA_81230/rules/upgrade-details",
+			},
+			withError: &Error{
+				Type:       "internal_error",
+				Title:      "Internal Server Error",
+				Detail:     "Error processing request",
+				StatusCode: http.StatusInternalServerError,
+			},
+			expectedMessage: "waiting for rule update of version 6",
+		},
+		"validation error": {
+			params:    CloneAndWaitForRuleUpdateRequest{ConfigID: 43253, CreateFromVersion: 5},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			var paths []string
+			var upgradeCalls int
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				paths = append(paths, r.URL.Path)
+				if len(paths) == test.cancelAfterCalls {
+					cancel()
+				}
+				if r.Method == test.failingMethod {
+					w.WriteHeader(http.StatusInternalServerError)
+					_, err := w.Write([]byte(`{"type": "internal_error", "title": "Internal Server Error", "detail": "Error processing request"}`))
+					assert.NoError(t, err)
+					return
+				}
+				if r.Method == http.MethodPost {
+					var body CreateConfigurationVersionCloneRequest
+					require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+					assert.Equal(t, CreateConfigurationVersionCloneRequest{CreateFromVersion: 5, RuleUpdate: true}, body)
+					w.WriteHeader(http.StatusCreated)
+					_, err := w.Write([]byte(cloneBody))
+					assert.NoError(t, err)
+					return
+				}
+				assert.Equal(t, http.MethodGet, r.Method)
+				w.WriteHeader(http.StatusOK)
+				_, err := w.Write([]byte(test.upgradeBodies[upgradeCalls]))
+				assert.NoError(t, err)
+				upgradeCalls++
+			}))
+			defer mockServer.Close()
+			start := time.Date(2022, 10, 27, 12, 0, 0, 0, time.UTC)
+			clock := poll.NewFakeClock(start)
+			client := mockAPIClient(t, mockServer, WithClock(clock))
+
+			type result struct {
+				resp *CreateConfigurationVersionCloneResponse
+				err  error
+			}
+			done := make(chan result)
+			go func() {
+				resp, err := client.CloneAndWaitForRuleUpdate(ctx, test.params)
+				done <- result{resp, err}
+			}()
+
+			if test.expectedResponse != nil {
+				for i := 1; i < len(test.upgradeBodies); i++ {
+					clock.BlockUntil(1)
+					clock.Advance(test.expectedInterval)
+				}
+			}
+			res := <-done
+			assert.Equal(t, test.expectedPaths, paths)
+			if test.withError != nil {
+				assert.True(t, errors.Is(res.err, test.withError), "want: %s; got: %s", test.withError, res.err)
+				assert.Contains(t, res.err.Error(), test.expectedMessage)
+				return
+			}
+			require.NoError(t, res.err)
+			assert.Equal(t, test.expectedResponse, res.resp)
+			assert.Equal(t, start.Add(time.Duration(len(test.upgradeBodies)-1)*test.expectedInterval), clock.Now())
+		})
+	}
+}
+
+func TestAppSec_CreateConfigurationVersionClone_DryRun(t *testing.T) {
+	tests := map[string]struct {
+		params           CreateConfigurationVersionCloneRequest
+		responseStatus   int
+		responseBody     string
+		expectedPaths    []string
+		expectedResponse *CreateConfigurationVersionCloneResponse
+		withError        error
+	}{
+		"source version exists": {
+			params:         CreateConfigurationVersionCloneRequest{ConfigID: 43253, CreateFromVersion: 5, DryRun: true},
+			responseStatus: http.StatusOK,
+			responseBody: `
+{
+    "configId": 43253,
+    "configName": "Example",
+    "version": 5,
+    "basedOn": 3,
+    "production": {"status": "Active"},
+    "staging": {"status": "Active"}
+}`,
+			expectedPaths: []string{"/appsec/v1/configs/43253/versions/5"},
+			expectedResponse: &CreateConfigurationVersionCloneResponse{
+				DryRun: &ConfigurationVersionCloneDryRun{
+					Source: GetConfigurationVersionCloneResponse{
+						ConfigID:   43253,
+						ConfigName: "Example",
+						Version:    5,
+						BasedOn:    3,
+						Production: ProductionVersionStatus{Status: "Active"},
+						Staging:    StagingVersionStatus{Status: "Active"},
+					},
+				},
+			},
+		},
+		"source version does not exist": {
+			params:         CreateConfigurationVersionCloneRequest{ConfigID: 43253, CreateFromVersion: 9, DryRun: true},
+			responseStatus: http.StatusNotFound,
+			responseBody: `
+{
+    "type": "https://problems.luna.akamaiapis.net/appsec/error-types/NOT-FOUND",
+    "title": "Not Found",
+    "detail": "Version 9 of configuration 43253 does not exist",
+    "status": 404
+}`,
+			expectedPaths: []string{"/appsec/v1/configs/43253/versions/9"},
+			withError: &Error{
+				Type:       "https://problems.luna.akamaiapis.net/appsec/error-types/NOT-FOUND",
+				Title:      "Not Found",
+				Detail:     "Version 9 of configuration 43253 does not exist",
+				StatusCode: http.StatusNotFound,
+			},
+		},
+		"validation error": {
+			params:    CreateConfigurationVersionCloneRequest{ConfigID: 43253, DryRun: true},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var paths []string
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodGet, r.Method)
+				paths = append(paths, r.URL.Path)
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			defer mockServer.Close()
+			client := mockAPIClient(t, mockServer)
+			result, err := client.CreateConfigurationVersionClone(context.Background(), test.params)
+			assert.Equal(t, test.expectedPaths, paths)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestAppSec_CreateConfigurationVersionClone_RuleUpdateMode(t *testing.T) {
+	tests := map[string]struct {
+		params             CreateConfigurationVersionCloneRequest
+		expectedRuleUpdate bool
+		expectedDebug      bool
+		withError          error
+	}{
+		"latest rule update": {
+			params:             CreateConfigurationVersionCloneRequest{ConfigID: 43253, CreateFromVersion: 3, RuleUpdateMode: RuleUpdateModeLatest},
+			expectedRuleUpdate: true,
+		},
+		"no rule update": {
+			params: CreateConfigurationVersionCloneRequest{ConfigID: 43253, CreateFromVersion: 3, RuleUpdateMode: RuleUpdateModeNone},
+		},
+		"mode not set, rule update": {
+			params:             CreateConfigurationVersionCloneRequest{ConfigID: 43253, CreateFromVersion: 3, RuleUpdate: true},
+			expectedRuleUpdate: true,
+		},
+		"mode not set, no rule update": {
+			params:        CreateConfigurationVersionCloneRequest{ConfigID: 43253, CreateFromVersion: 3},
+			expectedDebug: true,
+		},
+		"no rule update mode with rule update": {
+			params:    CreateConfigurationVersionCloneRequest{ConfigID: 43253, CreateFromVersion: 3, RuleUpdate: true, RuleUpdateMode: RuleUpdateModeNone},
+			withError: ErrStructValidation,
+		},
+		"invalid mode": {
+			params:    CreateConfigurationVersionCloneRequest{ConfigID: 43253, CreateFromVersion: 3, RuleUpdateMode: "PREVIOUS"},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPost, r.Method)
+				var body map[string]interface{}
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+				assert.Equal(t, map[string]interface{}{"createFromVersion": float64(3), "ruleUpdate": test.expectedRuleUpdate}, body)
+				w.WriteHeader(http.StatusCreated)
+				_, err := w.Write([]byte(`{"configId": 43253, "version": 4, "basedOn": 3}`))
+				assert.NoError(t, err)
+			}))
+			defer mockServer.Close()
+			client := mockAPIClient(t, mockServer)
+			handler := memory.New()
+			ctx := session.ContextWithOptions(context.Background(),
+				session.WithContextLog(&log.Logger{Handler: handler, Level: log.DebugLevel}))
+
+			result, err := client.CreateConfigurationVersionClone(ctx, test.params)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, 4, result.Version)
+
+			var messages []string
+			for _, entry := range handler.Entries {
+				assert.NotEqual(t, log.WarnLevel, entry.Level, "unexpected warning: %s", entry.Message)
+				if strings.Contains(entry.Message, "without rule update") {
+					messages = append(messages, entry.Message)
+				}
+			}
+			if test.expectedDebug {
+				assert.Equal(t, []string{"cloning version 3 of configuration 43253 without rule update, RuleUpdateMode is not set"}, messages)
+			} else {
+				assert.Empty(t, messages)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package appsec_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/appsec"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/edgegrid"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/session"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInterfaces(t *testing.T) {
+	sess, err := session.New(session.WithSigner(&edgegrid.Config{}))
+	require.NoError(t, err)
+	implementations := map[string]interface{}{
+		"client": appsec.Client(sess),
+		"mock":   &appsec.Mock{},
+	}
+	interfaces := []reflect.Type{
+		reflect.TypeOf((*appsec.Activations)(nil)).Elem(),
+		reflect.TypeOf((*appsec.AdvancedSettingsEvasivePathMatch)(nil)).Elem(),
+		reflect.TypeOf((*appsec.AdvancedSettingsLogging)(nil)).Elem(),
+		reflect.TypeOf((*appsec.AdvancedSettingsPragma)(nil)).Elem(),
+		reflect.TypeOf((*appsec.AdvancedSettingsPrefetch)(nil)).Elem(),
+		reflect.TypeOf((*appsec.ApiConstraintsProtection)(nil)).Elem(),
+		reflect.TypeOf((*appsec.ApiEndpoints)(nil)).Elem(),
+		reflect.TypeOf((*appsec.ApiHostnameCoverage)(nil)).Elem(),
+		reflect.TypeOf((*appsec.ApiHostnameCoverageMatchTargets)(nil)).Elem(),
+		reflect.TypeOf((*appsec.ApiHostnameCoverageOverlapping)(nil)).Elem(),
+		reflect.TypeOf((*appsec.ApiRequestConstraints)(nil)).Elem(),
+		reflect.TypeOf((*appsec.AttackGroup)(nil)).Elem(),
+		reflect.TypeOf((*appsec.BypassNetworkLists)(nil)).Elem(),
+		reflect.TypeOf((*appsec.Configuration)(nil)).Elem(),
+		reflect.TypeOf((*appsec.ConfigurationClone)(nil)).Elem(),
+		reflect.TypeOf((*appsec.ConfigurationVersion)(nil)).Elem(),
+		reflect.TypeOf((*appsec.ConfigurationVersionClone)(nil)).Elem(),
+		reflect.TypeOf((*appsec.ContractsGroups)(nil)).Elem(),
+		reflect.TypeOf((*appsec.CustomDeny)(nil)).Elem(),
+		reflect.TypeOf((*appsec.CustomRule)(nil)).Elem(),
+		reflect.TypeOf((*appsec.CustomRuleAction)(nil)).Elem(),
+		reflect.TypeOf((*appsec.Eval)(nil)).Elem(),
+		reflect.TypeOf((*appsec.EvalGroup)(nil)).Elem(),
+		reflect.TypeOf((*appsec.EvalHost)(nil)).Elem(),
+		reflect.TypeOf((*appsec.EvalPenaltyBox)(nil)).Elem(),
+		reflect.TypeOf((*appsec.EvalProtectHost)(nil)).Elem(),
+		reflect.TypeOf((*appsec.EvalRule)(nil)).Elem(),
+		reflect.TypeOf((*appsec.ExportConfiguration)(nil)).Elem(),
+		reflect.TypeOf((*appsec.FailoverHostnames)(nil)).Elem(),
+		reflect.TypeOf((*appsec.IPGeo)(nil)).Elem(),
+		reflect.TypeOf((*appsec.IPGeoProtection)(nil)).Elem(),
+		reflect.TypeOf((*appsec.MalwareContentTypes)(nil)).Elem(),
+		reflect.TypeOf((*appsec.MalwarePolicy)(nil)).Elem(),
+		reflect.TypeOf((*appsec.MalwarePolicyAction)(nil)).Elem(),
+		reflect.TypeOf((*appsec.MalwareProtection)(nil)).Elem(),
+		reflect.TypeOf((*appsec.MatchTarget)(nil)).Elem(),
+		reflect.TypeOf((*appsec.MatchTargetSequence)(nil)).Elem(),
+		reflect.TypeOf((*appsec.NetworkLayerProtection)(nil)).Elem(),
+		reflect.TypeOf((*appsec.PenaltyBox)(nil)).Elem(),
+		reflect.TypeOf((*appsec.PolicyProtections)(nil)).Elem(),
+		reflect.TypeOf((*appsec.RatePolicy)(nil)).Elem(),
+		reflect.TypeOf((*appsec.RatePolicyAction)(nil)).Elem(),
+		reflect.TypeOf((*appsec.RateProtection)(nil)).Elem(),
+		reflect.TypeOf((*appsec.ReputationAnalysis)(nil)).Elem(),
+		reflect.TypeOf((*appsec.ReputationProfile)(nil)).Elem(),
+		reflect.TypeOf((*appsec.ReputationProfileAction)(nil)).Elem(),
+		reflect.TypeOf((*appsec.ReputationProtection)(nil)).Elem(),
+		reflect.TypeOf((*appsec.Rule)(nil)).Elem(),
+		reflect.TypeOf((*appsec.RuleUpgrade)(nil)).Elem(),
+		reflect.TypeOf((*appsec.SecurityPolicy)(nil)).Elem(),
+		reflect.TypeOf((*appsec.SecurityPolicyClone)(nil)).Elem(),
+		reflect.TypeOf((*appsec.SelectableHostnames)(nil)).Elem(),
+		reflect.TypeOf((*appsec.SelectedHostname)(nil)).Elem(),
+		reflect.TypeOf((*appsec.SiemDefinitions)(nil)).Elem(),
+		reflect.TypeOf((*appsec.SiemSettings)(nil)).Elem(),
+		reflect.TypeOf((*appsec.SlowPostProtection)(nil)).Elem(),
+		reflect.TypeOf((*appsec.SlowPostProtectionSetting)(nil)).Elem(),
+		reflect.TypeOf((*appsec.ThreatIntel)(nil)).Elem(),
+		reflect.TypeOf((*appsec.TuningRecommendations)(nil)).Elem(),
+		reflect.TypeOf((*appsec.VersionNotes)(nil)).Elem(),
+		reflect.TypeOf((*appsec.WAFMode)(nil)).Elem(),
+		reflect.TypeOf((*appsec.WAFProtection)(nil)).Elem(),
+		reflect.TypeOf((*appsec.WAPBypassNetworkLists)(nil)).Elem(),
+		reflect.TypeOf((*appsec.WAPSelectedHostnames)(nil)).Elem(),
+	}
+
+	for name, implementation := range implementations {
+		t.Run(name, func(t *testing.T) {
+			for _, i := range interfaces {
+				assert.True(t, reflect.TypeOf(implementation).Implements(i), "%T does not implement %s", implementation, i)
+			}
+		})
+	}
+}
@@ -12,6 +12,16 @@ type Mock struct {
 	mock.Mock
 }
 
+type (
+	// GetConfigurationVersionCloneFn is any function having the same signature as GetConfigurationVersionClone
+	GetConfigurationVersionCloneFn func(context.Context, GetConfigurationVersionCloneRequest) (*GetConfigurationVersionCloneResponse, error)
+
+	// CreateConfigurationVersionCloneFn is any function having the same signature as CreateConfigurationVersionClone
+	CreateConfigurationVersionCloneFn func(context.Context, CreateConfigurationVersionCloneRequest) (*CreateConfigurationVersionCloneResponse, error)
+)
+
+var _ APPSEC = &Mock{}
+
 func (m *Mock) UpdateWAPSelectedHostnames(ctx context.Context, req UpdateWAPSelectedHostnamesRequest) (*UpdateWAPSelectedHostnamesResponse, error) {
 	args := m.Called(ctx, req)
 	if args.Get(0) == nil {
@@ -1131,6 +1141,34 @@ func (m *Mock) GetConfigurationVersionClone(ctx context.Context, req GetConfigur
 	return args.Get(0).(*GetConfigurationVersionCloneResponse), args.Error(1)
 }
 
+func (m *Mock) OnGetConfigurationVersionClone(ctx, req interface{}, impl GetConfigurationVersionCloneFn) *mock.Call {
+	call := m.On("GetConfigurationVersionClone", ctx, req)
+	call.Run(func(CallArgs mock.Arguments) {
+		callCtx := CallArgs.Get(0).(context.Context)
+		callReq := CallArgs.Get(1).(GetConfigurationVersionCloneRequest)
+
+		call.Return(impl(callCtx, callReq))
+	})
+
+	return call
+}
+
+func (m *Mock) GetVersionLineage(ctx context.Context, req GetVersionLineageRequest) (*GetVersionLineageResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*GetVersionLineageResponse), args.Error(1)
+}
+
+func (m *Mock) CloneAndWaitForRuleUpdate(ctx context.Context, req CloneAndWaitForRuleUpdateRequest) (*CreateConfigurationVersionCloneResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*CreateConfigurationVersionCloneResponse), args.Error(1)
+}
+
 func (m *Mock) GetConfigurationClone(ctx context.Context, req GetConfigurationCloneRequest) (*GetConfigurationCloneResponse, error) {
 	args := m.Called(ctx, req)
 	if args.Get(0) == nil {
@@ -1331,6 +1369,18 @@ func (m *Mock) CreateConfigurationVersionClone(ctx context.Context, req CreateCo
 	return args.Get(0).(*CreateConfigurationVersionCloneResponse), args.Error(1)
 }
 
+func (m *Mock) OnCreateConfigurationVersionClone(ctx, req interface{}, impl CreateConfigurationVersionCloneFn) *mock.Call {
+	call := m.On("CreateConfigurationVersionClone", ctx, req)
+	call.Run(func(CallArgs mock.Arguments) {
+		callCtx := CallArgs.Get(0).(context.Context)
+		callReq := CallArgs.Get(1).(CreateConfigurationVersionCloneRequest)
+
+		call.Return(impl(callCtx, callReq))
+	})
+
+	return call
+}
+
 func (m *Mock) CreateConfigurationClone(ctx context.Context, req CreateConfigurationCloneRequest) (*CreateConfigurationCloneResponse, error) {
 	args := m.Called(ctx, req)
 	if args.Get(0) == nil {
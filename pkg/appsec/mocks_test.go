@@ -0,0 +1,40 @@
+package appsec
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMock(t *testing.T) {
+	ctx := context.Background()
+	m := &Mock{}
+	var client APPSEC = m
+
+	m.OnGetConfigurationVersionClone(ctx, mock.Anything, func(_ context.Context, r GetConfigurationVersionCloneRequest) (*GetConfigurationVersionCloneResponse, error) {
+		return &GetConfigurationVersionCloneResponse{ConfigID: r.ConfigID, Version: r.Version, BasedOn: r.Version - 1}, nil
+	}).Once()
+	m.OnCreateConfigurationVersionClone(ctx, CreateConfigurationVersionCloneRequest{ConfigID: 43253, CreateFromVersion: 5}, func(_ context.Context, r CreateConfigurationVersionCloneRequest) (*CreateConfigurationVersionCloneResponse, error) {
+		return &CreateConfigurationVersionCloneResponse{ConfigID: r.ConfigID, Version: 6, BasedOn: r.CreateFromVersion}, nil
+	}).Once()
+	m.OnCreateConfigurationVersionClone(ctx, CreateConfigurationVersionCloneRequest{ConfigID: 43253, CreateFromVersion: 9}, func(context.Context, CreateConfigurationVersionCloneRequest) (*CreateConfigurationVersionCloneResponse, error) {
+		return nil, ErrStructValidation
+	}).Once()
+
+	version, err := client.GetConfigurationVersionClone(ctx, GetConfigurationVersionCloneRequest{ConfigID: 43253, Version: 5})
+	require.NoError(t, err)
+	assert.Equal(t, &GetConfigurationVersionCloneResponse{ConfigID: 43253, Version: 5, BasedOn: 4}, version)
+
+	clone, err := client.CreateConfigurationVersionClone(ctx, CreateConfigurationVersionCloneRequest{ConfigID: 43253, CreateFromVersion: 5})
+	require.NoError(t, err)
+	assert.Equal(t, &CreateConfigurationVersionCloneResponse{ConfigID: 43253, Version: 6, BasedOn: 5}, clone)
+
+	_, err = client.CreateConfigurationVersionClone(ctx, CreateConfigurationVersionCloneRequest{ConfigID: 43253, CreateFromVersion: 9})
+	assert.True(t, errors.Is(err, ErrStructValidation), "want: %s; got: %s", ErrStructValidation, err)
+
+	m.AssertExpectations(t)
+}
@@ -19,6 +19,9 @@ const (
 	// DefaultSection is the .edgerc ini default section
 	DefaultSection = "default"
 
+	// DefaultEnvPrefix is the prefix of the environment variables used to populate the config
+	DefaultEnvPrefix = "AKAMAI"
+
 	// MaxBodySize is the max payload size for client requests
 	MaxBodySize = 131072
 )
@@ -32,6 +35,8 @@ var (
 	ErrLoadingFile = errors.New("loading config file")
 	// ErrSectionDoesNotExist is returned when a section with provided name does not exist in edgerc
 	ErrSectionDoesNotExist = errors.New("provided config section does not exist")
+	// ErrMissingCredentials is returned when the loaded configuration has an empty host or credential
+	ErrMissingCredentials = errors.New("missing credentials")
 	// ErrHostContainsSlashAtTheEnd is returned when host has unnecessary '/' at the end
 	ErrHostContainsSlashAtTheEnd = errors.New("host must not contain '/' at the end")
 )
@@ -49,9 +54,10 @@ type (
 		MaxBody      int      `ini:"max_body"`
 		Debug        bool     `ini:"debug"`
 
-		file    string
-		section string
-		env     bool
+		file      string
+		section   string
+		env       bool
+		envPrefix string
 	}
 
 	// Option defines a configuration option
@@ -61,8 +67,9 @@ type (
 // New returns new configuration with the specified options
 func New(opts ...Option) (*Config, error) {
 	c := &Config{
-		section: DefaultSection,
-		env:     false,
+		section:   DefaultSection,
+		env:       false,
+		envPrefix: DefaultEnvPrefix,
 	}
 
 	for _, opt := range opts {
@@ -70,9 +77,12 @@ func New(opts ...Option) (*Config, error) {
 	}
 
 	if c.env {
-		if err := c.FromEnv(c.section); err == nil {
+		if err := c.FromEnvWithPrefix(c.envPrefix, c.section); err == nil {
+			if err := c.validateCredentials(); err != nil {
+				return nil, err
+			}
 			return c, nil
-		} else if !errors.Is(err, ErrRequiredOptionEnv) {
+		} else if !errors.Is(err, ErrRequiredOptionEnv) || c.file == "" {
 			return nil, err
 		}
 	}
@@ -81,6 +91,9 @@ func New(opts ...Option) (*Config, error) {
 		if err := c.FromFile(c.file, c.section); err != nil {
 			return c, fmt.Errorf("unable to load config from environment or .edgerc file: %w", err)
 		}
+		if err := c.validateCredentials(); err != nil {
+			return nil, fmt.Errorf("invalid section %q of %s: %w", c.section, c.file, err)
+		}
 	}
 
 	return c, nil
@@ -116,6 +129,16 @@ func WithEnv(env bool) Option {
 	}
 }
 
+// WithEnvPrefix populates the config from environment variables starting with the prefix instead of AKAMAI,
+// e.g. passing "MYTOOL" will cause it to look for MYTOOL_HOST, etc., see FromEnvWithPrefix
+// If no config file is set, New returns an error when any of the required variables is missing
+func WithEnvPrefix(prefix string) Option {
+	return func(c *Config) {
+		c.env = true
+		c.envPrefix = prefix
+	}
+}
+
 // FromFile creates a config the configuration in standard INI format
 func (c *Config) FromFile(file string, section string) error {
 	var (
@@ -165,15 +188,24 @@ func (c *Config) FromFile(file string, section string) error {
 //
 // If AKAMAI_{SECTION} does not exist, it will fall back to just AKAMAI_.
 func (c *Config) FromEnv(section string) error {
+	return c.FromEnvWithPrefix(DefaultEnvPrefix, section)
+}
+
+// FromEnvWithPrefix creates a new config using the Environment (ENV), like FromEnv,
+// but with variables starting with the prefix instead of AKAMAI, e.g. passing "MYTOOL" and "ccu"
+// will cause it to look for MYTOOL_CCU_HOST, MYTOOL_CCU_CLIENT_TOKEN, etc.
+//
+// All of the HOST, CLIENT_TOKEN, CLIENT_SECRET and ACCESS_TOKEN variables are required,
+// the returned error lists every one which is missing.
+func (c *Config) FromEnvWithPrefix(prefix, section string) error {
 	var (
 		requiredOptions = []string{"HOST", "CLIENT_TOKEN", "CLIENT_SECRET", "ACCESS_TOKEN"}
-		prefix          string
+		missing         []string
 	)
 
-	prefix = "AKAMAI"
-
+	prefix = strings.ToUpper(prefix)
 	if section != DefaultSection {
-		prefix = "AKAMAI_" + strings.ToUpper(section)
+		prefix = prefix + "_" + strings.ToUpper(section)
 	}
 
 	for _, opt := range requiredOptions {
@@ -181,7 +213,8 @@ func (c *Config) FromEnv(section string) error {
 
 		val, ok := os.LookupEnv(optKey)
 		if !ok {
-			return fmt.Errorf("%w: %q", ErrRequiredOptionEnv, optKey)
+			missing = append(missing, strconv.Quote(optKey))
+			continue
 		}
 		switch {
 		case opt == "HOST":
@@ -195,6 +228,10 @@ func (c *Config) FromEnv(section string) error {
 		}
 	}
 
+	if len(missing) > 0 {
+		return fmt.Errorf("%w: %s", ErrRequiredOptionEnv, strings.Join(missing, ", "))
+	}
+
 	c.MaxBody = 0
 
 	val := os.Getenv(fmt.Sprintf("%s_%s", prefix, "MAX_BODY"))
@@ -221,6 +258,29 @@ func Timestamp(t time.Time) string {
 	return t.Format("20060102T15:04:05-0700")
 }
 
+// validateCredentials verifies that the fields required to sign requests are not empty,
+// so that a malformed configuration fails when it is loaded rather than on the first request
+func (c *Config) validateCredentials() error {
+	var missing []string
+	for _, field := range []struct {
+		name  string
+		value string
+	}{
+		{"host", c.Host},
+		{"client_token", c.ClientToken},
+		{"client_secret", c.ClientSecret},
+		{"access_token", c.AccessToken},
+	} {
+		if field.value == "" {
+			missing = append(missing, field.name)
+		}
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("%w: %s", ErrMissingCredentials, strings.Join(missing, ", "))
+	}
+	return nil
+}
+
 // Validate verifies that the host is not ending with the slash character
 func (c *Config) Validate() error {
 	if strings.HasSuffix(c.Host, "/") {
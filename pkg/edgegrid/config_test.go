@@ -3,7 +3,9 @@ package edgegrid
 import (
 	"errors"
 	"fmt"
+	"net/http"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -207,6 +209,129 @@ func TestConfig_FromEnv(t *testing.T) {
 	}
 }
 
+func TestNew_WithEnvPrefix(t *testing.T) {
+	tests := map[string]struct {
+		options   []Option
+		envs      map[string]string
+		expected  *Config
+		withError string
+	}{
+		"custom prefix": {
+			options: []Option{WithEnvPrefix("mytool")},
+			envs: map[string]string{
+				"MYTOOL_HOST":          "akab-host.luna.akamaiapis.net",
+				"MYTOOL_CLIENT_TOKEN":  "akab-client-token",
+				"MYTOOL_CLIENT_SECRET": "client-secret",
+				"MYTOOL_ACCESS_TOKEN":  "akab-access-token",
+			},
+			expected: &Config{
+				Host:         "akab-host.luna.akamaiapis.net",
+				ClientToken:  "akab-client-token",
+				ClientSecret: "client-secret",
+				AccessToken:  "akab-access-token",
+				MaxBody:      MaxBodySize,
+			},
+		},
+		"custom prefix and section": {
+			options: []Option{WithEnvPrefix("MYTOOL"), WithSection("ccu")},
+			envs: map[string]string{
+				"MYTOOL_CCU_HOST":          "akab-ccu-host.luna.akamaiapis.net",
+				"MYTOOL_CCU_CLIENT_TOKEN":  "akab-ccu-client-token",
+				"MYTOOL_CCU_CLIENT_SECRET": "ccu-client-secret",
+				"MYTOOL_CCU_ACCESS_TOKEN":  "akab-ccu-access-token",
+				"MYTOOL_CCU_ACCOUNT_KEY":   "1-ABCDE",
+			},
+			expected: &Config{
+				Host:         "akab-ccu-host.luna.akamaiapis.net",
+				ClientToken:  "akab-ccu-client-token",
+				ClientSecret: "ccu-client-secret",
+				AccessToken:  "akab-ccu-access-token",
+				AccountKey:   "1-ABCDE",
+				MaxBody:      MaxBodySize,
+			},
+		},
+		"missing variables": {
+			options: []Option{WithEnvPrefix("MYTOOL")},
+			envs: map[string]string{
+				"MYTOOL_HOST":         "akab-host.luna.akamaiapis.net",
+				"MYTOOL_ACCESS_TOKEN": "akab-access-token",
+			},
+			withError: `required option is missing from env: "MYTOOL_CLIENT_TOKEN", "MYTOOL_CLIENT_SECRET"`,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			for k, v := range test.envs {
+				require.NoError(t, os.Setenv(k, v))
+			}
+			defer func() {
+				for k := range test.envs {
+					require.NoError(t, os.Unsetenv(k))
+				}
+			}()
+			cfg, err := New(test.options...)
+			if test.withError != "" {
+				assert.True(t, errors.Is(err, ErrRequiredOptionEnv), "want: %v; got: %v", ErrRequiredOptionEnv, err)
+				assert.EqualError(t, err, test.withError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected.Host, cfg.Host)
+			assert.Equal(t, test.expected.ClientToken, cfg.ClientToken)
+			assert.Equal(t, test.expected.ClientSecret, cfg.ClientSecret)
+			assert.Equal(t, test.expected.AccessToken, cfg.AccessToken)
+			assert.Equal(t, test.expected.AccountKey, cfg.AccountKey)
+			assert.Equal(t, test.expected.MaxBody, cfg.MaxBody)
+
+			req, err := http.NewRequest(http.MethodGet, "/papi/v1/contracts", nil)
+			require.NoError(t, err)
+			cfg.SignRequest(req)
+			assert.Equal(t, test.expected.Host, req.URL.Host)
+			auth := req.Header.Get("Authorization")
+			assert.True(t, strings.HasPrefix(auth, fmt.Sprintf("EG1-HMAC-SHA256 client_token=%s;access_token=%s;", test.expected.ClientToken, test.expected.AccessToken)), auth)
+			assert.Contains(t, auth, ";signature=")
+		})
+	}
+}
+
+func TestNew_MissingCredentials(t *testing.T) {
+	tests := map[string]struct {
+		options   []Option
+		envs      map[string]string
+		withError string
+	}{
+		"empty values in edgerc section": {
+			options:   []Option{WithFile("test/edgerc"), WithSection("empty-credentials")},
+			withError: `invalid section "empty-credentials" of test/edgerc: missing credentials: client_token, client_secret`,
+		},
+		"empty environment variables": {
+			options: []Option{WithEnvPrefix("MYTOOL")},
+			envs: map[string]string{
+				"MYTOOL_HOST":          "",
+				"MYTOOL_CLIENT_TOKEN":  "akab-client-token",
+				"MYTOOL_CLIENT_SECRET": "client-secret",
+				"MYTOOL_ACCESS_TOKEN":  "",
+			},
+			withError: "missing credentials: host, access_token",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			for k, v := range test.envs {
+				require.NoError(t, os.Setenv(k, v))
+			}
+			defer func() {
+				for k := range test.envs {
+					require.NoError(t, os.Unsetenv(k))
+				}
+			}()
+			_, err := New(test.options...)
+			assert.True(t, errors.Is(err, ErrMissingCredentials), "want: %v; got: %v", ErrMissingCredentials, err)
+			assert.EqualError(t, err, test.withError)
+		})
+	}
+}
+
 func TestConfig_Validate(t *testing.T) {
 	tests := map[string]struct {
 		fileName        string
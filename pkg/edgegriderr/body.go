@@ -0,0 +1,32 @@
+package edgegriderr
+
+import (
+	"mime"
+	"net/http"
+	"strings"
+)
+
+// MaxBodySnippetLength is the number of bytes of a non-JSON error body kept by BodySnippet
+const MaxBodySnippetLength = 512
+
+// IsJSONContent reports whether an error body should be parsed as JSON, based on its content type
+// If the content type is not set, it is detected from the body, so that plain text is still attempted as JSON
+func IsJSONContent(contentType string, body []byte) bool {
+	if contentType == "" {
+		contentType = http.DetectContentType(body)
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return true
+	}
+	return mediaType == "application/json" || strings.HasSuffix(mediaType, "+json") || mediaType == "text/plain"
+}
+
+// BodySnippet returns the beginning of an error body as valid UTF-8, e.g. to report an HTML error page
+// returned by a proxy or gateway in front of the API
+func BodySnippet(body []byte) string {
+	if len(body) > MaxBodySnippetLength {
+		body = body[:MaxBodySnippetLength]
+	}
+	return strings.ToValidUTF8(string(body), "\uFFFD")
+}
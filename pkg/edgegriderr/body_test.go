@@ -0,0 +1,74 @@
+package edgegriderr
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tj/assert"
+)
+
+func TestIsJSONContent(t *testing.T) {
+	tests := map[string]struct {
+		contentType string
+		body        string
+		expected    bool
+	}{
+		"json": {
+			contentType: "application/json",
+			body:        `{"title": "Bad Request"}`,
+			expected:    true,
+		},
+		"problem json with parameters": {
+			contentType: "application/problem+json; charset=utf-8",
+			body:        `{"title": "Bad Request"}`,
+			expected:    true,
+		},
+		"plain text": {
+			contentType: "text/plain",
+			body:        "Bad Request",
+			expected:    true,
+		},
+		"html": {
+			contentType: "text/html; charset=iso-8859-1",
+			body:        "<html><body>Bad Gateway</body></html>",
+		},
+		"html without content type": {
+			body: "<html><body>Bad Gateway</body></html>",
+		},
+		"invalid content type": {
+			contentType: "text/",
+			body:        "<html><body>Bad Gateway</body></html>",
+			expected:    true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, IsJSONContent(test.contentType, []byte(test.body)))
+		})
+	}
+}
+
+func TestBodySnippet(t *testing.T) {
+	tests := map[string]struct {
+		body     string
+		expected string
+	}{
+		"short body": {
+			body:     "Bad Gateway",
+			expected: "Bad Gateway",
+		},
+		"invalid UTF-8": {
+			body:     "caf\xe9",
+			expected: "caf\uFFFD",
+		},
+		"truncated": {
+			body:     strings.Repeat("a", MaxBodySnippetLength+10),
+			expected: strings.Repeat("a", MaxBodySnippetLength),
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, BodySnippet([]byte(test.body)))
+		})
+	}
+}
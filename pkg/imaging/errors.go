@@ -6,6 +6,10 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"strconv"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/edgegriderr"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/session"
 )
 
 type (
@@ -26,6 +30,11 @@ type (
 		ClientIP        string            `json:"clientIp,omitempty"`
 		RequestTime     string            `json:"requestTime,omitempty"`
 		AuthzRealm      string            `json:"authzRealm,omitempty"`
+		Limit           int               `json:"limit,omitempty"`
+		Remaining       int               `json:"remaining,omitempty"`
+		RetryAfter      int               `json:"retryAfter,omitempty"`
+
+		err error
 	}
 )
 
@@ -39,17 +48,50 @@ func (i *imaging) Error(r *http.Response) error {
 		e.Status = r.StatusCode
 		e.Title = "Failed to read error body"
 		e.Detail = err.Error()
+		e.err = err
 		return &e
 	}
 
-	if err := json.Unmarshal(body, &e); err != nil {
-		i.Log(r.Request.Context()).Errorf("could not unmarshal API error: %s", err)
-		e.Title = string(body)
+	if len(body) > 0 && !edgegriderr.IsJSONContent(r.Header.Get("Content-Type"), body) {
+		// gateways and proxies may respond with an HTML page instead of a problem JSON
+		i.Log(r.Request.Context()).Errorf("API error response is not JSON: %s", r.Header.Get("Content-Type"))
+		e.Title = "Non-JSON error response"
+		e.Detail = edgegriderr.BodySnippet(body)
 		e.Status = r.StatusCode
+	} else if err := json.Unmarshal(body, &e); err != nil {
+		i.Log(r.Request.Context()).Errorf("could not unmarshal API error: %s", err)
+		e = Error{Status: r.StatusCode}
+		if len(body) > 0 {
+			// the content type may be missing or wrong, so the body is reported as it is
+			e.Title = "Non-JSON error response"
+			e.Detail = edgegriderr.BodySnippet(body)
+		}
 	}
+	e.applyHeaders(r.StatusCode, r.Header)
 	return &e
 }
 
+// applyHeaders sets RetryAfter to the seconds of the Retry-After header and fills in the rate limit details
+// of 429 responses from the response headers, as some of them only report them there. Limits present in the body take precedence
+func (e *Error) applyHeaders(statusCode int, h http.Header) {
+	if seconds, err := strconv.Atoi(h.Get("Retry-After")); err == nil && seconds >= 0 {
+		e.RetryAfter = seconds
+	}
+	if statusCode != http.StatusTooManyRequests || e.Limit != 0 {
+		return
+	}
+	if rl, ok := session.ParseRateLimit(h); ok {
+		e.Limit = rl.Limit
+		e.Remaining = rl.Remaining
+	}
+}
+
+// Unwrap returns the error which occurred while reading the error response body, e.g. session.ErrResponseBodyTooLarge,
+// or nil if the body was read
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
 func (e *Error) Error() string {
 	msg, err := json.MarshalIndent(e, "", "\t")
 	if err != nil {
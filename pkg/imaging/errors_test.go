@@ -1,16 +1,28 @@
 package imaging
 
 import (
+	"errors"
+	"fmt"
 	"io/ioutil"
 	"net/http"
 	"strings"
 	"testing"
+	"testing/iotest"
 
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/edgegriderr"
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/session"
 	"github.com/stretchr/testify/require"
 	"github.com/tj/assert"
 )
 
+const htmlErrorPage = `<html>
+<head><title>502 Bad Gateway</title></head>
+<body>
+<h1>Bad Gateway</h1>
+<p>The proxy server received an invalid response from an upstream server.</p>
+</body>
+</html>`
+
 func TestNewError(t *testing.T) {
 	sess, err := session.New()
 	require.NoError(t, err)
@@ -81,11 +93,52 @@ func TestNewError(t *testing.T) {
 				Request: req,
 			},
 			expected: &Error{
-				Title:  "test",
-				Detail: "",
+				Title:  "Non-JSON error response",
+				Detail: "test",
 				Status: http.StatusInternalServerError,
 			},
 		},
+		"HTML error page with JSON content type": {
+			response: &http.Response{
+				Status:     "Bad Gateway",
+				StatusCode: http.StatusBadGateway,
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+				Body:       ioutil.NopCloser(strings.NewReader(htmlErrorPage)),
+				Request:    req,
+			},
+			expected: &Error{
+				Title:  "Non-JSON error response",
+				Detail: htmlErrorPage,
+				Status: http.StatusBadGateway,
+			},
+		},
+		"HTML error page, status code 502": {
+			response: &http.Response{
+				Status:     "Bad Gateway",
+				StatusCode: http.StatusBadGateway,
+				Header:     http.Header{"Content-Type": []string{"text/html; charset=iso-8859-1"}},
+				Body:       ioutil.NopCloser(strings.NewReader(htmlErrorPage + "caf\xe9")),
+				Request:    req,
+			},
+			expected: &Error{
+				Title:  "Non-JSON error response",
+				Detail: htmlErrorPage + "caf\uFFFD",
+				Status: http.StatusBadGateway,
+			},
+		},
+		"HTML error page without content type, truncated": {
+			response: &http.Response{
+				Status:     "Bad Gateway",
+				StatusCode: http.StatusBadGateway,
+				Body:       ioutil.NopCloser(strings.NewReader(htmlErrorPage + strings.Repeat("<p>padding</p>", 100))),
+				Request:    req,
+			},
+			expected: &Error{
+				Title:  "Non-JSON error response",
+				Detail: (htmlErrorPage + strings.Repeat("<p>padding</p>", 100))[:edgegriderr.MaxBodySnippetLength],
+				Status: http.StatusBadGateway,
+			},
+		},
 	}
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
@@ -95,6 +148,93 @@ func TestNewError(t *testing.T) {
 	}
 }
 
+func TestError_ReadBodyFails(t *testing.T) {
+	sess, err := session.New()
+	require.NoError(t, err)
+	req, err := http.NewRequest(http.MethodGet, "/", nil)
+	require.NoError(t, err)
+
+	readErr := fmt.Errorf("%w: exceeds 256 bytes", session.ErrResponseBodyTooLarge)
+	res := Client(sess).(*imaging).Error(&http.Response{
+		StatusCode: http.StatusBadRequest,
+		Body:       ioutil.NopCloser(iotest.ErrReader(readErr)),
+		Request:    req,
+	})
+
+	assert.True(t, errors.Is(res, session.ErrResponseBodyTooLarge), "want: %s; got: %s", session.ErrResponseBodyTooLarge, res)
+	e, ok := res.(*Error)
+	require.True(t, ok)
+	assert.Equal(t, "Failed to read error body", e.Title)
+	assert.Equal(t, http.StatusBadRequest, e.Status)
+}
+
+func TestError_RateLimitHeaders(t *testing.T) {
+	sess, err := session.New()
+	require.NoError(t, err)
+	req, err := http.NewRequest(http.MethodGet, "/", nil)
+	require.NoError(t, err)
+
+	rateLimitHeaders := http.Header{
+		"X-Ratelimit-Limit":     []string{"100"},
+		"X-Ratelimit-Remaining": []string{"0"},
+		"Retry-After":           []string{"30"},
+	}
+	tests := map[string]struct {
+		statusCode         int
+		header             http.Header
+		body               string
+		expectedLimit      int
+		expectedRemaining  int
+		expectedRetryAfter int
+	}{
+		"empty body with headers only": {
+			statusCode:         http.StatusTooManyRequests,
+			header:             rateLimitHeaders,
+			expectedLimit:      100,
+			expectedRetryAfter: 30,
+		},
+		"non-JSON body with headers only": {
+			statusCode:        http.StatusTooManyRequests,
+			header:            http.Header{"Content-Type": []string{"text/html"}, "X-Ratelimit-Limit": []string{"100"}, "X-Ratelimit-Remaining": []string{"2"}},
+			body:              "<html><body>Too many requests</body></html>",
+			expectedLimit:     100,
+			expectedRemaining: 2,
+		},
+		"limits in body take precedence": {
+			statusCode:         http.StatusTooManyRequests,
+			header:             rateLimitHeaders,
+			body:               `{"type": "testType", "title": "Too many requests", "status": 429, "limit": 20, "remaining": 0}`,
+			expectedLimit:      20,
+			expectedRetryAfter: 30,
+		},
+		"limits not read from headers of other errors": {
+			statusCode:         http.StatusServiceUnavailable,
+			header:             rateLimitHeaders,
+			expectedRetryAfter: 30,
+		},
+		"no headers": {
+			statusCode: http.StatusTooManyRequests,
+			header:     http.Header{},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			res := Client(sess).(*imaging).Error(&http.Response{
+				StatusCode: test.statusCode,
+				Header:     test.header,
+				Body:       ioutil.NopCloser(strings.NewReader(test.body)),
+				Request:    req,
+			})
+
+			e, ok := res.(*Error)
+			require.True(t, ok)
+			assert.Equal(t, test.expectedLimit, e.Limit)
+			assert.Equal(t, test.expectedRemaining, e.Remaining)
+			assert.Equal(t, test.expectedRetryAfter, e.RetryAfter)
+		})
+	}
+}
+
 func TestAs(t *testing.T) {
 	tests := map[string]struct {
 		err      Error
@@ -2,11 +2,20 @@ package papi
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
 	"net/url"
-
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/poll"
+	"github.com/apex/log"
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/spf13/cast"
 )
@@ -27,9 +36,33 @@ type (
 		// See: https://developer.akamai.com/api/core_features/property_manager/v1.html#getpropertyactivation
 		GetActivation(context.Context, GetActivationRequest) (*GetActivationResponse, error)
 
+		// GetActivationErrors returns the errors and warnings reported for an activation which ended with FAILED status
+		GetActivationErrors(context.Context, GetActivationErrorsRequest) (*GetActivationErrorsResponse, error)
+
 		// CancelActivation allows for canceling an activation while it is still PENDING
 		// See: https://developer.akamai.com/api/core_features/property_manager/v1.html#deletepropertyactivation
 		CancelActivation(context.Context, CancelActivationRequest) (*CancelActivationResponse, error)
+
+		// WaitForActivation polls the activation until it reaches a final status or the context is done
+		WaitForActivation(context.Context, WaitForActivationRequest) (*GetActivationResponse, error)
+
+		// WatchActivation polls the activation and sends it on the returned channel each time its status changes,
+		// until it reaches a final status or the context is done
+		WatchActivation(context.Context, WaitForActivationRequest) (<-chan ActivationUpdate, error)
+
+		// CreateActivationAcknowledgingWarnings creates an activation without acknowledging warnings and, if the API reports
+		// unacknowledged warnings, re-submits it acknowledging them only if all of them are accepted by the request filter
+		CreateActivationAcknowledgingWarnings(context.Context, CreateActivationAcknowledgingWarningsRequest) (*CreateActivationResponse, error)
+
+		// ListGroupActivations lists the properties of a group and fetches the activations of each of them concurrently
+		ListGroupActivations(context.Context, ListGroupActivationsRequest) (*ListGroupActivationsResponse, error)
+
+		// RollbackActivation activates again the version which was active on the network before the currently active one,
+		// as found in the activation history of the property
+		RollbackActivation(context.Context, RollbackActivationRequest) (*RollbackActivationResponse, error)
+
+		// GetActivationsByNetwork returns the property activations grouped by network, e.g. to build a combined timeline
+		GetActivationsByNetwork(context.Context, GetActivationsRequest) (*GetActivationsByNetworkResponse, error)
 	}
 
 	// ActivationFallbackInfo encapsulates information about fast fallback, which may allow you to fallback to a previous activation when
@@ -65,6 +98,10 @@ type (
 		UpdateDate             string                  `json:"updateDate,omitempty"`
 		Note                   string                  `json:"note,omitempty"`
 		NotifyEmails           []string                `json:"notifyEmails"`
+
+		// AdditionalFields holds the fields returned by the API which are not mapped to the struct, e.g. progress indicators
+		// of long running activations. They are not sent back to the API.
+		AdditionalFields map[string]json.RawMessage `json:"-"`
 	}
 
 	// CreateActivationRequest is the request parameters for a new activation or deactivation request
@@ -73,6 +110,20 @@ type (
 		ContractID string
 		GroupID    string
 		Activation Activation
+
+		// Precheck, for deactivations, confirms that the property version is active on the network before submitting
+		// the request, and fails with ErrVersionNotActive otherwise. It costs an additional GET request
+		Precheck bool
+
+		// OnlyIfNotActive, for activations, skips the request if the property version is already active on the network
+		// and returns the activation which activated it instead, e.g. to make reconciliation loops idempotent.
+		// The returned ActivationID is empty if that activation is no longer listed. It costs up to two additional GET requests
+		OnlyIfNotActive bool
+
+		// ETag, if set, is sent in the If-Match header, so that the request is rejected with an error matching
+		// ErrPreconditionFailed if the property version has changed since its etag was read,
+		// e.g. to avoid deactivating a version based on a stale view of it
+		ETag string
 	}
 
 	// ActivationsItems are the activation items array from a response
@@ -95,7 +146,7 @@ type (
 	CreateActivationResponse struct {
 		Response
 		ActivationID   string
-		ActivationLink string `json:"activationLink"`
+		ActivationLink Link `json:"activationLink"`
 	}
 
 	// GetActivationsRequest is the get activation request
@@ -103,6 +154,23 @@ type (
 		PropertyID string
 		ContractID string
 		GroupID    string
+
+		// Since, if set, limits the returned activations to the ones submitted after it.
+		// The API does not support such filter, so the activations are filtered by their SubmitDate after being fetched
+		Since time.Time
+
+		// ActivationType, if set, limits the returned activations to the ones of this type.
+		// The API does not support such filter, so the activations are filtered after being fetched
+		ActivationType ActivationType
+
+		// CreatedBy, if set, limits the returned activations to the ones submitted by this user.
+		// The API does not support such filter and documents no field with the submitter, so the activations are filtered
+		// by their createdBy field, if the API returns it. Activations without it are not returned when filtering
+		CreatedBy string
+
+		// Expand fills in the account, property and group details missing from some items, e.g. older ones.
+		// If any item has no PropertyName, the property is fetched once to look it up, which costs an additional GET request
+		Expand bool
 	}
 
 	// GetActivationRequest is the get activation request
@@ -119,6 +187,115 @@ type (
 		Activation *Activation `json:"-"`
 	}
 
+	// GetActivationErrorsRequest is the request for fetching the errors of a failed activation
+	GetActivationErrorsRequest struct {
+		PropertyID   string
+		ContractID   string
+		GroupID      string
+		ActivationID string
+	}
+
+	// GetActivationErrorsResponse contains the errors and warnings of a failed activation
+	// Errors and Warnings are empty if the activation status is not FAILED
+	GetActivationErrorsResponse struct {
+		Status   ActivationStatus
+		Errors   []*Error
+		Warnings []*Error
+	}
+
+	// WaitForActivationRequest is the request for waiting until an activation reaches a final status
+	WaitForActivationRequest struct {
+		PropertyID   string
+		ContractID   string
+		GroupID      string
+		ActivationID string
+
+		// PollInterval is the time between status checks.
+		// If not set, the Retry-After value returned by the API is used, or DefaultActivationPollInterval if there is none
+		PollInterval time.Duration
+	}
+
+	// ActivationUpdate is sent by WatchActivation when the activation status changes. If polling fails, an update
+	// with only Err set is sent and the channel is closed
+	ActivationUpdate struct {
+		Activation *Activation
+		Err        error
+	}
+
+	// CreateActivationAcknowledgingWarningsRequest is the request for creating an activation which acknowledges only accepted warnings
+	CreateActivationAcknowledgingWarningsRequest struct {
+		CreateActivationRequest
+
+		// AcceptWarning decides whether a warning returned by the API can be acknowledged, e.g. based on its type
+		AcceptWarning WarningFilter
+	}
+
+	// ActivationWarning is a warning which has to be acknowledged before the activation is accepted
+	ActivationWarning struct {
+		Type          string `json:"type"`
+		Title         string `json:"title"`
+		Detail        string `json:"detail"`
+		ErrorLocation string `json:"errorLocation,omitempty"`
+		MessageID     string `json:"messageId"`
+	}
+
+	// WarningFilter reports whether the activation warning can be acknowledged
+	WarningFilter func(ActivationWarning) bool
+
+	// ListGroupActivationsRequest is the request for listing the activations of all properties in a group
+	ListGroupActivationsRequest struct {
+		ContractID string
+		GroupID    string
+
+		// Since, if set, limits the returned activations to the ones submitted after it, see GetActivationsRequest
+		Since time.Time
+
+		// MaxConcurrency is the maximum number of activation lists fetched at the same time.
+		// If not set, DefaultGroupActivationsConcurrency is used
+		MaxConcurrency int
+	}
+
+	// ListGroupActivationsResponse contains the activations of every property in the group, in the order the properties were listed.
+	// Fetching the activations of a single property does not fail the whole call, the error is reported in Errors under its PropertyID.
+	// Errors is nil if the activations of every property were fetched
+	ListGroupActivationsResponse struct {
+		Properties []PropertyActivations
+		Errors     *BulkError
+	}
+
+	// PropertyActivations are the activations of a single property
+	PropertyActivations struct {
+		PropertyID   string
+		PropertyName string
+		Activations  []*Activation
+	}
+
+	// RollbackActivationRequest is the request for re-activating the previously active version of a property on the network
+	RollbackActivationRequest struct {
+		PropertyID             string
+		ContractID             string
+		GroupID                string
+		Network                ActivationNetwork
+		Note                   string
+		NotifyEmails           []string
+		AcknowledgeAllWarnings bool
+	}
+
+	// RollbackActivationResponse is the response for a rollback, which is a new activation of RollbackVersion
+	// replacing ActiveVersion on the network
+	RollbackActivationResponse struct {
+		CreateActivationResponse
+		ActiveVersion   int
+		RollbackVersion int
+	}
+
+	// GetActivationsByNetworkResponse is the response with the property activations grouped by network,
+	// in the order returned by the API
+	GetActivationsByNetworkResponse struct {
+		Staging    []*Activation
+		Production []*Activation
+	}
+
 	// CancelActivationRequest is used to delete a PENDING activation
 	CancelActivationRequest struct {
 		PropertyID   string
@@ -187,6 +364,12 @@ const (
 
 	// ActivationNetworkProduction is the production network
 	ActivationNetworkProduction ActivationNetwork = "PRODUCTION"
+
+	// DefaultActivationPollInterval is the default time between activation status checks
+	DefaultActivationPollInterval = time.Minute
+
+	// DefaultGroupActivationsConcurrency is the default number of activation lists fetched at the same time by ListGroupActivations
+	DefaultGroupActivationsConcurrency = 5
 )
 
 // Validate validates CreateActivationRequest
@@ -199,20 +382,26 @@ func (v CreateActivationRequest) Validate() error {
 		"Activation.FMAActivationState": validation.Validate(v.Activation.FMAActivationState, validation.Empty),
 		"Activation.GroupID":            validation.Validate(v.Activation.GroupID, validation.Empty),
 		"Activation.Network":            validation.Validate(v.Activation.Network, validation.In(ActivationNetworkStaging, ActivationNetworkProduction)),
-		"Activation.NotifyEmails":       validation.Validate(v.Activation.NotifyEmails, validation.Length(1, 0)),
+		"Activation.NotifyEmails":       validation.Validate(v.Activation.NotifyEmails, validation.Required),
 		"Activation.PropertyID":         validation.Validate(v.Activation.PropertyID, validation.Empty),
 		"Activation.PropertyName":       validation.Validate(v.Activation.PropertyName, validation.Empty),
 		"Activation.Status":             validation.Validate(v.Activation.Status, validation.Empty),
 		"Activation.SubmitDate":         validation.Validate(v.Activation.SubmitDate, validation.Empty),
 		"Activation.UpdateDate":         validation.Validate(v.Activation.UpdateDate, validation.Empty),
-		"Activation.Type":               validation.Validate(v.Activation.ActivationType, validation.In(ActivationTypeActivate, ActivationTypeDeactivate)),
+		"Activation.Type":               validation.Validate(v.Activation.ActivationType),
 	}.Filter()
 }
 
+// Validate validates ActivationType, an empty type is valid
+func (t ActivationType) Validate() error {
+	return validation.Validate(string(t), validation.In(string(ActivationTypeActivate), string(ActivationTypeDeactivate)))
+}
+
 // Validate validates GetActivationsRequest
 func (v GetActivationsRequest) Validate() error {
 	return validation.Errors{
-		"PropertyID": validation.Validate(v.PropertyID, validation.Required),
+		"PropertyID":     validation.Validate(v.PropertyID, validation.Required),
+		"ActivationType": validation.Validate(v.ActivationType),
 	}.Filter()
 }
 
@@ -224,6 +413,48 @@ func (v GetActivationRequest) Validate() error {
 	}.Filter()
 }
 
+// Validate validates GetActivationErrorsRequest
+func (v GetActivationErrorsRequest) Validate() error {
+	return validation.Errors{
+		"PropertyID":   validation.Validate(v.PropertyID, validation.Required),
+		"ActivationID": validation.Validate(v.ActivationID, validation.Required),
+	}.Filter()
+}
+
+// Validate validates WaitForActivationRequest
+func (v WaitForActivationRequest) Validate() error {
+	return validation.Errors{
+		"PropertyID":   validation.Validate(v.PropertyID, validation.Required),
+		"ActivationID": validation.Validate(v.ActivationID, validation.Required),
+		"PollInterval": validation.Validate(v.PollInterval, validation.Min(time.Duration(0))),
+	}.Filter()
+}
+
+// Validate validates CreateActivationAcknowledgingWarningsRequest
+func (v CreateActivationAcknowledgingWarningsRequest) Validate() error {
+	return validation.Errors{
+		"Activation.AcknowledgeAllWarnings": validation.Validate(v.Activation.AcknowledgeAllWarnings, validation.Empty),
+		"AcceptWarning":                     validation.Validate(v.AcceptWarning, validation.NotNil),
+	}.Filter()
+}
+
+// Validate validates ListGroupActivationsRequest
+func (v ListGroupActivationsRequest) Validate() error {
+	return validation.Errors{
+		"ContractID":     validation.Validate(v.ContractID, validation.Required),
+		"GroupID":        validation.Validate(v.GroupID, validation.Required),
+		"MaxConcurrency": validation.Validate(v.MaxConcurrency, validation.Min(0)),
+	}.Filter()
+}
+
+// Validate validates RollbackActivationRequest
+func (v RollbackActivationRequest) Validate() error {
+	return validation.Errors{
+		"PropertyID": validation.Validate(v.PropertyID, validation.Required),
+		"Network":    validation.Validate(v.Network, validation.Required, validation.In(ActivationNetworkStaging, ActivationNetworkProduction)),
+	}.Filter()
+}
+
 // Validate validate CancelActivationRequest
 func (v CancelActivationRequest) Validate() error {
 	return validation.Errors{
@@ -239,16 +470,60 @@ var (
 	ErrGetActivations = errors.New("fetching activations")
 	// ErrGetActivation represents error when fetching activation fails
 	ErrGetActivation = errors.New("fetching activation")
+	// ErrGetActivationErrors represents error when fetching the errors of a failed activation fails
+	ErrGetActivationErrors = errors.New("fetching activation errors")
 	// ErrCancelActivation represents error when canceling activation fails
 	ErrCancelActivation = errors.New("canceling activation")
+	// ErrWaitForActivation represents error when waiting for activation fails
+	ErrWaitForActivation = errors.New("waiting for activation")
+	// ErrWatchActivation represents error when watching activation fails
+	ErrWatchActivation = errors.New("watching activation")
+	// ErrCreateActivationAcknowledgingWarnings represents error when creating activation with selectively acknowledged warnings fails
+	ErrCreateActivationAcknowledgingWarnings = errors.New("creating activation acknowledging warnings")
+	// ErrListGroupActivations represents error when listing activations of a group fails
+	ErrListGroupActivations = errors.New("listing group activations")
+	// ErrRollbackActivation represents error when rolling back an activation fails
+	ErrRollbackActivation = errors.New("rolling back activation")
+	// ErrGetActivationsByNetwork represents error when fetching activations grouped by network fails
+	ErrGetActivationsByNetwork = errors.New("fetching activations by network")
+	// ErrNoRollbackVersion is returned when the activation history has no previously active version to roll back to
+	ErrNoRollbackVersion = errors.New("no version to roll back to")
+	// ErrInvalidActivationNote is returned when the activation note is rejected by the validator set with WithActivationNoteValidator
+	ErrInvalidActivationNote = errors.New("invalid activation note")
+	// ErrVersionNotActive is returned when a deactivation is prechecked and the property version is not active on the network
+	ErrVersionNotActive = errors.New("property version is not active on the network")
+	// ErrWarningsNotAccepted is returned when the activation has warnings which were not accepted by the warning filter
+	ErrWarningsNotAccepted = errors.New("activation warnings not accepted")
 )
 
 func (p *papi) CreateActivation(ctx context.Context, params CreateActivationRequest) (*CreateActivationResponse, error) {
+	// client level defaults are applied before validation, so that they can satisfy the required fields
+	if params.Activation.Note == "" {
+		params.Activation.Note = p.activationNote
+	}
+	if len(params.Activation.NotifyEmails) == 0 && len(p.activationNotifyEmails) > 0 {
+		params.Activation.NotifyEmails = append([]string(nil), p.activationNotifyEmails...)
+	}
+
 	if err := params.Validate(); err != nil {
 		return nil, fmt.Errorf("%s: %w: %s", ErrCreateActivation, ErrStructValidation, err)
 	}
+	if p.activationNoteCheck != nil {
+		if err := p.activationNoteCheck(params.Activation.Note); err != nil {
+			return nil, fmt.Errorf("%s: %w: %s", ErrCreateActivation, ErrInvalidActivationNote, err)
+		}
+	}
+	if p.activationEmailCheck != nil {
+		if disallowed := disallowedEmails(params.Activation.NotifyEmails, p.activationEmailCheck); len(disallowed) > 0 {
+			return nil, fmt.Errorf("%s: %w: NotifyEmails: addresses not allowed: %s", ErrCreateActivation, ErrStructValidation, strings.Join(disallowed, ", "))
+		}
+	}
 
-	logger := p.Log(ctx)
+	logger := p.Log(ctx).WithFields(log.Fields{
+		"property_id": params.PropertyID,
+		"version":     params.Activation.PropertyVersion,
+		"network":     params.Activation.Network,
+	})
 	logger.Debug("CreateActivation")
 
 	// explicitly set the activation type
@@ -256,9 +531,26 @@ func (p *papi) CreateActivation(ctx context.Context, params CreateActivationRequ
 		params.Activation.ActivationType = ActivationTypeActivate
 	}
 
+	if params.Precheck && params.Activation.ActivationType == ActivationTypeDeactivate {
+		if err := p.checkVersionActive(ctx, params); err != nil {
+			return nil, fmt.Errorf("%s: %w", ErrCreateActivation, err)
+		}
+	}
+
+	if params.OnlyIfNotActive && params.Activation.ActivationType == ActivationTypeActivate {
+		existing, err := p.findActiveActivation(ctx, params)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", ErrCreateActivation, err)
+		}
+		if existing != nil {
+			logger.Debugf("version %d is already active on %s, skipping activation", params.Activation.PropertyVersion, params.Activation.Network)
+			return existing, nil
+		}
+	}
+
 	uri, err := url.Parse(fmt.Sprintf(
 		"/papi/v1/properties/%s/activations",
-		params.PropertyID),
+		url.PathEscape(params.PropertyID)),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("%w: failed to parse url: %s", ErrCreateActivation, err)
@@ -276,6 +568,9 @@ func (p *papi) CreateActivation(ctx context.Context, params CreateActivationRequ
 	if err != nil {
 		return nil, fmt.Errorf("%w: failed to create request: %s", ErrCreateActivation, err)
 	}
+	if params.ETag != "" {
+		req.Header.Set("If-Match", params.ETag)
+	}
 
 	var rval CreateActivationResponse
 
@@ -288,26 +583,144 @@ func (p *papi) CreateActivation(ctx context.Context, params CreateActivationRequ
 		return nil, fmt.Errorf("%s: %w", ErrCreateActivation, p.Error(resp))
 	}
 
-	id, err := ResponseLinkParse(rval.ActivationLink)
+	id, err := rval.ActivationLink.ID()
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w: %s", ErrCreateActivation, ErrInvalidResponseLink, err)
+		return nil, fmt.Errorf("%s: %w", ErrCreateActivation, err)
 	}
 	rval.ActivationID = id
 
 	return &rval, nil
 }
 
+// RequireNotePattern returns a NoteValidator which rejects notes not matching the pattern, e.g. a ticket ID like `\bOPS-\d+\b`
+func RequireNotePattern(pattern *regexp.Regexp) NoteValidator {
+	return func(note string) error {
+		if !pattern.MatchString(note) {
+			return fmt.Errorf("note %q does not match %q", note, pattern)
+		}
+		return nil
+	}
+}
+
+// AllowEmails returns a NotifyEmailFilter which accepts only the given addresses, compared case-insensitively
+func AllowEmails(addresses ...string) NotifyEmailFilter {
+	allowed := make(map[string]bool, len(addresses))
+	for _, address := range addresses {
+		allowed[strings.ToLower(address)] = true
+	}
+	return func(email string) bool {
+		return allowed[strings.ToLower(email)]
+	}
+}
+
+// AllowEmailDomains returns a NotifyEmailFilter which accepts only addresses in the given domains, compared case-insensitively.
+// Subdomains are not accepted unless listed
+func AllowEmailDomains(domains ...string) NotifyEmailFilter {
+	allowed := make(map[string]bool, len(domains))
+	for _, domain := range domains {
+		allowed[strings.ToLower(strings.TrimPrefix(domain, "@"))] = true
+	}
+	return func(email string) bool {
+		at := strings.LastIndex(email, "@")
+		return at >= 0 && allowed[strings.ToLower(email[at+1:])]
+	}
+}
+
+// disallowedEmails returns the emails rejected by the filter
+func disallowedEmails(emails []string, allowed NotifyEmailFilter) []string {
+	var disallowed []string
+	for _, email := range emails {
+		if !allowed(email) {
+			disallowed = append(disallowed, email)
+		}
+	}
+	return disallowed
+}
+
+// findActiveActivation returns the most recent activation of the requested version on the network if the version is active,
+// or nil if it is not active
+func (p *papi) findActiveActivation(ctx context.Context, params CreateActivationRequest) (*CreateActivationResponse, error) {
+	if err := p.checkVersionActive(ctx, params); errors.Is(err, ErrVersionNotActive) {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	activations, err := p.GetActivations(ctx, GetActivationsRequest{
+		PropertyID: params.PropertyID,
+		ContractID: params.ContractID,
+		GroupID:    params.GroupID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rval := CreateActivationResponse{
+		Response: Response{
+			AccountID:  activations.AccountID,
+			ContractID: activations.ContractID,
+			GroupID:    activations.GroupID,
+		},
+	}
+	var latest *Activation
+	for _, activation := range activations.Activations.Items {
+		if activation == nil {
+			continue
+		}
+		if activation.PropertyVersion != params.Activation.PropertyVersion || activation.Network != params.Activation.Network ||
+			activation.ActivationType != ActivationTypeActivate || activation.Status != ActivationStatusActive {
+			continue
+		}
+		if latest == nil || activation.SubmitDate > latest.SubmitDate {
+			latest = activation
+		}
+	}
+	if latest != nil {
+		rval.ActivationID = latest.ActivationID
+		rval.ActivationLink = Link(fmt.Sprintf("/papi/%s/properties/%s/activations/%s?contractId=%s&groupId=%s",
+			p.apiVersion, url.PathEscape(params.PropertyID), url.PathEscape(latest.ActivationID), activations.ContractID, activations.GroupID))
+	}
+	return &rval, nil
+}
+
+// checkVersionActive returns ErrVersionNotActive if the version of the activation request is not active on its network
+func (p *papi) checkVersionActive(ctx context.Context, params CreateActivationRequest) error {
+	version, err := p.GetPropertyVersion(ctx, GetPropertyVersionRequest{
+		PropertyID:      params.PropertyID,
+		PropertyVersion: params.Activation.PropertyVersion,
+		ContractID:      params.ContractID,
+		GroupID:         params.GroupID,
+	})
+	if err != nil {
+		return err
+	}
+
+	status := version.Version.StagingStatus
+	if params.Activation.Network == ActivationNetworkProduction {
+		status = version.Version.ProductionStatus
+	}
+	if status != VersionStatusActive {
+		return fmt.Errorf("%w: version %d is %s on %s", ErrVersionNotActive, params.Activation.PropertyVersion, status, params.Activation.Network)
+	}
+	return nil
+}
+
 func (p *papi) GetActivations(ctx context.Context, params GetActivationsRequest) (*GetActivationsResponse, error) {
+	if err := p.applyDefaultLocation(&params.ContractID, &params.GroupID); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrGetActivations, ErrStructValidation, err)
+	}
 	if err := params.Validate(); err != nil {
 		return nil, fmt.Errorf("%s: %w: %s", ErrGetActivations, ErrStructValidation, err)
 	}
 
-	logger := p.Log(ctx)
+	logger := p.Log(ctx).WithFields(log.Fields{
+		"property_id": params.PropertyID,
+	})
 	logger.Debug("GetActivations")
 
 	uri, err := url.Parse(fmt.Sprintf(
 		"/papi/v1/properties/%s/activations",
-		params.PropertyID),
+		url.PathEscape(params.PropertyID)),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("%w: failed to parse url: %s", ErrGetActivations, err)
@@ -337,21 +750,140 @@ func (p *papi) GetActivations(ctx context.Context, params GetActivationsRequest)
 		return nil, fmt.Errorf("%s: %w", ErrGetActivations, p.Error(resp))
 	}
 
+	if !params.Since.IsZero() {
+		rval.Activations.Items = activationsSubmittedAfter(rval.Activations.Items, params.Since)
+	}
+
+	if params.ActivationType != "" {
+		rval.Activations.Items = activationsOfType(rval.Activations.Items, params.ActivationType)
+	}
+
+	if params.CreatedBy != "" {
+		rval.Activations.Items = activationsCreatedBy(rval.Activations.Items, params.CreatedBy)
+	}
+
+	if params.Expand {
+		if err := p.expandActivations(ctx, params, &rval); err != nil {
+			return nil, fmt.Errorf("%s: %w", ErrGetActivations, err)
+		}
+	}
+
 	return &rval, nil
 }
 
+// expandActivations fills in the details missing from the activation items, using the response, the request
+// and, only if some property name is missing, the property itself
+func (p *papi) expandActivations(ctx context.Context, params GetActivationsRequest, activations *GetActivationsResponse) error {
+	var propertyName string
+	for _, activation := range activations.Activations.Items {
+		if activation == nil || activation.PropertyName != "" {
+			continue
+		}
+		if propertyName == "" {
+			property, err := p.GetProperty(ctx, GetPropertyRequest{
+				PropertyID: params.PropertyID,
+				ContractID: params.ContractID,
+				GroupID:    params.GroupID,
+			})
+			if err != nil {
+				return err
+			}
+			propertyName = property.Property.PropertyName
+		}
+		activation.PropertyName = propertyName
+	}
+
+	for _, activation := range activations.Activations.Items {
+		if activation == nil {
+			continue
+		}
+		if activation.PropertyID == "" {
+			activation.PropertyID = params.PropertyID
+		}
+		if activation.AccountID == "" {
+			activation.AccountID = activations.AccountID
+		}
+		if activation.GroupID == "" {
+			activation.GroupID = activations.GroupID
+		}
+	}
+	return nil
+}
+
+// activationsOfType returns the activations of the given type
+func activationsOfType(activations []*Activation, activationType ActivationType) []*Activation {
+	filtered := make([]*Activation, 0, len(activations))
+	for _, activation := range activations {
+		if activation != nil && activation.ActivationType == activationType {
+			filtered = append(filtered, activation)
+		}
+	}
+	return filtered
+}
+
+// activationsCreatedBy returns the activations submitted by the given user
+func activationsCreatedBy(activations []*Activation, user string) []*Activation {
+	filtered := make([]*Activation, 0, len(activations))
+	for _, activation := range activations {
+		if activation == nil {
+			continue
+		}
+		if createdBy, ok := activation.CreatedBy(); ok && createdBy == user {
+			filtered = append(filtered, activation)
+		}
+	}
+	return filtered
+}
+
+// CreatedBy returns the user who submitted the activation, from the createdBy field kept in AdditionalFields.
+// The field is not documented by the API, it returns false if it is missing or is not a string
+func (a *Activation) CreatedBy() (string, bool) {
+	raw, ok := a.AdditionalFields["createdBy"]
+	if !ok {
+		return "", false
+	}
+	var createdBy string
+	if err := json.Unmarshal(raw, &createdBy); err != nil {
+		return "", false
+	}
+	return createdBy, true
+}
+
+// activationsSubmittedAfter returns the activations submitted after the given time
+// Activations with missing or invalid submit date are kept, so that they are not silently skipped by incremental syncs
+func activationsSubmittedAfter(activations []*Activation, since time.Time) []*Activation {
+	filtered := make([]*Activation, 0, len(activations))
+	for _, activation := range activations {
+		if activation == nil {
+			continue
+		}
+		submitted, err := time.Parse(time.RFC3339, activation.SubmitDate)
+		if err == nil && !submitted.After(since) {
+			continue
+		}
+		filtered = append(filtered, activation)
+	}
+	return filtered
+}
+
 func (p *papi) GetActivation(ctx context.Context, params GetActivationRequest) (*GetActivationResponse, error) {
+	if err := p.applyDefaultLocation(&params.ContractID, &params.GroupID); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrGetActivation, ErrStructValidation, err)
+	}
 	if err := params.Validate(); err != nil {
 		return nil, fmt.Errorf("%s: %w: %s", ErrGetActivation, ErrStructValidation, err)
 	}
 
-	logger := p.Log(ctx)
+	logger := p.Log(ctx).WithFields(log.Fields{
+		"property_id":   params.PropertyID,
+		"activation_id": params.ActivationID,
+	})
 	logger.Debug("GetActivation")
 
 	uri := fmt.Sprintf(
 		"/papi/v1/properties/%s/activations/%s?contractId=%s&groupId=%s",
-		params.PropertyID,
-		params.ActivationID,
+		url.PathEscape(params.PropertyID),
+		url.PathEscape(params.ActivationID),
 		params.ContractID,
 		params.GroupID)
 
@@ -384,18 +916,71 @@ func (p *papi) GetActivation(ctx context.Context, params GetActivationRequest) (
 	return &rval, nil
 }
 
+func (p *papi) GetActivationErrors(ctx context.Context, params GetActivationErrorsRequest) (*GetActivationErrorsResponse, error) {
+	if err := p.applyDefaultLocation(&params.ContractID, &params.GroupID); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrGetActivationErrors, ErrStructValidation, err)
+	}
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrGetActivationErrors, ErrStructValidation, err)
+	}
+
+	logger := p.Log(ctx).WithFields(log.Fields{
+		"property_id":   params.PropertyID,
+		"activation_id": params.ActivationID,
+	})
+	logger.Debug("GetActivationErrors")
+
+	activation, err := p.GetActivation(ctx, GetActivationRequest{
+		PropertyID:   params.PropertyID,
+		ContractID:   params.ContractID,
+		GroupID:      params.GroupID,
+		ActivationID: params.ActivationID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ErrGetActivationErrors, err)
+	}
+
+	rval := GetActivationErrorsResponse{Status: activation.Activation.Status}
+	if rval.Status != ActivationStatusFailed {
+		return &rval, nil
+	}
+
+	// the reasons may be reported in the response envelope as well as on the activation itself
+	rval.Errors = append(rval.Errors, activation.Errors...)
+	rval.Warnings = append(rval.Warnings, activation.Warnings...)
+	for field, target := range map[string]*[]*Error{"errors": &rval.Errors, "warnings": &rval.Warnings} {
+		value, ok := activation.Activation.AdditionalFields[field]
+		if !ok {
+			continue
+		}
+		var reported []*Error
+		if err := json.Unmarshal(value, &reported); err != nil {
+			return nil, fmt.Errorf("%s: invalid activation %s: %s", ErrGetActivationErrors, field, err)
+		}
+		*target = append(*target, reported...)
+	}
+
+	return &rval, nil
+}
+
 func (p *papi) CancelActivation(ctx context.Context, params CancelActivationRequest) (*CancelActivationResponse, error) {
+	if err := p.applyDefaultLocation(&params.ContractID, &params.GroupID); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrCancelActivation, ErrStructValidation, err)
+	}
 	if err := params.Validate(); err != nil {
 		return nil, fmt.Errorf("%s: %w: %s", ErrCancelActivation, ErrStructValidation, err)
 	}
 
-	logger := p.Log(ctx)
+	logger := p.Log(ctx).WithFields(log.Fields{
+		"property_id":   params.PropertyID,
+		"activation_id": params.ActivationID,
+	})
 	logger.Debug("CancelActivation")
 
 	uri := fmt.Sprintf(
 		"/papi/v1/properties/%s/activations/%s?contractId=%s&groupId=%s",
-		params.PropertyID,
-		params.ActivationID,
+		url.PathEscape(params.PropertyID),
+		url.PathEscape(params.ActivationID),
 		params.ContractID,
 		params.GroupID)
 
@@ -417,3 +1002,537 @@ func (p *papi) CancelActivation(ctx context.Context, params CancelActivationRequ
 
 	return &rval, nil
 }
+
+// WaitForActivation polls the activation until its status is final, i.e. it is no longer pending.
+// A FAILED or ABORTED activation is returned without an error, the caller is expected to check the status
+func (p *papi) WaitForActivation(ctx context.Context, params WaitForActivationRequest) (*GetActivationResponse, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrWaitForActivation, ErrStructValidation, err)
+	}
+
+	logger := p.Log(ctx).WithFields(log.Fields{
+		"property_id":   params.PropertyID,
+		"activation_id": params.ActivationID,
+	})
+	logger.Debug("WaitForActivation")
+
+	var activation *GetActivationResponse
+	checkActivation := func(ctx context.Context) (bool, error) {
+		var err error
+		activation, err = p.GetActivation(ctx, GetActivationRequest{
+			PropertyID:   params.PropertyID,
+			ContractID:   params.ContractID,
+			GroupID:      params.GroupID,
+			ActivationID: params.ActivationID,
+		})
+		if err != nil {
+			return false, err
+		}
+		return activation.Activation.Status.isFinal(), nil
+	}
+	nextInterval := func(int) time.Duration {
+		interval := activationPollInterval(params.PollInterval, activation)
+		logger.Debugf("activation %s is %s, checking again in %s", params.ActivationID, activation.Activation.Status, interval)
+		return interval
+	}
+
+	if err := poll.Until(ctx, checkActivation, poll.WithClock(p.clock), poll.WithIntervalFunc(nextInterval)); err != nil {
+		return nil, fmt.Errorf("%s: %w", ErrWaitForActivation, err)
+	}
+	return activation, nil
+}
+
+// WatchActivation polls the activation the same way as WaitForActivation, sending only the responses with a changed status.
+// The channel is closed after the update with a final status, after an update with an error, or when the context is done.
+// Updates are sent without buffering, so polling waits for the receiver
+func (p *papi) WatchActivation(ctx context.Context, params WaitForActivationRequest) (<-chan ActivationUpdate, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrWatchActivation, ErrStructValidation, err)
+	}
+
+	logger := p.Log(ctx).WithFields(log.Fields{
+		"property_id":   params.PropertyID,
+		"activation_id": params.ActivationID,
+	})
+	logger.Debug("WatchActivation")
+
+	updates := make(chan ActivationUpdate)
+	send := func(update ActivationUpdate) bool {
+		select {
+		case updates <- update:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+
+	go func() {
+		defer close(updates)
+
+		var activation *GetActivationResponse
+		var status ActivationStatus
+		checkActivation := func(ctx context.Context) (bool, error) {
+			var err error
+			activation, err = p.GetActivation(ctx, GetActivationRequest{
+				PropertyID:   params.PropertyID,
+				ContractID:   params.ContractID,
+				GroupID:      params.GroupID,
+				ActivationID: params.ActivationID,
+			})
+			if err != nil {
+				return false, err
+			}
+			if activation.Activation.Status != status {
+				status = activation.Activation.Status
+				if !send(ActivationUpdate{Activation: activation.Activation}) {
+					return false, ctx.Err()
+				}
+			}
+			return status.isFinal(), nil
+		}
+		nextInterval := func(int) time.Duration {
+			return activationPollInterval(params.PollInterval, activation)
+		}
+
+		err := poll.Until(ctx, checkActivation, poll.WithClock(p.clock), poll.WithIntervalFunc(nextInterval))
+		if err != nil && ctx.Err() == nil {
+			send(ActivationUpdate{Err: fmt.Errorf("%s: %w", ErrWatchActivation, err)})
+		}
+	}()
+
+	return updates, nil
+}
+
+// activationPollInterval returns the time to wait before checking the activation again, the requested interval if set,
+// otherwise the Retry-After value of the last response, or DefaultActivationPollInterval if there is none
+func activationPollInterval(interval time.Duration, activation *GetActivationResponse) time.Duration {
+	if interval == 0 {
+		interval = time.Duration(activation.RetryAfter) * time.Second
+	}
+	if interval <= 0 {
+		interval = DefaultActivationPollInterval
+	}
+	return interval
+}
+
+// CreateActivationAcknowledgingWarnings submits the activation without acknowledging any warnings not listed in the request.
+// If the API rejects it because of unacknowledged warnings, the activation is re-submitted acknowledging them,
+// but only if every one of them is accepted by AcceptWarning. Otherwise ErrWarningsNotAccepted is returned.
+func (p *papi) CreateActivationAcknowledgingWarnings(ctx context.Context, params CreateActivationAcknowledgingWarningsRequest) (*CreateActivationResponse, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrCreateActivationAcknowledgingWarnings, ErrStructValidation, err)
+	}
+
+	logger := p.Log(ctx).WithFields(log.Fields{
+		"property_id": params.PropertyID,
+		"version":     params.Activation.PropertyVersion,
+		"network":     params.Activation.Network,
+	})
+	logger.Debug("CreateActivationAcknowledgingWarnings")
+
+	resp, err := p.CreateActivation(ctx, params.CreateActivationRequest)
+	if err == nil {
+		return resp, nil
+	}
+
+	var apiErr *Error
+	if !errors.As(err, &apiErr) || !strings.HasSuffix(apiErr.Type, "warnings-not-acknowledged") || len(apiErr.Warnings) == 0 {
+		return nil, fmt.Errorf("%s: %w", ErrCreateActivationAcknowledgingWarnings, err)
+	}
+	var warnings []ActivationWarning
+	if err := json.Unmarshal(apiErr.Warnings, &warnings); err != nil {
+		return nil, fmt.Errorf("%w: parsing warnings: %s", ErrCreateActivationAcknowledgingWarnings, err)
+	}
+
+	var rejected []string
+	request := params.CreateActivationRequest
+	request.Activation.AcknowledgeWarnings = append([]string(nil), params.Activation.AcknowledgeWarnings...)
+	for _, warning := range warnings {
+		if !params.AcceptWarning(warning) {
+			rejected = append(rejected, fmt.Sprintf("%s (%s): %s", warning.MessageID, warning.Type, warning.Detail))
+			continue
+		}
+		request.Activation.AcknowledgeWarnings = append(request.Activation.AcknowledgeWarnings, warning.MessageID)
+	}
+	if len(rejected) > 0 {
+		return nil, fmt.Errorf("%s: %w: %s", ErrCreateActivationAcknowledgingWarnings, ErrWarningsNotAccepted, strings.Join(rejected, "; "))
+	}
+
+	logger.Debugf("re-submitting activation acknowledging %d warnings", len(warnings))
+	resp, err = p.CreateActivation(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ErrCreateActivationAcknowledgingWarnings, err)
+	}
+	return resp, nil
+}
+
+// RollbackActivation looks up the currently active version and the version active before it on the network
+// in the activation history, and activates the latter. ErrNoRollbackVersion is returned if the latest completed
+// activation on the network is not an activation of a different version, e.g. no version is active, or
+// only one version has ever been activated
+func (p *papi) RollbackActivation(ctx context.Context, params RollbackActivationRequest) (*RollbackActivationResponse, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrRollbackActivation, ErrStructValidation, err)
+	}
+
+	logger := p.Log(ctx).WithFields(log.Fields{
+		"property_id": params.PropertyID,
+		"network":     params.Network,
+	})
+	logger.Debug("RollbackActivation")
+
+	activations, err := p.GetActivations(ctx, GetActivationsRequest{
+		PropertyID: params.PropertyID,
+		ContractID: params.ContractID,
+		GroupID:    params.GroupID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ErrRollbackActivation, err)
+	}
+
+	activeVersion, rollbackVersion, err := findRollbackVersion(activations.Activations.Items, params.Network)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ErrRollbackActivation, err)
+	}
+	logger.Debugf("rolling back %s from version %d to %d", params.Network, activeVersion, rollbackVersion)
+
+	activation, err := p.CreateActivation(ctx, CreateActivationRequest{
+		PropertyID: params.PropertyID,
+		ContractID: params.ContractID,
+		GroupID:    params.GroupID,
+		Activation: Activation{
+			ActivationType:         ActivationTypeActivate,
+			PropertyVersion:        rollbackVersion,
+			Network:                params.Network,
+			Note:                   params.Note,
+			NotifyEmails:           params.NotifyEmails,
+			AcknowledgeAllWarnings: params.AcknowledgeAllWarnings,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ErrRollbackActivation, err)
+	}
+
+	return &RollbackActivationResponse{
+		CreateActivationResponse: *activation,
+		ActiveVersion:            activeVersion,
+		RollbackVersion:          rollbackVersion,
+	}, nil
+}
+
+// findRollbackVersion returns the version active on the network and the version activated before it,
+// taking into account only the activations which completed, i.e. the ones which are ACTIVE or were superseded
+func findRollbackVersion(activations []*Activation, network ActivationNetwork) (int, int, error) {
+	var history []*Activation
+	for _, activation := range activations {
+		if activation == nil {
+			continue
+		}
+		if activation.Network != network ||
+			(activation.Status != ActivationStatusActive && activation.Status != ActivationStatusInactive) {
+			continue
+		}
+		history = append(history, activation)
+	}
+	sort.SliceStable(history, func(i, j int) bool {
+		return history[i].SubmitDate > history[j].SubmitDate
+	})
+
+	if len(history) == 0 || history[0].ActivationType != ActivationTypeActivate || history[0].Status != ActivationStatusActive {
+		return 0, 0, fmt.Errorf("%w: no version is active on %s", ErrNoRollbackVersion, network)
+	}
+	active := history[0].PropertyVersion
+	for _, activation := range history[1:] {
+		if activation.ActivationType == ActivationTypeActivate && activation.PropertyVersion != active {
+			return active, activation.PropertyVersion, nil
+		}
+	}
+	return 0, 0, fmt.Errorf("%w: version %d is the only version activated on %s", ErrNoRollbackVersion, active, network)
+}
+
+// GetActivationsByNetwork lists the property activations, applying the filters of the request, and groups them by network
+func (p *papi) GetActivationsByNetwork(ctx context.Context, params GetActivationsRequest) (*GetActivationsByNetworkResponse, error) {
+	logger := p.Log(ctx).WithFields(log.Fields{
+		"property_id": params.PropertyID,
+	})
+	logger.Debug("GetActivationsByNetwork")
+
+	activations, err := p.GetActivations(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ErrGetActivationsByNetwork, err)
+	}
+
+	var rval GetActivationsByNetworkResponse
+	for _, activation := range activations.Activations.Items {
+		if activation == nil {
+			continue
+		}
+		switch activation.Network {
+		case ActivationNetworkStaging:
+			rval.Staging = append(rval.Staging, activation)
+		case ActivationNetworkProduction:
+			rval.Production = append(rval.Production, activation)
+		}
+	}
+	return &rval, nil
+}
+
+// AcceptWarningTypes returns a WarningFilter accepting only warnings of the given types
+func AcceptWarningTypes(types ...string) WarningFilter {
+	accepted := make(map[string]bool, len(types))
+	for _, t := range types {
+		accepted[t] = true
+	}
+	return func(warning ActivationWarning) bool {
+		return accepted[warning.Type]
+	}
+}
+
+// isFinal reports whether the activation will not change its status anymore
+func (s ActivationStatus) isFinal() bool {
+	switch s {
+	case ActivationStatusActive, ActivationStatusInactive, ActivationStatusAborted, ActivationStatusFailed, ActivationStatusDeactivated:
+		return true
+	}
+	return false
+}
+
+// activationFields are the lower-cased JSON field names mapped to Activation struct fields,
+// as encoding/json matches the names case-insensitively
+var activationFields = func() map[string]bool {
+	fields := make(map[string]bool)
+	t := reflect.TypeOf(Activation{})
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if name != "" && name != "-" {
+			fields[strings.ToLower(name)] = true
+		}
+	}
+	return fields
+}()
+
+// MarshalJSON marshals the activation, NotifyEmails is always encoded as an array, even if it is nil
+func (a Activation) MarshalJSON() ([]byte, error) {
+	type activation Activation
+	if a.NotifyEmails == nil {
+		a.NotifyEmails = []string{}
+	}
+	return json.Marshal(activation(a))
+}
+
+// UnmarshalJSON unmarshals the activation, keeping the fields which are not mapped to the struct in AdditionalFields
+func (a *Activation) UnmarshalJSON(data []byte) error {
+	type activation Activation
+	var act activation
+	if err := json.Unmarshal(data, &act); err != nil {
+		return err
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	for name, value := range fields {
+		if activationFields[strings.ToLower(name)] {
+			continue
+		}
+		if act.AdditionalFields == nil {
+			act.AdditionalFields = make(map[string]json.RawMessage)
+		}
+		act.AdditionalFields[name] = value
+	}
+
+	*a = Activation(act)
+	return nil
+}
+
+// UnmarshalJSON unmarshals the fallback info, accepting numeric fields encoded either as JSON numbers or as strings,
+// as the API occasionally returns them quoted
+func (f *ActivationFallbackInfo) UnmarshalJSON(data []byte) error {
+	type fallbackInfo ActivationFallbackInfo
+	aux := struct {
+		*fallbackInfo
+		FallbackVersion            flexibleInt `json:"fallbackVersion"`
+		SteadyStateTime            flexibleInt `json:"steadyStateTime"`
+		FastFallbackExpirationTime flexibleInt `json:"fastFallbackExpirationTime"`
+	}{
+		fallbackInfo: (*fallbackInfo)(f),
+	}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+
+	f.FallbackVersion = int(aux.FallbackVersion)
+	f.SteadyStateTime = int(aux.SteadyStateTime)
+	f.FastFallbackExpirationTime = int(aux.FastFallbackExpirationTime)
+	return nil
+}
+
+// flexibleInt is an int which can be unmarshaled from either a JSON number or a string containing one
+type flexibleInt int
+
+// UnmarshalJSON unmarshals the int from a JSON number or string, an empty string is unmarshaled as 0
+func (i *flexibleInt) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+	value := strings.Trim(string(data), `"`)
+	if value == "" {
+		*i = 0
+		return nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return fmt.Errorf("invalid integer %s: %w", data, err)
+	}
+	*i = flexibleInt(n)
+	return nil
+}
+
+// ToGetRequest builds the request to fetch this activation again, e.g. when iterating GetActivations results
+// The contract and group are usually only present at the top level of the listing, so they are passed in;
+// the group of the activation itself takes precedence when populated
+func (a Activation) ToGetRequest(contractID, groupID string) GetActivationRequest {
+	if a.GroupID != "" {
+		groupID = a.GroupID
+	}
+	return GetActivationRequest{
+		PropertyID:   a.PropertyID,
+		ContractID:   contractID,
+		GroupID:      groupID,
+		ActivationID: a.ActivationID,
+	}
+}
+
+// Summary returns a one-line description of the activation, e.g. "v4 ACTIVATE -> STAGING: ACTIVE (submitted 2022-10-27)"
+func (a Activation) Summary() string {
+	summary := fmt.Sprintf("v%d %s -> %s: %s", a.PropertyVersion, a.ActivationType, a.Network, a.Status)
+	if a.SubmitDate == "" {
+		return summary
+	}
+	submitted := a.SubmitDate
+	if t, err := time.Parse(time.RFC3339, a.SubmitDate); err == nil {
+		submitted = t.Format("2006-01-02")
+	}
+	return fmt.Sprintf("%s (submitted %s)", summary, submitted)
+}
+
+// CanFastFallback reports whether the activation can still be rolled back with fast fallback at the given time,
+// which requires the API to allow it and the fallback window not to have expired. It is false without FallbackInfo
+func (a Activation) CanFastFallback(now time.Time) bool {
+	return a.FallbackInfo != nil && a.FallbackInfo.CanFastFallback && a.TimeUntilFallbackExpires(now) > 0
+}
+
+// TimeUntilFallbackExpires returns the time left at the given time until the fast fallback window expires,
+// 0 if it has already expired or the expiration time is not known
+func (a Activation) TimeUntilFallbackExpires(now time.Time) time.Duration {
+	if a.FallbackInfo == nil || a.FallbackInfo.FastFallbackExpirationTime == 0 {
+		return 0
+	}
+	left := time.Unix(int64(a.FallbackInfo.FastFallbackExpirationTime), 0).Sub(now)
+	if left < 0 {
+		return 0
+	}
+	return left
+}
+
+// SummarizeActivations returns the number of activations in each status, e.g. for dashboards
+func SummarizeActivations(activations []*Activation) map[ActivationStatus]int {
+	summary := make(map[ActivationStatus]int)
+	for _, activation := range activations {
+		if activation == nil {
+			continue
+		}
+		summary[activation.Status]++
+	}
+	return summary
+}
+
+// FailedActivation pairs a failed or aborted activation with the reason it did not complete
+type FailedActivation struct {
+	Activation *Activation
+	Reason     string
+}
+
+// FailedActivations returns only the activations with FAILED or ABORTED status, e.g. for alerting.
+// The reason is taken from the fast metadata activation state when the API reports one, otherwise it is the status itself.
+func FailedActivations(activations []*Activation) []FailedActivation {
+	failed := make([]FailedActivation, 0)
+	for _, activation := range activations {
+		if activation == nil {
+			continue
+		}
+		if activation.Status != ActivationStatusFailed && activation.Status != ActivationStatusAborted {
+			continue
+		}
+		reason := activation.FMAActivationState
+		if reason == "" {
+			reason = string(activation.Status)
+		}
+		failed = append(failed, FailedActivation{Activation: activation, Reason: reason})
+	}
+	return failed
+}
+
+func (p *papi) ListGroupActivations(ctx context.Context, params ListGroupActivationsRequest) (*ListGroupActivationsResponse, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrListGroupActivations, ErrStructValidation, err)
+	}
+
+	logger := p.Log(ctx)
+	logger.Debug("ListGroupActivations")
+
+	properties, err := p.GetProperties(ctx, GetPropertiesRequest{
+		ContractID: params.ContractID,
+		GroupID:    params.GroupID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ErrListGroupActivations, err)
+	}
+
+	concurrency := params.MaxConcurrency
+	if concurrency == 0 {
+		concurrency = DefaultGroupActivationsConcurrency
+	}
+
+	count := len(properties.Properties.Items)
+	ids := make([]string, count)
+	for i, property := range properties.Properties.Items {
+		ids[i] = property.PropertyID
+	}
+	activations := make([]*GetActivationsResponse, count)
+	bulkErr, err := forEachConcurrently(ctx, ids, concurrency, func(i int) error {
+		res, err := p.GetActivations(ctx, GetActivationsRequest{
+			PropertyID: ids[i],
+			ContractID: params.ContractID,
+			GroupID:    params.GroupID,
+			Since:      params.Since,
+		})
+		if err != nil {
+			return err
+		}
+		activations[i] = res
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ErrListGroupActivations, err)
+	}
+
+	result := ListGroupActivationsResponse{
+		Properties: make([]PropertyActivations, 0, count),
+	}
+	for i, property := range properties.Properties.Items {
+		if activations[i] == nil {
+			continue
+		}
+		result.Properties = append(result.Properties, PropertyActivations{
+			PropertyID:   property.PropertyID,
+			PropertyName: property.PropertyName,
+			Activations:  activations[i].Activations.Items,
+		})
+	}
+	if !bulkErr.AllSucceeded() {
+		result.Errors = bulkErr
+	}
+	return &result, nil
+}
@@ -2,11 +2,22 @@ package papi
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"regexp"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/poll"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/session"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/tools"
+	"github.com/apex/log"
+	"github.com/apex/log/handlers/memory"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -96,6 +107,19 @@ func TestPapi_CreateActivation(t *testing.T) {
 			},
 			withError: ErrStructValidation,
 		},
+		"validation error - empty notify emails": {
+			request: CreateActivationRequest{
+				PropertyID: "prp_175780",
+				ContractID: "ctr_1-1TJZFW",
+				GroupID:    "grp_15166",
+				Activation: Activation{
+					PropertyVersion: 1,
+					Network:         ActivationNetworkStaging,
+					NotifyEmails:    []string{},
+				},
+			},
+			withError: ErrStructValidation,
+		},
 	}
 
 	for name, test := range tests {
@@ -119,6 +143,130 @@ func TestPapi_CreateActivation(t *testing.T) {
 	}
 }
 
+func TestPapi_CreateActivation_WithActivationDefaults(t *testing.T) {
+	tests := map[string]struct {
+		options              []Option
+		activation           Activation
+		expectedNote         string
+		expectedNotifyEmails []string
+		withError            error
+	}{
+		"defaults applied": {
+			options: []Option{WithActivationDefaults("routine release", "ops@example.com", "noc@example.com")},
+			activation: Activation{
+				PropertyVersion: 1,
+				Network:         ActivationNetworkStaging,
+			},
+			expectedNote:         "routine release",
+			expectedNotifyEmails: []string{"ops@example.com", "noc@example.com"},
+		},
+		"request values override defaults": {
+			options: []Option{WithActivationDefaults("routine release", "ops@example.com")},
+			activation: Activation{
+				PropertyVersion: 1,
+				Network:         ActivationNetworkStaging,
+				Note:            "hotfix",
+				NotifyEmails:    []string{"you@example.com"},
+			},
+			expectedNote:         "hotfix",
+			expectedNotifyEmails: []string{"you@example.com"},
+		},
+		"default note only": {
+			options: []Option{WithActivationDefaults("routine release")},
+			activation: Activation{
+				PropertyVersion: 1,
+				Network:         ActivationNetworkStaging,
+				NotifyEmails:    []string{"you@example.com"},
+			},
+			expectedNote:         "routine release",
+			expectedNotifyEmails: []string{"you@example.com"},
+		},
+		"no defaults": {
+			activation: Activation{
+				PropertyVersion: 1,
+				Network:         ActivationNetworkStaging,
+				NotifyEmails:    []string{"you@example.com"},
+			},
+			expectedNotifyEmails: []string{"you@example.com"},
+		},
+		"validation error - defaults do not fix invalid request": {
+			options: []Option{WithActivationDefaults("routine release", "ops@example.com")},
+			activation: Activation{
+				PropertyVersion: 1,
+				Network:         "INVALID",
+			},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var activation Activation
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&activation))
+				assert.Equal(t, test.expectedNote, activation.Note)
+				assert.Equal(t, test.expectedNotifyEmails, activation.NotifyEmails)
+				w.WriteHeader(http.StatusCreated)
+				_, err := w.Write([]byte(`{"activationLink": "/papi/v1/properties/prp_175780/activations/atv_67037?contractId=ctr_1-1TJZFW&groupId=grp_15166"}`))
+				assert.NoError(t, err)
+			}))
+			defer mockServer.Close()
+			client := mockAPIClient(t, mockServer, test.options...)
+			_, err := client.CreateActivation(context.Background(), CreateActivationRequest{
+				PropertyID: "prp_175780",
+				ContractID: "ctr_1-1TJZFW",
+				GroupID:    "grp_15166",
+				Activation: test.activation,
+			})
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestPapi_CreateActivation_UseFastFallback(t *testing.T) {
+	tests := map[string]struct {
+		useFastFallback bool
+	}{
+		"fast fallback requested": {
+			useFastFallback: true,
+		},
+		"fast fallback not requested": {
+			useFastFallback: false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var body map[string]interface{}
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+				assert.Equal(t, test.useFastFallback, body["useFastFallback"])
+				w.WriteHeader(http.StatusCreated)
+				_, err := w.Write([]byte(`{"activationLink": "/papi/v1/properties/prp_175780/activations/atv_67037?contractId=ctr_1-1TJZFW&groupId=grp_15166"}`))
+				assert.NoError(t, err)
+			}))
+			defer mockServer.Close()
+			client := mockAPIClient(t, mockServer)
+			_, err := client.CreateActivation(context.Background(), CreateActivationRequest{
+				PropertyID: "prp_175780",
+				ContractID: "ctr_1-1TJZFW",
+				GroupID:    "grp_15166",
+				Activation: Activation{
+					PropertyVersion: 1,
+					Network:         ActivationNetworkProduction,
+					NotifyEmails:    []string{"you@example.com"},
+					UseFastFallback: test.useFastFallback,
+				},
+			})
+			require.NoError(t, err)
+		})
+	}
+}
+
 func TestPapi_GetActivations(t *testing.T) {
 	tests := map[string]struct {
 		request          GetActivationsRequest
@@ -257,6 +405,100 @@ func TestPapi_GetActivations(t *testing.T) {
 	}
 }
 
+func TestPapi_GetActivations_Since(t *testing.T) {
+	responseBody := `
+{
+	"accountId": "act_1-1TJZFB",
+	"contractId": "ctr_1-1TJZFW",
+	"groupId": "grp_15166",
+	"activations": {
+		"items": [
+			{
+				"activationId": "atv_3",
+				"propertyId": "prp_173136",
+				"propertyVersion": 3,
+				"network": "STAGING",
+				"activationType": "ACTIVATE",
+				"status": "PENDING",
+				"submitDate": "2022-10-27T12:30:00Z"
+			},
+			{
+				"activationId": "atv_2",
+				"propertyId": "prp_173136",
+				"propertyVersion": 2,
+				"network": "STAGING",
+				"activationType": "ACTIVATE",
+				"status": "ACTIVE",
+				"submitDate": "2022-10-27T12:00:00Z"
+			},
+			{
+				"activationId": "atv_1",
+				"propertyId": "prp_173136",
+				"propertyVersion": 1,
+				"network": "STAGING",
+				"activationType": "ACTIVATE",
+				"status": "INACTIVE",
+				"submitDate": "2022-09-01T08:00:00Z"
+			},
+			{
+				"activationId": "atv_0",
+				"propertyId": "prp_173136",
+				"propertyVersion": 1,
+				"network": "PRODUCTION",
+				"activationType": "ACTIVATE",
+				"status": "INACTIVE"
+			}
+		]
+	}
+}`
+
+	tests := map[string]struct {
+		since               time.Time
+		expectedActivations []string
+	}{
+		"no cutoff returns all": {
+			expectedActivations: []string{"atv_3", "atv_2", "atv_1", "atv_0"},
+		},
+		"cutoff excludes older activations": {
+			since:               time.Date(2022, 10, 1, 0, 0, 0, 0, time.UTC),
+			expectedActivations: []string{"atv_3", "atv_2", "atv_0"},
+		},
+		"activation submitted at the cutoff is excluded": {
+			since:               time.Date(2022, 10, 27, 12, 0, 0, 0, time.UTC),
+			expectedActivations: []string{"atv_3", "atv_0"},
+		},
+		"cutoff in another time zone": {
+			since:               time.Date(2022, 10, 27, 14, 0, 0, 0, time.FixedZone("CEST", 2*60*60)),
+			expectedActivations: []string{"atv_3", "atv_0"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/papi/v1/properties/prp_173136/activations?contractId=ctr_1-1TJZFW&groupId=grp_15166", r.URL.String())
+				w.WriteHeader(http.StatusOK)
+				_, err := w.Write([]byte(responseBody))
+				assert.NoError(t, err)
+			}))
+			defer mockServer.Close()
+			client := mockAPIClient(t, mockServer)
+			result, err := client.GetActivations(context.Background(), GetActivationsRequest{
+				PropertyID: "prp_173136",
+				ContractID: "ctr_1-1TJZFW",
+				GroupID:    "grp_15166",
+				Since:      test.since,
+			})
+			require.NoError(t, err)
+			ids := make([]string, 0, len(result.Activations.Items))
+			for _, activation := range result.Activations.Items {
+				ids = append(ids, activation.ActivationID)
+			}
+			assert.Equal(t, test.expectedActivations, ids)
+		})
+	}
+}
+
 func TestPapi_GetActivation(t *testing.T) {
 	tests := map[string]struct {
 		request          GetActivationRequest
@@ -578,3 +820,2243 @@ func TestPapi_CancelActivation(t *testing.T) {
 		})
 	}
 }
+
+func TestSummarizeActivations(t *testing.T) {
+	body := `
+{
+	"activations": {
+		"items": [
+			{
+				"activationId": "atv_1",
+				"propertyVersion": 1,
+				"network": "STAGING",
+				"activationType": "ACTIVATE",
+				"status": "ACTIVE"
+			},
+			{
+				"activationId": "atv_2",
+				"propertyVersion": 2,
+				"network": "PRODUCTION",
+				"activationType": "ACTIVATE",
+				"status": "ACTIVE"
+			},
+			{
+				"activationId": "atv_3",
+				"propertyVersion": 3,
+				"network": "STAGING",
+				"activationType": "ACTIVATE",
+				"status": "PENDING"
+			},
+			{
+				"activationId": "atv_4",
+				"propertyVersion": 3,
+				"network": "PRODUCTION",
+				"activationType": "ACTIVATE",
+				"status": "FAILED"
+			},
+			{
+				"activationId": "atv_5",
+				"propertyVersion": 4,
+				"network": "PRODUCTION",
+				"activationType": "ACTIVATE",
+				"status": "FAILED"
+			},
+			{
+				"activationId": "atv_6",
+				"propertyVersion": 1,
+				"network": "PRODUCTION",
+				"activationType": "ACTIVATE",
+				"status": "ACTIVE"
+			}
+		]
+	}
+}`
+	var activations GetActivationsResponse
+	require.NoError(t, json.Unmarshal([]byte(body), &activations))
+
+	tests := map[string]struct {
+		given    []*Activation
+		expected map[ActivationStatus]int
+	}{
+		"multiple statuses": {
+			given: activations.Activations.Items,
+			expected: map[ActivationStatus]int{
+				ActivationStatusActive:  3,
+				ActivationStatusPending: 1,
+				ActivationStatusFailed:  2,
+			},
+		},
+		"nil items are skipped": {
+			given: []*Activation{nil, {Status: ActivationStatusPending}},
+			expected: map[ActivationStatus]int{
+				ActivationStatusPending: 1,
+			},
+		},
+		"no activations": {
+			given:    nil,
+			expected: map[ActivationStatus]int{},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, SummarizeActivations(test.given))
+		})
+	}
+}
+
+func TestPapi_WaitForActivation(t *testing.T) {
+	activationBody := func(status ActivationStatus) string {
+		return fmt.Sprintf(`
+{
+	"activations": {
+		"items": [
+			{
+				"activationId": "atv_1696985",
+				"propertyId": "prp_173136",
+				"propertyVersion": 1,
+				"network": "STAGING",
+				"activationType": "ACTIVATE",
+				"status": "%s"
+			}
+		]
+	}
+}`, status)
+	}
+
+	tests := map[string]struct {
+		request          WaitForActivationRequest
+		statuses         []ActivationStatus
+		retryAfter       string
+		expectedInterval time.Duration
+		expectedStatus   ActivationStatus
+		withError        error
+	}{
+		"pending, zone and active": {
+			request: WaitForActivationRequest{
+				PropertyID:   "prp_173136",
+				ContractID:   "ctr_1-1TJZFW",
+				GroupID:      "grp_15166",
+				ActivationID: "atv_1696985",
+				PollInterval: 10 * time.Second,
+			},
+			statuses:         []ActivationStatus{ActivationStatusPending, ActivationStatusZone1, ActivationStatusZone3, ActivationStatusActive},
+			expectedInterval: 10 * time.Second,
+			expectedStatus:   ActivationStatusActive,
+		},
+		"interval from Retry-After header": {
+			request: WaitForActivationRequest{
+				PropertyID:   "prp_173136",
+				ActivationID: "atv_1696985",
+			},
+			statuses:         []ActivationStatus{ActivationStatusPending, ActivationStatusFailed},
+			retryAfter:       "30",
+			expectedInterval: 30 * time.Second,
+			expectedStatus:   ActivationStatusFailed,
+		},
+		"default interval": {
+			request: WaitForActivationRequest{
+				PropertyID:   "prp_173136",
+				ActivationID: "atv_1696985",
+			},
+			statuses:         []ActivationStatus{ActivationStatusNew, ActivationStatusPending, ActivationStatusActive},
+			expectedInterval: DefaultActivationPollInterval,
+			expectedStatus:   ActivationStatusActive,
+		},
+		"already active": {
+			request: WaitForActivationRequest{
+				PropertyID:   "prp_173136",
+				ActivationID: "atv_1696985",
+			},
+			statuses:       []ActivationStatus{ActivationStatusActive},
+			expectedStatus: ActivationStatusActive,
+		},
+		"validation error": {
+			request: WaitForActivationRequest{
+				PropertyID:   "prp_173136",
+				PollInterval: -time.Second,
+			},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var calls int32
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodGet, r.Method)
+				call := atomic.AddInt32(&calls, 1)
+				if test.retryAfter != "" {
+					w.Header().Set("Retry-After", test.retryAfter)
+				}
+				w.WriteHeader(http.StatusOK)
+				_, err := w.Write([]byte(activationBody(test.statuses[call-1])))
+				assert.NoError(t, err)
+			}))
+			defer mockServer.Close()
+			start := time.Date(2022, 10, 27, 12, 0, 0, 0, time.UTC)
+			clock := poll.NewFakeClock(start)
+			client := mockAPIClient(t, mockServer, WithClock(clock))
+
+			type result struct {
+				resp *GetActivationResponse
+				err  error
+			}
+			done := make(chan result)
+			go func() {
+				resp, err := client.WaitForActivation(context.Background(), test.request)
+				done <- result{resp, err}
+			}()
+
+			for i := 1; i < len(test.statuses); i++ {
+				clock.BlockUntil(1)
+				clock.Advance(test.expectedInterval)
+			}
+			res := <-done
+			if test.withError != nil {
+				assert.True(t, errors.Is(res.err, test.withError), "want: %s; got: %s", test.withError, res.err)
+				return
+			}
+			require.NoError(t, res.err)
+			assert.Equal(t, test.expectedStatus, res.resp.Activation.Status)
+			assert.Equal(t, int32(len(test.statuses)), atomic.LoadInt32(&calls))
+			assert.Equal(t, start.Add(time.Duration(len(test.statuses)-1)*test.expectedInterval), clock.Now())
+		})
+	}
+}
+
+func TestPapi_WaitForActivation_ContextCanceled(t *testing.T) {
+	mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"activations":{"items":[{"activationId":"atv_1","status":"PENDING"}]}}`))
+		assert.NoError(t, err)
+	}))
+	defer mockServer.Close()
+	clock := poll.NewFakeClock(time.Now())
+	client := mockAPIClient(t, mockServer, WithClock(clock))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		_, err := client.WaitForActivation(ctx, WaitForActivationRequest{PropertyID: "prp_1", ActivationID: "atv_1"})
+		done <- err
+	}()
+
+	clock.BlockUntil(1)
+	cancel()
+	err := <-done
+	assert.True(t, errors.Is(err, context.Canceled), "want: %s; got: %s", context.Canceled, err)
+}
+
+func TestPapi_WatchActivation(t *testing.T) {
+	activationBody := func(status ActivationStatus) string {
+		return fmt.Sprintf(`{"activations": {"items": [{"activationId": "atv_1696985", "propertyId": "prp_173136", "status": "%s"}]}}`, status)
+	}
+
+	tests := map[string]struct {
+		request          WaitForActivationRequest
+		statuses         []ActivationStatus
+		failAt           int
+		expectedCalls    int
+		expectedStatuses []ActivationStatus
+		withError        func(*testing.T, error)
+	}{
+		"pending and active": {
+			request: WaitForActivationRequest{
+				PropertyID:   "prp_173136",
+				ActivationID: "atv_1696985",
+				PollInterval: 10 * time.Second,
+			},
+			statuses:         []ActivationStatus{ActivationStatusPending, ActivationStatusPending, ActivationStatusActive},
+			expectedCalls:    3,
+			expectedStatuses: []ActivationStatus{ActivationStatusPending, ActivationStatusActive},
+		},
+		"polling fails": {
+			request: WaitForActivationRequest{
+				PropertyID:   "prp_173136",
+				ActivationID: "atv_1696985",
+				PollInterval: 10 * time.Second,
+			},
+			statuses:         []ActivationStatus{ActivationStatusPending},
+			failAt:           2,
+			expectedCalls:    2,
+			expectedStatuses: []ActivationStatus{ActivationStatusPending},
+			withError: func(t *testing.T, err error) {
+				want := &Error{
+					Type:       "internal_error",
+					Title:      "Internal Server Error",
+					StatusCode: http.StatusInternalServerError,
+				}
+				assert.True(t, errors.Is(err, want), "want: %s; got: %s", want, err)
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var calls int32
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				call := atomic.AddInt32(&calls, 1)
+				if int(call) == test.failAt {
+					w.WriteHeader(http.StatusInternalServerError)
+					_, err := w.Write([]byte(`{"type": "internal_error", "title": "Internal Server Error", "status": 500}`))
+					assert.NoError(t, err)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+				_, err := w.Write([]byte(activationBody(test.statuses[call-1])))
+				assert.NoError(t, err)
+			}))
+			defer mockServer.Close()
+			clock := poll.NewFakeClock(time.Now())
+			client := mockAPIClient(t, mockServer, WithClock(clock))
+
+			updates, err := client.WatchActivation(context.Background(), test.request)
+			require.NoError(t, err)
+			done := make(chan []ActivationUpdate)
+			go func() {
+				var received []ActivationUpdate
+				for update := range updates {
+					received = append(received, update)
+				}
+				done <- received
+			}()
+
+			for i := 1; i < test.expectedCalls; i++ {
+				clock.BlockUntil(1)
+				clock.Advance(test.request.PollInterval)
+			}
+			received := <-done
+
+			var statuses []ActivationStatus
+			for _, update := range received {
+				if update.Err != nil {
+					continue
+				}
+				statuses = append(statuses, update.Activation.Status)
+			}
+			assert.Equal(t, test.expectedStatuses, statuses)
+			assert.Equal(t, int32(test.expectedCalls), atomic.LoadInt32(&calls))
+			last := received[len(received)-1]
+			if test.withError != nil {
+				test.withError(t, last.Err)
+				return
+			}
+			assert.NoError(t, last.Err)
+		})
+	}
+}
+
+func TestPapi_WatchActivation_ContextCanceled(t *testing.T) {
+	mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"activations":{"items":[{"activationId":"atv_1","status":"PENDING"}]}}`))
+		assert.NoError(t, err)
+	}))
+	defer mockServer.Close()
+	clock := poll.NewFakeClock(time.Now())
+	client := mockAPIClient(t, mockServer, WithClock(clock))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	updates, err := client.WatchActivation(ctx, WaitForActivationRequest{PropertyID: "prp_1", ActivationID: "atv_1"})
+	require.NoError(t, err)
+
+	update := <-updates
+	assert.Equal(t, ActivationStatusPending, update.Activation.Status)
+	clock.BlockUntil(1)
+	cancel()
+	_, ok := <-updates
+	assert.False(t, ok)
+
+	_, err = client.WatchActivation(ctx, WaitForActivationRequest{PropertyID: "prp_1"})
+	assert.True(t, errors.Is(err, ErrStructValidation), "want: %s; got: %s", ErrStructValidation, err)
+}
+
+func TestFailedActivations(t *testing.T) {
+	body := `
+{
+	"activations": {
+		"items": [
+			{
+				"activationId": "atv_1",
+				"propertyVersion": 1,
+				"network": "STAGING",
+				"activationType": "ACTIVATE",
+				"status": "ACTIVE"
+			},
+			{
+				"activationId": "atv_2",
+				"propertyVersion": 2,
+				"network": "PRODUCTION",
+				"activationType": "ACTIVATE",
+				"status": "FAILED",
+				"fmaActivationState": "steps"
+			},
+			{
+				"activationId": "atv_3",
+				"propertyVersion": 3,
+				"network": "STAGING",
+				"activationType": "ACTIVATE",
+				"status": "ABORTED"
+			},
+			{
+				"activationId": "atv_4",
+				"propertyVersion": 3,
+				"network": "PRODUCTION",
+				"activationType": "ACTIVATE",
+				"status": "PENDING"
+			}
+		]
+	}
+}`
+	var activations GetActivationsResponse
+	require.NoError(t, json.Unmarshal([]byte(body), &activations))
+	items := activations.Activations.Items
+
+	tests := map[string]struct {
+		given    []*Activation
+		expected []FailedActivation
+	}{
+		"failed and aborted": {
+			given: items,
+			expected: []FailedActivation{
+				{Activation: items[1], Reason: "steps"},
+				{Activation: items[2], Reason: "ABORTED"},
+			},
+		},
+		"no failures": {
+			given:    []*Activation{items[0], items[3]},
+			expected: []FailedActivation{},
+		},
+		"nil entries are skipped": {
+			given:    []*Activation{nil, items[1]},
+			expected: []FailedActivation{{Activation: items[1], Reason: "steps"}},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, FailedActivations(test.given))
+		})
+	}
+}
+
+func TestActivation_ToGetRequest(t *testing.T) {
+	body := `
+{
+	"accountId": "act_1-1TJZFB",
+	"contractId": "ctr_1-1TJZFW",
+	"groupId": "grp_15225",
+	"activations": {
+		"items": [
+			{
+				"activationId": "atv_1",
+				"propertyId": "prp_173136",
+				"propertyVersion": 1,
+				"network": "STAGING",
+				"activationType": "ACTIVATE",
+				"status": "ACTIVE"
+			},
+			{
+				"activationId": "atv_2",
+				"propertyId": "prp_173136",
+				"groupId": "grp_15166",
+				"propertyVersion": 2,
+				"network": "PRODUCTION",
+				"activationType": "ACTIVATE",
+				"status": "ACTIVE"
+			}
+		]
+	}
+}`
+	var activations GetActivationsResponse
+	require.NoError(t, json.Unmarshal([]byte(body), &activations))
+	items := activations.Activations.Items
+
+	tests := map[string]struct {
+		given    *Activation
+		expected GetActivationRequest
+	}{
+		"group from listing": {
+			given: items[0],
+			expected: GetActivationRequest{
+				PropertyID:   "prp_173136",
+				ContractID:   "ctr_1-1TJZFW",
+				GroupID:      "grp_15225",
+				ActivationID: "atv_1",
+			},
+		},
+		"group from activation": {
+			given: items[1],
+			expected: GetActivationRequest{
+				PropertyID:   "prp_173136",
+				ContractID:   "ctr_1-1TJZFW",
+				GroupID:      "grp_15166",
+				ActivationID: "atv_2",
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := test.given.ToGetRequest(activations.ContractID, activations.GroupID)
+			assert.Equal(t, test.expected, req)
+			assert.NoError(t, req.Validate())
+		})
+	}
+}
+
+func TestPapi_CreateActivationAcknowledgingWarnings(t *testing.T) {
+	warningsBody := `
+{
+	"type": "https://problems.luna.akamaiapis.net/papi/v0/activation-warnings-not-acknowledged",
+	"title": "Unacknowledged warnings",
+	"detail": "Activation warnings must be acknowledged",
+	"status": 400,
+	"warnings": [
+		{
+			"type": "https://problems.luna.akamaiapis.net/papi/v0/validation/validation_message.ssl_custom_hostname",
+			"title": "Hostname not covered by certificate",
+			"detail": "The hostname www.example.com is not covered by the certificate",
+			"messageId": "msg_1"
+		},
+		{
+			"type": "https://problems.luna.akamaiapis.net/papi/v0/validation/product_behavior_issue.cpcode_incorrect_product",
+			"title": "CP code product mismatch",
+			"detail": "The CP code is not configured for this product",
+			"messageId": "msg_2"
+		}
+	]
+}`
+	createdBody := `{"activationLink": "/papi/v1/properties/prp_175780/activations/atv_67037?contractId=ctr_1-1TJZFW&groupId=grp_15166"}`
+	request := CreateActivationRequest{
+		PropertyID: "prp_175780",
+		ContractID: "ctr_1-1TJZFW",
+		GroupID:    "grp_15166",
+		Activation: Activation{
+			PropertyVersion:     1,
+			Network:             ActivationNetworkStaging,
+			NotifyEmails:        []string{"you@example.com"},
+			AcknowledgeWarnings: []string{"msg_0"},
+		},
+	}
+
+	type response struct {
+		status int
+		body   string
+	}
+	tests := map[string]struct {
+		request                     CreateActivationAcknowledgingWarningsRequest
+		responses                   []response
+		expectedAcknowledgeWarnings [][]string
+		expectedResponse            *CreateActivationResponse
+		withError                   error
+	}{
+		"no warnings": {
+			request: CreateActivationAcknowledgingWarningsRequest{
+				CreateActivationRequest: request,
+				AcceptWarning:           AcceptWarningTypes(),
+			},
+			responses:                   []response{{http.StatusCreated, createdBody}},
+			expectedAcknowledgeWarnings: [][]string{{"msg_0"}},
+			expectedResponse: &CreateActivationResponse{
+				ActivationID:   "atv_67037",
+				ActivationLink: "/papi/v1/properties/prp_175780/activations/atv_67037?contractId=ctr_1-1TJZFW&groupId=grp_15166",
+			},
+		},
+		"all warnings accepted": {
+			request: CreateActivationAcknowledgingWarningsRequest{
+				CreateActivationRequest: request,
+				AcceptWarning: AcceptWarningTypes(
+					"https://problems.luna.akamaiapis.net/papi/v0/validation/validation_message.ssl_custom_hostname",
+					"https://problems.luna.akamaiapis.net/papi/v0/validation/product_behavior_issue.cpcode_incorrect_product",
+				),
+			},
+			responses: []response{
+				{http.StatusBadRequest, warningsBody},
+				{http.StatusCreated, createdBody},
+			},
+			expectedAcknowledgeWarnings: [][]string{{"msg_0"}, {"msg_0", "msg_1", "msg_2"}},
+			expectedResponse: &CreateActivationResponse{
+				ActivationID:   "atv_67037",
+				ActivationLink: "/papi/v1/properties/prp_175780/activations/atv_67037?contractId=ctr_1-1TJZFW&groupId=grp_15166",
+			},
+		},
+		"warnings accepted by custom filter": {
+			request: CreateActivationAcknowledgingWarningsRequest{
+				CreateActivationRequest: request,
+				AcceptWarning: func(warning ActivationWarning) bool {
+					return !strings.Contains(warning.Type, "cpcode") || warning.MessageID == "msg_2"
+				},
+			},
+			responses: []response{
+				{http.StatusBadRequest, warningsBody},
+				{http.StatusCreated, createdBody},
+			},
+			expectedAcknowledgeWarnings: [][]string{{"msg_0"}, {"msg_0", "msg_1", "msg_2"}},
+			expectedResponse: &CreateActivationResponse{
+				ActivationID:   "atv_67037",
+				ActivationLink: "/papi/v1/properties/prp_175780/activations/atv_67037?contractId=ctr_1-1TJZFW&groupId=grp_15166",
+			},
+		},
+		"warning not accepted": {
+			request: CreateActivationAcknowledgingWarningsRequest{
+				CreateActivationRequest: request,
+				AcceptWarning: AcceptWarningTypes(
+					"https://problems.luna.akamaiapis.net/papi/v0/validation/validation_message.ssl_custom_hostname",
+				),
+			},
+			responses:                   []response{{http.StatusBadRequest, warningsBody}},
+			expectedAcknowledgeWarnings: [][]string{{"msg_0"}},
+			withError:                   ErrWarningsNotAccepted,
+		},
+		"other error": {
+			request: CreateActivationAcknowledgingWarningsRequest{
+				CreateActivationRequest: request,
+				AcceptWarning:           AcceptWarningTypes(),
+			},
+			responses: []response{{http.StatusInternalServerError, `
+{
+	"type": "internal_error",
+	"title": "Internal Server Error",
+	"detail": "Error creating activation",
+	"status": 500
+}`}},
+			expectedAcknowledgeWarnings: [][]string{{"msg_0"}},
+			withError: &Error{
+				Type:       "internal_error",
+				Title:      "Internal Server Error",
+				Detail:     "Error creating activation",
+				StatusCode: http.StatusInternalServerError,
+			},
+		},
+		"validation error - acknowledge all warnings": {
+			request: CreateActivationAcknowledgingWarningsRequest{
+				CreateActivationRequest: CreateActivationRequest{
+					PropertyID: "prp_175780",
+					Activation: Activation{
+						PropertyVersion:        1,
+						Network:                ActivationNetworkStaging,
+						AcknowledgeAllWarnings: true,
+					},
+				},
+				AcceptWarning: AcceptWarningTypes(),
+			},
+			withError: ErrStructValidation,
+		},
+		"validation error - missing filter": {
+			request: CreateActivationAcknowledgingWarningsRequest{
+				CreateActivationRequest: request,
+			},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var acknowledged [][]string
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPost, r.Method)
+				var activation Activation
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&activation))
+				assert.False(t, activation.AcknowledgeAllWarnings)
+				res := test.responses[len(acknowledged)]
+				acknowledged = append(acknowledged, activation.AcknowledgeWarnings)
+				w.WriteHeader(res.status)
+				_, err := w.Write([]byte(res.body))
+				assert.NoError(t, err)
+			}))
+			defer mockServer.Close()
+			client := mockAPIClient(t, mockServer)
+			result, err := client.CreateActivationAcknowledgingWarnings(context.Background(), test.request)
+			assert.Equal(t, test.expectedAcknowledgeWarnings, acknowledged)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestActivation_UnmarshalJSON(t *testing.T) {
+	tests := map[string]struct {
+		body                     string
+		expectedAdditionalFields map[string]json.RawMessage
+	}{
+		"activation with progress": {
+			body: `
+{
+	"activationId": "atv_1696985",
+	"propertyId": "prp_173136",
+	"propertyVersion": 1,
+	"network": "PRODUCTION",
+	"activationType": "ACTIVATE",
+	"status": "ZONE_2",
+	"fmaActivationState": "steps",
+	"progress": 45,
+	"stateHistory": [{"state": "received"}, {"state": "steps"}]
+}`,
+			expectedAdditionalFields: map[string]json.RawMessage{
+				"progress":     json.RawMessage(`45`),
+				"stateHistory": json.RawMessage(`[{"state": "received"}, {"state": "steps"}]`),
+			},
+		},
+		"only known fields": {
+			body: `
+{
+	"activationId": "atv_1696985",
+	"propertyVersion": 1,
+	"network": "STAGING",
+	"status": "ACTIVE",
+	"Note": "field names are case-insensitive"
+}`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var activation Activation
+			require.NoError(t, json.Unmarshal([]byte(test.body), &activation))
+			assert.Equal(t, "atv_1696985", activation.ActivationID)
+			assert.Equal(t, test.expectedAdditionalFields, activation.AdditionalFields)
+
+			// additional fields are not sent back to the API
+			data, err := json.Marshal(activation)
+			require.NoError(t, err)
+			var fields map[string]interface{}
+			require.NoError(t, json.Unmarshal(data, &fields))
+			for field := range test.expectedAdditionalFields {
+				assert.NotContains(t, fields, field)
+			}
+		})
+	}
+}
+
+func TestActivation_Summary(t *testing.T) {
+	tests := map[string]struct {
+		given    Activation
+		expected string
+	}{
+		"active on staging": {
+			given: Activation{
+				PropertyVersion: 4,
+				ActivationType:  ActivationTypeActivate,
+				Network:         ActivationNetworkStaging,
+				Status:          ActivationStatusActive,
+				SubmitDate:      "2022-10-27T12:32:08Z",
+			},
+			expected: "v4 ACTIVATE -> STAGING: ACTIVE (submitted 2022-10-27)",
+		},
+		"pending deactivation on production": {
+			given: Activation{
+				PropertyVersion: 2,
+				ActivationType:  ActivationTypeDeactivate,
+				Network:         ActivationNetworkProduction,
+				Status:          ActivationStatusDeactivating,
+				SubmitDate:      "2022-11-02T08:00:00Z",
+			},
+			expected: "v2 DEACTIVATE -> PRODUCTION: PENDING_DEACTIVATION (submitted 2022-11-02)",
+		},
+		"unparsable submit date": {
+			given: Activation{
+				PropertyVersion: 1,
+				ActivationType:  ActivationTypeActivate,
+				Network:         ActivationNetworkStaging,
+				Status:          ActivationStatusFailed,
+				SubmitDate:      "yesterday",
+			},
+			expected: "v1 ACTIVATE -> STAGING: FAILED (submitted yesterday)",
+		},
+		"no submit date": {
+			given: Activation{
+				PropertyVersion: 3,
+				ActivationType:  ActivationTypeActivate,
+				Network:         ActivationNetworkProduction,
+				Status:          ActivationStatusNew,
+			},
+			expected: "v3 ACTIVATE -> PRODUCTION: NEW",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.given.Summary())
+		})
+	}
+}
+
+func TestPapi_ListGroupActivations(t *testing.T) {
+	propertiesBody := `
+{
+    "properties": {
+        "items": [
+            {
+                "accountId": "act_1-1TJZFB",
+                "contractId": "ctr_1-1TJZH5",
+                "groupId": "grp_15225",
+                "propertyId": "prp_175780",
+                "propertyName": "example.com",
+                "latestVersion": 2
+            },
+            {
+                "accountId": "act_1-1TJZFB",
+                "contractId": "ctr_1-1TJZH5",
+                "groupId": "grp_15225",
+                "propertyId": "prp_175781",
+                "propertyName": "www.example.com",
+                "latestVersion": 5
+            }
+        ]
+    }
+}`
+	activationsBody := func(propertyID, propertyName string, version int) string {
+		return fmt.Sprintf(`
+{
+    "activations": {
+        "items": [
+            {
+                "activationId": "atv_%[1]s",
+                "propertyName": "%[2]s",
+                "propertyId": "%[1]s",
+                "propertyVersion": %[3]d,
+                "network": "STAGING",
+                "activationType": "ACTIVATE",
+                "status": "ACTIVE",
+                "submitDate": "2022-10-27T12:32:08Z"
+            }
+        ]
+    }
+}`, propertyID, propertyName, version)
+	}
+	activation := func(propertyID, propertyName string, version int) *Activation {
+		return &Activation{
+			ActivationID:    "atv_" + propertyID,
+			PropertyName:    propertyName,
+			PropertyID:      propertyID,
+			PropertyVersion: version,
+			Network:         ActivationNetworkStaging,
+			ActivationType:  ActivationTypeActivate,
+			Status:          ActivationStatusActive,
+			SubmitDate:      "2022-10-27T12:32:08Z",
+		}
+	}
+
+	tests := map[string]struct {
+		params           ListGroupActivationsRequest
+		propertiesStatus int
+		failingProperty  string
+		expectedResponse *ListGroupActivationsResponse
+		expectedErrors   map[string]error
+		withError        error
+	}{
+		"activations of all properties": {
+			params:           ListGroupActivationsRequest{ContractID: "ctr_1-1TJZH5", GroupID: "grp_15225"},
+			propertiesStatus: http.StatusOK,
+			expectedResponse: &ListGroupActivationsResponse{
+				Properties: []PropertyActivations{
+					{
+						PropertyID:   "prp_175780",
+						PropertyName: "example.com",
+						Activations:  []*Activation{activation("prp_175780", "example.com", 2)},
+					},
+					{
+						PropertyID:   "prp_175781",
+						PropertyName: "www.example.com",
+						Activations:  []*Activation{activation("prp_175781", "www.example.com", 5)},
+					},
+				},
+			},
+		},
+		"activations of one property fail": {
+			params:           ListGroupActivationsRequest{ContractID: "ctr_1-1TJZH5", GroupID: "grp_15225", MaxConcurrency: 1},
+			propertiesStatus: http.StatusOK,
+			failingProperty:  "prp_175780",
+			expectedResponse: &ListGroupActivationsResponse{
+				Properties: []PropertyActivations{
+					{
+						PropertyID:   "prp_175781",
+						PropertyName: "www.example.com",
+						Activations:  []*Activation{activation("prp_175781", "www.example.com", 5)},
+					},
+				},
+			},
+			expectedErrors: map[string]error{"prp_175780": &Error{
+				Type:       "internal_error",
+				Title:      "Internal Server Error",
+				StatusCode: http.StatusInternalServerError,
+			}},
+		},
+		"listing properties fails": {
+			params:           ListGroupActivationsRequest{ContractID: "ctr_1-1TJZH5", GroupID: "grp_15225"},
+			propertiesStatus: http.StatusInternalServerError,
+			withError: &Error{
+				Type:       "internal_error",
+				Title:      "Internal Server Error",
+				StatusCode: http.StatusInternalServerError,
+			},
+		},
+		"validation error": {
+			params:    ListGroupActivationsRequest{ContractID: "ctr_1-1TJZH5"},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodGet, r.Method)
+				assert.Equal(t, "ctr_1-1TJZH5", r.URL.Query().Get("contractId"))
+				assert.Equal(t, "grp_15225", r.URL.Query().Get("groupId"))
+				var err error
+				switch r.URL.Path {
+				case "/papi/v1/properties":
+					w.WriteHeader(test.propertiesStatus)
+					if test.propertiesStatus != http.StatusOK {
+						_, err = w.Write([]byte(`{"type": "internal_error", "title": "Internal Server Error", "status": 500}`))
+						break
+					}
+					_, err = w.Write([]byte(propertiesBody))
+				case "/papi/v1/properties/" + test.failingProperty + "/activations":
+					w.WriteHeader(http.StatusInternalServerError)
+					_, err = w.Write([]byte(`{"type": "internal_error", "title": "Internal Server Error", "status": 500}`))
+				case "/papi/v1/properties/prp_175780/activations":
+					w.WriteHeader(http.StatusOK)
+					_, err = w.Write([]byte(activationsBody("prp_175780", "example.com", 2)))
+				case "/papi/v1/properties/prp_175781/activations":
+					w.WriteHeader(http.StatusOK)
+					_, err = w.Write([]byte(activationsBody("prp_175781", "www.example.com", 5)))
+				default:
+					t.Errorf("unexpected path: %s", r.URL.Path)
+				}
+				assert.NoError(t, err)
+			}))
+			defer mockServer.Close()
+			client := mockAPIClient(t, mockServer)
+			result, err := client.ListGroupActivations(context.Background(), test.params)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse.Properties, result.Properties)
+			if test.expectedErrors == nil {
+				assert.Nil(t, result.Errors)
+				return
+			}
+			require.NotNil(t, result.Errors)
+			assert.Len(t, result.Errors.Failures, len(test.expectedErrors))
+			result.Errors.Each(func(failure BulkItemError) {
+				expected := test.expectedErrors[failure.ID]
+				assert.True(t, errors.Is(failure, expected), "want: %s; got: %s", expected, failure)
+			})
+		})
+	}
+}
+
+func TestPapi_ListGroupActivations_ContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/papi/v1/properties" {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(`{"properties": {"items": [{"propertyId": "prp_1"}, {"propertyId": "prp_2"}, {"propertyId": "prp_3"}]}}`))
+			assert.NoError(t, err)
+			return
+		}
+		cancel()
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"activations": {"items": []}}`))
+		assert.NoError(t, err)
+	}))
+	defer mockServer.Close()
+	client := mockAPIClient(t, mockServer)
+
+	_, err := client.ListGroupActivations(ctx, ListGroupActivationsRequest{
+		ContractID:     "ctr_1-1TJZH5",
+		GroupID:        "grp_15225",
+		MaxConcurrency: 1,
+	})
+	assert.True(t, errors.Is(err, context.Canceled), "want: %s; got: %s", context.Canceled, err)
+}
+
+func TestPapi_CreateActivation_Precheck(t *testing.T) {
+	versionBody := func(productionStatus string) string {
+		return fmt.Sprintf(`
+{
+    "propertyId": "prp_175780",
+    "propertyName": "example.com",
+    "versions": {
+        "items": [
+            {
+                "propertyVersion": 3,
+                "productionStatus": "%s",
+                "stagingStatus": "INACTIVE"
+            }
+        ]
+    }
+}`, productionStatus)
+	}
+	created := `
+{
+	"activationLink": "/papi/v1/properties/prp_175780/activations/atv_67037?contractId=ctr_1-1TJZFW&groupId=grp_15166"
+}`
+
+	tests := map[string]struct {
+		activationType   ActivationType
+		precheck         bool
+		productionStatus string
+		expectedMethods  []string
+		withError        error
+	}{
+		"deactivation of active version": {
+			activationType:   ActivationTypeDeactivate,
+			precheck:         true,
+			productionStatus: "ACTIVE",
+			expectedMethods:  []string{http.MethodGet, http.MethodPost},
+		},
+		"deactivation of inactive version": {
+			activationType:   ActivationTypeDeactivate,
+			precheck:         true,
+			productionStatus: "INACTIVE",
+			expectedMethods:  []string{http.MethodGet},
+			withError:        ErrVersionNotActive,
+		},
+		"deactivation without precheck": {
+			activationType:  ActivationTypeDeactivate,
+			expectedMethods: []string{http.MethodPost},
+		},
+		"activation is not prechecked": {
+			activationType:  ActivationTypeActivate,
+			precheck:        true,
+			expectedMethods: []string{http.MethodPost},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var methods []string
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				methods = append(methods, r.Method)
+				var err error
+				if r.Method == http.MethodGet {
+					assert.Equal(t, "/papi/v1/properties/prp_175780/versions/3", r.URL.Path)
+					w.WriteHeader(http.StatusOK)
+					_, err = w.Write([]byte(versionBody(test.productionStatus)))
+				} else {
+					assert.Equal(t, "/papi/v1/properties/prp_175780/activations", r.URL.Path)
+					w.WriteHeader(http.StatusCreated)
+					_, err = w.Write([]byte(created))
+				}
+				assert.NoError(t, err)
+			}))
+			defer mockServer.Close()
+			client := mockAPIClient(t, mockServer)
+			result, err := client.CreateActivation(context.Background(), CreateActivationRequest{
+				PropertyID: "prp_175780",
+				ContractID: "ctr_1-1TJZFW",
+				GroupID:    "grp_15166",
+				Activation: Activation{
+					PropertyVersion: 3,
+					Network:         ActivationNetworkProduction,
+					ActivationType:  test.activationType,
+					NotifyEmails:    []string{"you@example.com"},
+				},
+				Precheck: test.precheck,
+			})
+			assert.Equal(t, test.expectedMethods, methods)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				assert.Contains(t, err.Error(), "version 3 is INACTIVE on PRODUCTION")
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "atv_67037", result.ActivationID)
+		})
+	}
+}
+
+func TestPapi_CreateActivation_ETag(t *testing.T) {
+	tests := map[string]struct {
+		etag           string
+		responseStatus int
+		responseBody   string
+		expectedHeader string
+		withError      error
+	}{
+		"etag sent in If-Match header": {
+			etag:           "1a2b3c4d5e",
+			responseStatus: http.StatusCreated,
+			responseBody: `
+{
+	"activationLink": "/papi/v1/properties/prp_175780/activations/atv_67037?contractId=ctr_1-1TJZFW&groupId=grp_15166"
+}`,
+			expectedHeader: "1a2b3c4d5e",
+		},
+		"no etag, no If-Match header": {
+			responseStatus: http.StatusCreated,
+			responseBody: `
+{
+	"activationLink": "/papi/v1/properties/prp_175780/activations/atv_67037?contractId=ctr_1-1TJZFW&groupId=grp_15166"
+}`,
+		},
+		"412 precondition failed": {
+			etag:           "1a2b3c4d5e",
+			responseStatus: http.StatusPreconditionFailed,
+			responseBody: `
+{
+	"type": "https://problems.luna.akamaiapis.net/papi/v0/precondition-failed",
+	"title": "Precondition Failed",
+	"detail": "The property version has changed",
+	"status": 412
+}`,
+			expectedHeader: "1a2b3c4d5e",
+			withError:      ErrPreconditionFailed,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/papi/v1/properties/prp_175780/activations", r.URL.Path)
+				assert.Equal(t, test.expectedHeader, r.Header.Get("If-Match"))
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			defer mockServer.Close()
+			client := mockAPIClient(t, mockServer)
+			result, err := client.CreateActivation(context.Background(), CreateActivationRequest{
+				PropertyID: "prp_175780",
+				ContractID: "ctr_1-1TJZFW",
+				GroupID:    "grp_15166",
+				Activation: Activation{
+					PropertyVersion: 3,
+					Network:         ActivationNetworkProduction,
+					ActivationType:  ActivationTypeDeactivate,
+					NotifyEmails:    []string{"you@example.com"},
+				},
+				ETag: test.etag,
+			})
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "atv_67037", result.ActivationID)
+		})
+	}
+}
+
+func TestPapi_CreateActivation_WithActivationNoteValidator(t *testing.T) {
+	ticketID := RequireNotePattern(regexp.MustCompile(`\bOPS-\d+\b`))
+	tests := map[string]struct {
+		options       []Option
+		note          string
+		expectedCalls int32
+		withError     error
+	}{
+		"note with ticket reference": {
+			options:       []Option{WithActivationNoteValidator(ticketID)},
+			note:          "OPS-1234 enable http/2",
+			expectedCalls: 1,
+		},
+		"note without ticket reference": {
+			options:   []Option{WithActivationNoteValidator(ticketID)},
+			note:      "enable http/2",
+			withError: ErrInvalidActivationNote,
+		},
+		"default note is validated": {
+			options:       []Option{WithActivationDefaults("OPS-1 routine release"), WithActivationNoteValidator(ticketID)},
+			expectedCalls: 1,
+		},
+		"no validator": {
+			note:          "enable http/2",
+			expectedCalls: 1,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var calls int32
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&calls, 1)
+				w.WriteHeader(http.StatusCreated)
+				_, err := w.Write([]byte(`{"activationLink": "/papi/v1/properties/prp_175780/activations/atv_67037?contractId=ctr_1-1TJZFW&groupId=grp_15166"}`))
+				assert.NoError(t, err)
+			}))
+			defer mockServer.Close()
+			client := mockAPIClient(t, mockServer, test.options...)
+			_, err := client.CreateActivation(context.Background(), CreateActivationRequest{
+				PropertyID: "prp_175780",
+				Activation: Activation{
+					PropertyVersion: 1,
+					Network:         ActivationNetworkStaging,
+					NotifyEmails:    []string{"you@example.com"},
+					Note:            test.note,
+				},
+			})
+			assert.Equal(t, test.expectedCalls, atomic.LoadInt32(&calls))
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				assert.Contains(t, err.Error(), `note "enable http/2" does not match`)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestPapi_CreateActivation_WithNotifyEmailAllowlist(t *testing.T) {
+	tests := map[string]struct {
+		options       []Option
+		notifyEmails  []string
+		expectedCalls int32
+		withError     string
+	}{
+		"allowed addresses": {
+			options:       []Option{WithNotifyEmailAllowlist(AllowEmails("ops@example.com", "Releases@example.com"))},
+			notifyEmails:  []string{"OPS@example.com", "releases@example.com"},
+			expectedCalls: 1,
+		},
+		"disallowed addresses": {
+			options:      []Option{WithNotifyEmailAllowlist(AllowEmails("ops@example.com"))},
+			notifyEmails: []string{"ops@example.com", "you@example.com", "me@example.org"},
+			withError:    "addresses not allowed: you@example.com, me@example.org",
+		},
+		"allowed domain": {
+			options:       []Option{WithNotifyEmailAllowlist(AllowEmailDomains("@lists.example.com"))},
+			notifyEmails:  []string{"ops@lists.example.com", "releases@Lists.Example.com"},
+			expectedCalls: 1,
+		},
+		"disallowed domain": {
+			options:      []Option{WithNotifyEmailAllowlist(AllowEmailDomains("lists.example.com"))},
+			notifyEmails: []string{"ops@lists.example.com", "you@example.com", "ops@sub.lists.example.com"},
+			withError:    "addresses not allowed: you@example.com, ops@sub.lists.example.com",
+		},
+		"default emails are checked": {
+			options: []Option{
+				WithActivationDefaults("routine release", "you@example.com"),
+				WithNotifyEmailAllowlist(AllowEmailDomains("lists.example.com")),
+			},
+			withError: "addresses not allowed: you@example.com",
+		},
+		"no allowlist": {
+			notifyEmails:  []string{"you@example.com"},
+			expectedCalls: 1,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var calls int32
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&calls, 1)
+				w.WriteHeader(http.StatusCreated)
+				_, err := w.Write([]byte(`{"activationLink": "/papi/v1/properties/prp_175780/activations/atv_67037?contractId=ctr_1-1TJZFW&groupId=grp_15166"}`))
+				assert.NoError(t, err)
+			}))
+			defer mockServer.Close()
+			client := mockAPIClient(t, mockServer, test.options...)
+			_, err := client.CreateActivation(context.Background(), CreateActivationRequest{
+				PropertyID: "prp_175780",
+				Activation: Activation{
+					PropertyVersion: 1,
+					Network:         ActivationNetworkStaging,
+					NotifyEmails:    test.notifyEmails,
+					Note:            "enable http/2",
+				},
+			})
+			assert.Equal(t, test.expectedCalls, atomic.LoadInt32(&calls))
+			if test.withError != "" {
+				assert.True(t, errors.Is(err, ErrStructValidation), "want: %s; got: %s", ErrStructValidation, err)
+				assert.Contains(t, err.Error(), test.withError)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestPapi_GetActivations_Expand(t *testing.T) {
+	complete := `
+            {
+                "activationId": "atv_1696985",
+                "propertyName": "example.com",
+                "propertyId": "prp_175780",
+                "propertyVersion": 2,
+                "network": "STAGING",
+                "activationType": "ACTIVATE",
+                "status": "ACTIVE",
+                "submitDate": "2022-10-27T12:32:08Z",
+                "accountId": "act_1-1TJZFB",
+                "groupId": "grp_15166"
+            }`
+	incomplete := `
+            {
+                "activationId": "atv_1696855",
+                "propertyVersion": 1,
+                "network": "STAGING",
+                "activationType": "DEACTIVATE",
+                "status": "INACTIVE",
+                "submitDate": "2020-10-27T12:32:08Z"
+            }`
+	body := func(items ...string) string {
+		return fmt.Sprintf(`
+{
+    "accountId": "act_1-1TJZFB",
+    "contractId": "ctr_1-1TJZFW",
+    "groupId": "grp_15166",
+    "activations": {"items": [%s]}
+}`, strings.Join(items, ","))
+	}
+	expanded := func(id string, version int, activationType ActivationType, status ActivationStatus, submitDate string) *Activation {
+		return &Activation{
+			AccountID:       "act_1-1TJZFB",
+			ActivationID:    id,
+			ActivationType:  activationType,
+			GroupID:         "grp_15166",
+			Network:         ActivationNetworkStaging,
+			PropertyID:      "prp_175780",
+			PropertyName:    "example.com",
+			PropertyVersion: version,
+			Status:          status,
+			SubmitDate:      submitDate,
+		}
+	}
+
+	tests := map[string]struct {
+		expand        bool
+		responseBody  string
+		expectedPaths []string
+		expectedItems []*Activation
+	}{
+		"mixed items are backfilled": {
+			expand:       true,
+			responseBody: body(complete, incomplete),
+			expectedPaths: []string{
+				"/papi/v1/properties/prp_175780/activations",
+				"/papi/v1/properties/prp_175780",
+			},
+			expectedItems: []*Activation{
+				expanded("atv_1696985", 2, ActivationTypeActivate, ActivationStatusActive, "2022-10-27T12:32:08Z"),
+				expanded("atv_1696855", 1, ActivationTypeDeactivate, ActivationStatusInactive, "2020-10-27T12:32:08Z"),
+			},
+		},
+		"complete items need no property lookup": {
+			expand:        true,
+			responseBody:  body(complete),
+			expectedPaths: []string{"/papi/v1/properties/prp_175780/activations"},
+			expectedItems: []*Activation{
+				expanded("atv_1696985", 2, ActivationTypeActivate, ActivationStatusActive, "2022-10-27T12:32:08Z"),
+			},
+		},
+		"items are not expanded by default": {
+			responseBody:  body(incomplete),
+			expectedPaths: []string{"/papi/v1/properties/prp_175780/activations"},
+			expectedItems: []*Activation{
+				{
+					ActivationID:    "atv_1696855",
+					ActivationType:  ActivationTypeDeactivate,
+					Network:         ActivationNetworkStaging,
+					PropertyVersion: 1,
+					Status:          ActivationStatusInactive,
+					SubmitDate:      "2020-10-27T12:32:08Z",
+				},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var paths []string
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				paths = append(paths, r.URL.Path)
+				assert.Equal(t, http.MethodGet, r.Method)
+				w.WriteHeader(http.StatusOK)
+				var err error
+				if r.URL.Path == "/papi/v1/properties/prp_175780" {
+					_, err = w.Write([]byte(`{"properties": {"items": [{"propertyId": "prp_175780", "propertyName": "example.com"}]}}`))
+				} else {
+					_, err = w.Write([]byte(test.responseBody))
+				}
+				assert.NoError(t, err)
+			}))
+			defer mockServer.Close()
+			client := mockAPIClient(t, mockServer)
+			result, err := client.GetActivations(context.Background(), GetActivationsRequest{
+				PropertyID: "prp_175780",
+				ContractID: "ctr_1-1TJZFW",
+				GroupID:    "grp_15166",
+				Expand:     test.expand,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedPaths, paths)
+			require.Len(t, result.Activations.Items, len(test.expectedItems))
+			for i, expected := range test.expectedItems {
+				actual := *result.Activations.Items[i]
+				actual.AdditionalFields = nil
+				assert.Equal(t, *expected, actual)
+			}
+		})
+	}
+}
+
+func TestActivationType_Validate(t *testing.T) {
+	tests := map[string]struct {
+		activationType ActivationType
+		withError      bool
+	}{
+		"activate":   {activationType: ActivationTypeActivate},
+		"deactivate": {activationType: ActivationTypeDeactivate},
+		"empty":      {activationType: ""},
+		"lower case": {activationType: "activate", withError: true},
+		"unknown":    {activationType: "RESTART", withError: true},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := test.activationType.Validate()
+			if test.withError {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestPapi_GetActivations_ActivationType(t *testing.T) {
+	responseBody := `
+{
+    "activations": {
+        "items": [
+            {"activationId": "atv_3", "activationType": "DEACTIVATE", "propertyVersion": 2, "network": "STAGING"},
+            {"activationId": "atv_2", "activationType": "ACTIVATE", "propertyVersion": 2, "network": "STAGING"},
+            {"activationId": "atv_1", "activationType": "ACTIVATE", "propertyVersion": 1, "network": "STAGING"}
+        ]
+    }
+}`
+	tests := map[string]struct {
+		activationType ActivationType
+		expectedIDs    []string
+		withError      error
+	}{
+		"activations only": {
+			activationType: ActivationTypeActivate,
+			expectedIDs:    []string{"atv_2", "atv_1"},
+		},
+		"deactivations only": {
+			activationType: ActivationTypeDeactivate,
+			expectedIDs:    []string{"atv_3"},
+		},
+		"no filter": {
+			expectedIDs: []string{"atv_3", "atv_2", "atv_1"},
+		},
+		"invalid type": {
+			activationType: "RESTART",
+			withError:      ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				_, err := w.Write([]byte(responseBody))
+				assert.NoError(t, err)
+			}))
+			defer mockServer.Close()
+			client := mockAPIClient(t, mockServer)
+			result, err := client.GetActivations(context.Background(), GetActivationsRequest{
+				PropertyID:     "prp_175780",
+				ActivationType: test.activationType,
+			})
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				assert.Contains(t, err.Error(), "ActivationType")
+				return
+			}
+			require.NoError(t, err)
+			var ids []string
+			for _, activation := range result.Activations.Items {
+				ids = append(ids, activation.ActivationID)
+			}
+			assert.Equal(t, test.expectedIDs, ids)
+		})
+	}
+}
+
+func TestActivationFallbackInfo_UnmarshalJSON(t *testing.T) {
+	tests := map[string]struct {
+		given     string
+		expected  ActivationFallbackInfo
+		withError bool
+	}{
+		"numeric values": {
+			given: `{"fastFallbackAttempted": false, "fallbackVersion": 10, "canFastFallback": true, "steadyStateTime": 1506448172, "fastFallbackExpirationTime": 1506451772}`,
+			expected: ActivationFallbackInfo{
+				FallbackVersion:            10,
+				CanFastFallback:            true,
+				SteadyStateTime:            1506448172,
+				FastFallbackExpirationTime: 1506451772,
+			},
+		},
+		"string encoded values": {
+			given: `{"fastFallbackAttempted": false, "fallbackVersion": "10", "canFastFallback": true, "steadyStateTime": "1506448172", "fastFallbackExpirationTime": "1506451772"}`,
+			expected: ActivationFallbackInfo{
+				FallbackVersion:            10,
+				CanFastFallback:            true,
+				SteadyStateTime:            1506448172,
+				FastFallbackExpirationTime: 1506451772,
+			},
+		},
+		"empty and null values": {
+			given:    `{"fallbackVersion": "", "steadyStateTime": null, "fastFallbackRecoveryState": "RECOVERING"}`,
+			expected: ActivationFallbackInfo{FastFallbackRecoveryState: tools.StringPtr("RECOVERING")},
+		},
+		"invalid string value": {
+			given:     `{"steadyStateTime": "yesterday"}`,
+			withError: true,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var info ActivationFallbackInfo
+			err := json.Unmarshal([]byte(test.given), &info)
+			if test.withError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, info)
+		})
+	}
+}
+
+func TestActivation_FastFallback(t *testing.T) {
+	now := time.Unix(1506450000, 0)
+	tests := map[string]struct {
+		fallbackInfo     *ActivationFallbackInfo
+		expectedCan      bool
+		expectedTimeLeft time.Duration
+	}{
+		"fallback available": {
+			fallbackInfo:     &ActivationFallbackInfo{CanFastFallback: true, FallbackVersion: 10, FastFallbackExpirationTime: 1506451772},
+			expectedCan:      true,
+			expectedTimeLeft: 1772 * time.Second,
+		},
+		"fallback expired": {
+			fallbackInfo: &ActivationFallbackInfo{CanFastFallback: true, FallbackVersion: 10, FastFallbackExpirationTime: 1506448172},
+		},
+		"fallback not allowed": {
+			fallbackInfo:     &ActivationFallbackInfo{CanFastFallback: false, FallbackVersion: 10, FastFallbackExpirationTime: 1506451772},
+			expectedTimeLeft: 1772 * time.Second,
+		},
+		"expiration time not known": {
+			fallbackInfo: &ActivationFallbackInfo{CanFastFallback: true, FallbackVersion: 10},
+		},
+		"no fallback info": {},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			activation := Activation{FallbackInfo: test.fallbackInfo}
+			assert.Equal(t, test.expectedCan, activation.CanFastFallback(now))
+			assert.Equal(t, test.expectedTimeLeft, activation.TimeUntilFallbackExpires(now))
+		})
+	}
+}
+
+func TestActivation_MarshalJSON(t *testing.T) {
+	tests := map[string]struct {
+		notifyEmails []string
+		expected     string
+	}{
+		"nil notify emails": {
+			notifyEmails: nil,
+			expected:     `[]`,
+		},
+		"empty notify emails": {
+			notifyEmails: []string{},
+			expected:     `[]`,
+		},
+		"populated notify emails": {
+			notifyEmails: []string{"you@example.com", "them@example.com"},
+			expected:     `["you@example.com","them@example.com"]`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			body, err := json.Marshal(Activation{PropertyVersion: 1, Network: ActivationNetworkStaging, NotifyEmails: test.notifyEmails})
+			require.NoError(t, err)
+			var fields map[string]json.RawMessage
+			require.NoError(t, json.Unmarshal(body, &fields))
+			assert.JSONEq(t, test.expected, string(fields["notifyEmails"]))
+		})
+	}
+}
+
+func TestPapi_GetActivationErrors(t *testing.T) {
+	tests := map[string]struct {
+		request          GetActivationErrorsRequest
+		responseStatus   int
+		responseBody     string
+		expectedResponse *GetActivationErrorsResponse
+		withError        error
+	}{
+		"failed activation": {
+			request:        GetActivationErrorsRequest{PropertyID: "prp_175780", ActivationID: "atv_1696855", ContractID: "ctr_1-1TJZFW", GroupID: "grp_15166"},
+			responseStatus: http.StatusOK,
+			responseBody: `
+{
+	"accountId": "act_1-1TJZFB",
+	"contractId": "ctr_1-1TJZFW",
+	"groupId": "grp_15166",
+	"warnings": [
+		{
+			"type": "https://problems.example.net/papi/v0/validation/deprecated_behavior",
+			"title": "Deprecated behavior",
+			"detail": "The behavior is deprecated"
+		}
+	],
+	"activations": {
+		"items": [
+			{
+				"activationId": "atv_1696855",
+				"propertyName": "example.com",
+				"propertyId": "prp_175780",
+				"propertyVersion": 1,
+				"network": "STAGING",
+				"activationType": "ACTIVATE",
+				"status": "FAILED",
+				"errors": [
+					{
+						"type": "https://problems.example.net/papi/v0/activation/invalid_origin",
+						"title": "Invalid origin",
+						"detail": "The origin hostname does not resolve"
+					}
+				]
+			}
+		]
+	}
+}`,
+			expectedResponse: &GetActivationErrorsResponse{
+				Status: ActivationStatusFailed,
+				Errors: []*Error{
+					{
+						Type:   "https://problems.example.net/papi/v0/activation/invalid_origin",
+						Title:  "Invalid origin",
+						Detail: "The origin hostname does not resolve",
+					},
+				},
+				Warnings: []*Error{
+					{
+						Type:   "https://problems.example.net/papi/v0/validation/deprecated_behavior",
+						Title:  "Deprecated behavior",
+						Detail: "The behavior is deprecated",
+					},
+				},
+			},
+		},
+		"activation not failed": {
+			request:        GetActivationErrorsRequest{PropertyID: "prp_175780", ActivationID: "atv_1696855", ContractID: "ctr_1-1TJZFW", GroupID: "grp_15166"},
+			responseStatus: http.StatusOK,
+			responseBody: `
+{
+	"activations": {
+		"items": [
+			{
+				"activationId": "atv_1696855",
+				"propertyId": "prp_175780",
+				"propertyVersion": 1,
+				"network": "STAGING",
+				"status": "ACTIVE"
+			}
+		]
+	}
+}`,
+			expectedResponse: &GetActivationErrorsResponse{Status: ActivationStatusActive},
+		},
+		"missing activation ID": {
+			request:   GetActivationErrorsRequest{PropertyID: "prp_175780"},
+			withError: ErrStructValidation,
+		},
+		"activation not found": {
+			request:        GetActivationErrorsRequest{PropertyID: "prp_175780", ActivationID: "atv_1696855", ContractID: "ctr_1-1TJZFW", GroupID: "grp_15166"},
+			responseStatus: http.StatusOK,
+			responseBody:   `{"activations": {"items": []}}`,
+			withError:      ErrNotFound,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/papi/v1/properties/prp_175780/activations/atv_1696855?contractId=ctr_1-1TJZFW&groupId=grp_15166", r.URL.String())
+				assert.Equal(t, http.MethodGet, r.Method)
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			defer mockServer.Close()
+			client := mockAPIClient(t, mockServer)
+			result, err := client.GetActivationErrors(context.Background(), test.request)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestPapi_CreateActivation_OnlyIfNotActive(t *testing.T) {
+	versionBody := func(productionStatus string) string {
+		return fmt.Sprintf(`
+{
+    "propertyId": "prp_175780",
+    "versions": {
+        "items": [
+            {
+                "propertyVersion": 3,
+                "productionStatus": "%s",
+                "stagingStatus": "INACTIVE"
+            }
+        ]
+    }
+}`, productionStatus)
+	}
+	activations := `
+{
+	"accountId": "act_1-1TJZFB",
+	"contractId": "ctr_1-1TJZFW",
+	"groupId": "grp_15166",
+	"activations": {
+		"items": [
+			{
+				"activationId": "atv_1696800",
+				"propertyVersion": 3,
+				"network": "PRODUCTION",
+				"activationType": "ACTIVATE",
+				"status": "ACTIVE",
+				"submitDate": "2022-10-20T10:00:00Z"
+			},
+			{
+				"activationId": "atv_1696855",
+				"propertyVersion": 3,
+				"network": "PRODUCTION",
+				"activationType": "ACTIVATE",
+				"status": "ACTIVE",
+				"submitDate": "2022-10-27T10:00:00Z"
+			},
+			{
+				"activationId": "atv_1696860",
+				"propertyVersion": 3,
+				"network": "STAGING",
+				"activationType": "ACTIVATE",
+				"status": "ACTIVE",
+				"submitDate": "2022-10-28T10:00:00Z"
+			}
+		]
+	}
+}`
+	created := `
+{
+	"activationLink": "/papi/v1/properties/prp_175780/activations/atv_67037?contractId=ctr_1-1TJZFW&groupId=grp_15166"
+}`
+
+	tests := map[string]struct {
+		options          []Option
+		productionStatus string
+		activationsBody  string
+		expectedRequests []string
+		expectedResponse *CreateActivationResponse
+	}{
+		"already active version is skipped": {
+			productionStatus: "ACTIVE",
+			activationsBody:  activations,
+			expectedRequests: []string{
+				"GET /papi/v1/properties/prp_175780/versions/3",
+				"GET /papi/v1/properties/prp_175780/activations",
+			},
+			expectedResponse: &CreateActivationResponse{
+				Response:       Response{AccountID: "act_1-1TJZFB", ContractID: "ctr_1-1TJZFW", GroupID: "grp_15166"},
+				ActivationID:   "atv_1696855",
+				ActivationLink: "/papi/v1/properties/prp_175780/activations/atv_1696855?contractId=ctr_1-1TJZFW&groupId=grp_15166",
+			},
+		},
+		"already active version with api version": {
+			options:          []Option{WithAPIVersion("v2")},
+			productionStatus: "ACTIVE",
+			activationsBody:  activations,
+			expectedRequests: []string{
+				"GET /papi/v2/properties/prp_175780/versions/3",
+				"GET /papi/v2/properties/prp_175780/activations",
+			},
+			expectedResponse: &CreateActivationResponse{
+				Response:       Response{AccountID: "act_1-1TJZFB", ContractID: "ctr_1-1TJZFW", GroupID: "grp_15166"},
+				ActivationID:   "atv_1696855",
+				ActivationLink: "/papi/v2/properties/prp_175780/activations/atv_1696855?contractId=ctr_1-1TJZFW&groupId=grp_15166",
+			},
+		},
+		"already active version without listed activation": {
+			productionStatus: "ACTIVE",
+			activationsBody:  `{"contractId": "ctr_1-1TJZFW", "groupId": "grp_15166", "activations": {"items": []}}`,
+			expectedRequests: []string{
+				"GET /papi/v1/properties/prp_175780/versions/3",
+				"GET /papi/v1/properties/prp_175780/activations",
+			},
+			expectedResponse: &CreateActivationResponse{
+				Response: Response{ContractID: "ctr_1-1TJZFW", GroupID: "grp_15166"},
+			},
+		},
+		"already active version with null activation": {
+			productionStatus: "ACTIVE",
+			activationsBody: `
+{
+	"contractId": "ctr_1-1TJZFW",
+	"groupId": "grp_15166",
+	"activations": {
+		"items": [
+			null,
+			{"activationId": "atv_1696855", "propertyVersion": 3, "network": "PRODUCTION", "activationType": "ACTIVATE", "status": "ACTIVE", "submitDate": "2022-10-27T10:00:00Z"}
+		]
+	}
+}`,
+			expectedRequests: []string{
+				"GET /papi/v1/properties/prp_175780/versions/3",
+				"GET /papi/v1/properties/prp_175780/activations",
+			},
+			expectedResponse: &CreateActivationResponse{
+				Response:       Response{ContractID: "ctr_1-1TJZFW", GroupID: "grp_15166"},
+				ActivationID:   "atv_1696855",
+				ActivationLink: "/papi/v1/properties/prp_175780/activations/atv_1696855?contractId=ctr_1-1TJZFW&groupId=grp_15166",
+			},
+		},
+		"inactive version is submitted": {
+			productionStatus: "INACTIVE",
+			expectedRequests: []string{
+				"GET /papi/v1/properties/prp_175780/versions/3",
+				"POST /papi/v1/properties/prp_175780/activations",
+			},
+			expectedResponse: &CreateActivationResponse{
+				ActivationID:   "atv_67037",
+				ActivationLink: "/papi/v1/properties/prp_175780/activations/atv_67037?contractId=ctr_1-1TJZFW&groupId=grp_15166",
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var requests []string
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests = append(requests, r.Method+" "+r.URL.Path)
+				var err error
+				switch r.Method + " " + strings.Replace(r.URL.Path, "/papi/v2/", "/papi/v1/", 1) {
+				case "GET /papi/v1/properties/prp_175780/versions/3":
+					w.WriteHeader(http.StatusOK)
+					_, err = w.Write([]byte(versionBody(test.productionStatus)))
+				case "GET /papi/v1/properties/prp_175780/activations":
+					w.WriteHeader(http.StatusOK)
+					_, err = w.Write([]byte(test.activationsBody))
+				default:
+					w.WriteHeader(http.StatusCreated)
+					_, err = w.Write([]byte(created))
+				}
+				assert.NoError(t, err)
+			}))
+			defer mockServer.Close()
+			client := mockAPIClient(t, mockServer, test.options...)
+			result, err := client.CreateActivation(context.Background(), CreateActivationRequest{
+				PropertyID: "prp_175780",
+				ContractID: "ctr_1-1TJZFW",
+				GroupID:    "grp_15166",
+				Activation: Activation{
+					PropertyVersion: 3,
+					Network:         ActivationNetworkProduction,
+					NotifyEmails:    []string{"you@example.com"},
+				},
+				OnlyIfNotActive: true,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedRequests, requests)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestPapi_RollbackActivation(t *testing.T) {
+	created := `
+{
+	"activationLink": "/papi/v1/properties/prp_175780/activations/atv_67037?contractId=ctr_1-1TJZFW&groupId=grp_15166"
+}`
+
+	tests := map[string]struct {
+		params           RollbackActivationRequest
+		activationsBody  string
+		expectedVersion  int
+		expectedRequests []string
+		expectedResponse *RollbackActivationResponse
+		withError        func(*testing.T, error)
+	}{
+		"prior version is activated": {
+			params: RollbackActivationRequest{
+				PropertyID:   "prp_175780",
+				ContractID:   "ctr_1-1TJZFW",
+				GroupID:      "grp_15166",
+				Network:      ActivationNetworkProduction,
+				Note:         "rollback",
+				NotifyEmails: []string{"you@example.com"},
+			},
+			activationsBody: `
+{
+	"activations": {
+		"items": [
+			{"activationId": "atv_5", "propertyVersion": 5, "network": "STAGING", "activationType": "ACTIVATE", "status": "ACTIVE", "submitDate": "2022-10-29T10:00:00Z"},
+			{"activationId": "atv_4", "propertyVersion": 4, "network": "PRODUCTION", "activationType": "ACTIVATE", "status": "ACTIVE", "submitDate": "2022-10-28T10:00:00Z"},
+			{"activationId": "atv_3f", "propertyVersion": 3, "network": "PRODUCTION", "activationType": "ACTIVATE", "status": "FAILED", "submitDate": "2022-10-27T10:00:00Z"},
+			{"activationId": "atv_4a", "propertyVersion": 4, "network": "PRODUCTION", "activationType": "ACTIVATE", "status": "INACTIVE", "submitDate": "2022-10-26T10:00:00Z"},
+			{"activationId": "atv_2", "propertyVersion": 2, "network": "PRODUCTION", "activationType": "ACTIVATE", "status": "INACTIVE", "submitDate": "2022-10-20T10:00:00Z"},
+			{"activationId": "atv_1", "propertyVersion": 1, "network": "PRODUCTION", "activationType": "ACTIVATE", "status": "INACTIVE", "submitDate": "2022-10-10T10:00:00Z"}
+		]
+	}
+}`,
+			expectedVersion: 2,
+			expectedRequests: []string{
+				"GET /papi/v1/properties/prp_175780/activations",
+				"POST /papi/v1/properties/prp_175780/activations",
+			},
+			expectedResponse: &RollbackActivationResponse{
+				CreateActivationResponse: CreateActivationResponse{
+					ActivationID:   "atv_67037",
+					ActivationLink: "/papi/v1/properties/prp_175780/activations/atv_67037?contractId=ctr_1-1TJZFW&groupId=grp_15166",
+				},
+				ActiveVersion:   4,
+				RollbackVersion: 2,
+			},
+		},
+		"null activation skipped": {
+			params: RollbackActivationRequest{
+				PropertyID:   "prp_175780",
+				ContractID:   "ctr_1-1TJZFW",
+				GroupID:      "grp_15166",
+				Network:      ActivationNetworkProduction,
+				NotifyEmails: []string{"you@example.com"},
+			},
+			activationsBody: `
+{
+	"activations": {
+		"items": [
+			{"activationId": "atv_4", "propertyVersion": 4, "network": "PRODUCTION", "activationType": "ACTIVATE", "status": "ACTIVE", "submitDate": "2022-10-28T10:00:00Z"},
+			null,
+			{"activationId": "atv_2", "propertyVersion": 2, "network": "PRODUCTION", "activationType": "ACTIVATE", "status": "INACTIVE", "submitDate": "2022-10-20T10:00:00Z"}
+		]
+	}
+}`,
+			expectedVersion: 2,
+			expectedRequests: []string{
+				"GET /papi/v1/properties/prp_175780/activations",
+				"POST /papi/v1/properties/prp_175780/activations",
+			},
+			expectedResponse: &RollbackActivationResponse{
+				CreateActivationResponse: CreateActivationResponse{
+					ActivationID:   "atv_67037",
+					ActivationLink: "/papi/v1/properties/prp_175780/activations/atv_67037?contractId=ctr_1-1TJZFW&groupId=grp_15166",
+				},
+				ActiveVersion:   4,
+				RollbackVersion: 2,
+			},
+		},
+		"only one version activated": {
+			params: RollbackActivationRequest{
+				PropertyID:   "prp_175780",
+				Network:      ActivationNetworkProduction,
+				NotifyEmails: []string{"you@example.com"},
+			},
+			activationsBody: `
+{
+	"activations": {
+		"items": [
+			{"activationId": "atv_2", "propertyVersion": 2, "network": "PRODUCTION", "activationType": "ACTIVATE", "status": "ACTIVE", "submitDate": "2022-10-20T10:00:00Z"},
+			{"activationId": "atv_2a", "propertyVersion": 2, "network": "PRODUCTION", "activationType": "ACTIVATE", "status": "INACTIVE", "submitDate": "2022-10-10T10:00:00Z"},
+			{"activationId": "atv_1", "propertyVersion": 1, "network": "STAGING", "activationType": "ACTIVATE", "status": "INACTIVE", "submitDate": "2022-10-01T10:00:00Z"}
+		]
+	}
+}`,
+			expectedRequests: []string{
+				"GET /papi/v1/properties/prp_175780/activations",
+			},
+			withError: func(t *testing.T, err error) {
+				assert.True(t, errors.Is(err, ErrNoRollbackVersion), "want: %s; got: %s", ErrNoRollbackVersion, err)
+			},
+		},
+		"property deactivated": {
+			params: RollbackActivationRequest{
+				PropertyID:   "prp_175780",
+				Network:      ActivationNetworkProduction,
+				NotifyEmails: []string{"you@example.com"},
+			},
+			activationsBody: `
+{
+	"activations": {
+		"items": [
+			{"activationId": "atv_3", "propertyVersion": 2, "network": "PRODUCTION", "activationType": "DEACTIVATE", "status": "ACTIVE", "submitDate": "2022-10-21T10:00:00Z"},
+			{"activationId": "atv_2", "propertyVersion": 2, "network": "PRODUCTION", "activationType": "ACTIVATE", "status": "INACTIVE", "submitDate": "2022-10-20T10:00:00Z"},
+			{"activationId": "atv_1", "propertyVersion": 1, "network": "PRODUCTION", "activationType": "ACTIVATE", "status": "INACTIVE", "submitDate": "2022-10-10T10:00:00Z"}
+		]
+	}
+}`,
+			expectedRequests: []string{
+				"GET /papi/v1/properties/prp_175780/activations",
+			},
+			withError: func(t *testing.T, err error) {
+				assert.True(t, errors.Is(err, ErrNoRollbackVersion), "want: %s; got: %s", ErrNoRollbackVersion, err)
+			},
+		},
+		"validation error": {
+			params: RollbackActivationRequest{
+				PropertyID: "prp_175780",
+			},
+			withError: func(t *testing.T, err error) {
+				assert.True(t, errors.Is(err, ErrStructValidation), "want: %s; got: %s", ErrStructValidation, err)
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var requests []string
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests = append(requests, r.Method+" "+r.URL.Path)
+				var err error
+				if r.Method == http.MethodGet {
+					w.WriteHeader(http.StatusOK)
+					_, err = w.Write([]byte(test.activationsBody))
+				} else {
+					var activation Activation
+					require.NoError(t, json.NewDecoder(r.Body).Decode(&activation))
+					assert.Equal(t, test.expectedVersion, activation.PropertyVersion)
+					assert.Equal(t, test.params.Network, activation.Network)
+					assert.Equal(t, test.params.Note, activation.Note)
+					w.WriteHeader(http.StatusCreated)
+					_, err = w.Write([]byte(created))
+				}
+				assert.NoError(t, err)
+			}))
+			defer mockServer.Close()
+			client := mockAPIClient(t, mockServer)
+			result, err := client.RollbackActivation(context.Background(), test.params)
+			assert.Equal(t, test.expectedRequests, requests)
+			if test.withError != nil {
+				test.withError(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestPapi_GetActivations_CreatedBy(t *testing.T) {
+	responseBody := `
+{
+    "activations": {
+        "items": [
+            {"activationId": "atv_4", "activationType": "ACTIVATE", "propertyVersion": 3, "network": "STAGING", "createdBy": "jsmith"},
+            {"activationId": "atv_3", "activationType": "DEACTIVATE", "propertyVersion": 2, "network": "STAGING", "createdBy": "adoe"},
+            {"activationId": "atv_2", "activationType": "ACTIVATE", "propertyVersion": 2, "network": "STAGING", "createdBy": "jsmith"},
+            {"activationId": "atv_1", "activationType": "ACTIVATE", "propertyVersion": 1, "network": "STAGING"}
+        ]
+    }
+}`
+	tests := map[string]struct {
+		createdBy   string
+		expectedIDs []string
+	}{
+		"activations of a user": {
+			createdBy:   "jsmith",
+			expectedIDs: []string{"atv_4", "atv_2"},
+		},
+		"activations of another user": {
+			createdBy:   "adoe",
+			expectedIDs: []string{"atv_3"},
+		},
+		"unknown user": {
+			createdBy: "nobody",
+		},
+		"no filter": {
+			expectedIDs: []string{"atv_4", "atv_3", "atv_2", "atv_1"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				_, err := w.Write([]byte(responseBody))
+				assert.NoError(t, err)
+			}))
+			defer mockServer.Close()
+			client := mockAPIClient(t, mockServer)
+			result, err := client.GetActivations(context.Background(), GetActivationsRequest{
+				PropertyID: "prp_175780",
+				CreatedBy:  test.createdBy,
+			})
+			require.NoError(t, err)
+			var ids []string
+			for _, activation := range result.Activations.Items {
+				ids = append(ids, activation.ActivationID)
+			}
+			assert.Equal(t, test.expectedIDs, ids)
+		})
+	}
+}
+
+func TestPapi_ActivationLogFields(t *testing.T) {
+	mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var err error
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusCreated)
+			_, err = w.Write([]byte(`{"activationLink": "/papi/v1/properties/prp_175780/activations/atv_1696985"}`))
+		} else {
+			w.WriteHeader(http.StatusOK)
+			_, err = w.Write([]byte(`{"activations": {"items": [{"activationId": "atv_1696985", "status": "ACTIVE"}]}}`))
+		}
+		assert.NoError(t, err)
+	}))
+	defer mockServer.Close()
+	client := mockAPIClient(t, mockServer)
+
+	tests := map[string]struct {
+		call           func(context.Context) error
+		expectedFields log.Fields
+	}{
+		"CreateActivation": {
+			call: func(ctx context.Context) error {
+				_, err := client.CreateActivation(ctx, CreateActivationRequest{
+					PropertyID: "prp_175780",
+					Activation: Activation{
+						PropertyVersion: 3,
+						Network:         ActivationNetworkStaging,
+						NotifyEmails:    []string{"you@example.com"},
+					},
+				})
+				return err
+			},
+			expectedFields: log.Fields{"property_id": "prp_175780", "version": 3, "network": ActivationNetworkStaging},
+		},
+		"GetActivation": {
+			call: func(ctx context.Context) error {
+				_, err := client.GetActivation(ctx, GetActivationRequest{PropertyID: "prp_175780", ActivationID: "atv_1696985"})
+				return err
+			},
+			expectedFields: log.Fields{"property_id": "prp_175780", "activation_id": "atv_1696985"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			handler := memory.New()
+			ctx := session.ContextWithOptions(context.Background(),
+				session.WithContextLog(&log.Logger{Handler: handler, Level: log.DebugLevel}))
+			require.NoError(t, test.call(ctx))
+
+			var entry *log.Entry
+			for _, e := range handler.Entries {
+				if e.Message == name {
+					entry = e
+				}
+			}
+			require.NotNil(t, entry, "no %q log entry", name)
+			assert.Equal(t, test.expectedFields, entry.Fields)
+		})
+	}
+}
+
+func TestPapi_GetActivationsByNetwork(t *testing.T) {
+	responseBody := `
+{
+    "activations": {
+        "items": [
+            {"activationId": "atv_4", "activationType": "ACTIVATE", "propertyVersion": 3, "network": "PRODUCTION", "status": "ACTIVE"},
+            {"activationId": "atv_3", "activationType": "ACTIVATE", "propertyVersion": 3, "network": "STAGING", "status": "ACTIVE"},
+            {"activationId": "atv_2", "activationType": "ACTIVATE", "propertyVersion": 2, "network": "PRODUCTION", "status": "INACTIVE"},
+            {"activationId": "atv_1", "activationType": "ACTIVATE", "propertyVersion": 1, "network": "STAGING", "status": "INACTIVE"}
+        ]
+    }
+}`
+	tests := map[string]struct {
+		params             GetActivationsRequest
+		responseStatus     int
+		responseBody       string
+		expectedStaging    []string
+		expectedProduction []string
+		withError          func(*testing.T, error)
+	}{
+		"activations grouped by network": {
+			params:             GetActivationsRequest{PropertyID: "prp_175780", ContractID: "ctr_1-1TJZFW", GroupID: "grp_15166"},
+			responseStatus:     http.StatusOK,
+			responseBody:       responseBody,
+			expectedStaging:    []string{"atv_3", "atv_1"},
+			expectedProduction: []string{"atv_4", "atv_2"},
+		},
+		"filters of the request applied": {
+			params:             GetActivationsRequest{PropertyID: "prp_175780", ContractID: "ctr_1-1TJZFW", GroupID: "grp_15166", ActivationType: ActivationTypeDeactivate},
+			responseStatus:     http.StatusOK,
+			responseBody:       responseBody,
+			expectedStaging:    []string{},
+			expectedProduction: []string{},
+		},
+		"500 internal server error": {
+			params:         GetActivationsRequest{PropertyID: "prp_175780", ContractID: "ctr_1-1TJZFW", GroupID: "grp_15166"},
+			responseStatus: http.StatusInternalServerError,
+			responseBody: `
+{
+	"type": "internal_error",
+	"title": "Internal Server Error",
+	"detail": "Error fetching activations",
+	"status": 500
+}`,
+			withError: func(t *testing.T, err error) {
+				want := &Error{
+					Type:       "internal_error",
+					Title:      "Internal Server Error",
+					Detail:     "Error fetching activations",
+					StatusCode: http.StatusInternalServerError,
+				}
+				assert.True(t, errors.Is(err, want), "want: %s; got: %s", want, err)
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/papi/v1/properties/prp_175780/activations", r.URL.Path)
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			defer mockServer.Close()
+			client := mockAPIClient(t, mockServer)
+			result, err := client.GetActivationsByNetwork(context.Background(), test.params)
+			if test.withError != nil {
+				test.withError(t, err)
+				return
+			}
+			require.NoError(t, err)
+			activationIDs := func(activations []*Activation) []string {
+				ids := []string{}
+				for _, activation := range activations {
+					ids = append(ids, activation.ActivationID)
+				}
+				return ids
+			}
+			assert.Equal(t, test.expectedStaging, activationIDs(result.Staging))
+			assert.Equal(t, test.expectedProduction, activationIDs(result.Production))
+		})
+	}
+}
@@ -14,6 +14,14 @@ type (
 		// GetContract provides a read-only list of contract names and identifiers
 		// See: https://developer.akamai.com/api/core_features/property_manager/v1.html#getcontracts
 		GetContracts(context.Context) (*GetContractsResponse, error)
+
+		// VerifyCredentials checks that the configured host and credentials are accepted by the API, by fetching the contracts
+		// It returns ErrInvalidCredentials if the request was not authorized
+		VerifyCredentials(context.Context) error
+
+		// GetAccountID returns the ID of the account the credentials belong to, as reported by the contracts response
+		// It is fetched once and cached for the lifetime of the client
+		GetAccountID(context.Context) (string, error)
 	}
 
 	// Contract represents a property contract resource
@@ -38,6 +46,12 @@ type (
 var (
 	// ErrGetContracts represents error when fetching contracts fails
 	ErrGetContracts = errors.New("fetching contracts")
+	// ErrVerifyCredentials represents error when verifying credentials fails
+	ErrVerifyCredentials = errors.New("verifying credentials")
+	// ErrGetAccountID represents error when fetching the account ID fails
+	ErrGetAccountID = errors.New("fetching account ID")
+	// ErrInvalidCredentials is returned when the API rejects the configured credentials
+	ErrInvalidCredentials = errors.New("invalid credentials")
 )
 
 func (p *papi) GetContracts(ctx context.Context) (*GetContractsResponse, error) {
@@ -62,3 +76,57 @@ func (p *papi) GetContracts(ctx context.Context) (*GetContractsResponse, error)
 
 	return &contracts, nil
 }
+
+func (p *papi) VerifyCredentials(ctx context.Context) error {
+	logger := p.Log(ctx)
+	logger.Debug("VerifyCredentials")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "/papi/v1/contracts", nil)
+	if err != nil {
+		return fmt.Errorf("%w: failed to create request: %s", ErrVerifyCredentials, err)
+	}
+
+	resp, err := p.Exec(req, nil)
+	if err != nil {
+		return fmt.Errorf("%w: request failed: %s", ErrVerifyCredentials, err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return fmt.Errorf("%s: %w: %s", ErrVerifyCredentials, ErrInvalidCredentials, p.Error(resp))
+	default:
+		return fmt.Errorf("%s: %w", ErrVerifyCredentials, p.Error(resp))
+	}
+}
+
+func (p *papi) GetAccountID(ctx context.Context) (string, error) {
+	logger := p.Log(ctx)
+	logger.Debug("GetAccountID")
+
+	p.accountMu.Lock()
+	accountID := p.accountID
+	p.accountMu.Unlock()
+	if accountID != "" {
+		return accountID, nil
+	}
+
+	// the lock is not held while fetching, so that a slow or canceled request does not block other callers.
+	// Concurrent callers may repeat the lookup until the first one completes, singleflight would avoid it,
+	// but golang.org/x/sync is not a dependency of this module
+	contracts, err := p.GetContracts(ctx)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", ErrGetAccountID, err)
+	}
+	if contracts.AccountID == "" {
+		return "", fmt.Errorf("%w: account ID missing from the response", ErrGetAccountID)
+	}
+
+	p.accountMu.Lock()
+	defer p.accountMu.Unlock()
+	if p.accountID == "" {
+		p.accountID = contracts.AccountID
+	}
+	return p.accountID, nil
+}
@@ -5,7 +5,10 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -84,3 +87,199 @@ func TestPapi_GetContracts(t *testing.T) {
 		})
 	}
 }
+
+func TestPapi_VerifyCredentials(t *testing.T) {
+	tests := map[string]struct {
+		responseStatus int
+		responseBody   string
+		closeServer    bool
+		withError      error
+	}{
+		"200 OK": {
+			responseStatus: http.StatusOK,
+			responseBody:   `{"accountId": "act_1-1TJZFB", "contracts": {"items": []}}`,
+		},
+		"401 unauthorized": {
+			responseStatus: http.StatusUnauthorized,
+			responseBody: `
+{
+	"type": "https://problems.luna.akamaiapis.net/-/pep-authn/deny",
+	"title": "Not authorized",
+	"status": 401,
+	"detail": "The signature does not match"
+}`,
+			withError: ErrInvalidCredentials,
+		},
+		"403 forbidden": {
+			responseStatus: http.StatusForbidden,
+			responseBody: `
+{
+	"type": "https://problems.luna.akamaiapis.net/-/pep-authz/deny",
+	"title": "Forbidden",
+	"status": 403,
+	"detail": "The client does not have the grant needed for the request"
+}`,
+			withError: ErrInvalidCredentials,
+		},
+		"500 internal server error": {
+			responseStatus: http.StatusInternalServerError,
+			responseBody: `
+{
+	"type": "internal_error",
+    "title": "Internal Server Error",
+    "detail": "Error fetching contracts",
+    "status": 500
+}`,
+			withError: &Error{
+				Type:       "internal_error",
+				Title:      "Internal Server Error",
+				Detail:     "Error fetching contracts",
+				StatusCode: http.StatusInternalServerError,
+			},
+		},
+		"network error": {
+			closeServer: true,
+			withError:   ErrVerifyCredentials,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/papi/v1/contracts", r.URL.String())
+				assert.Equal(t, http.MethodGet, r.Method)
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			if test.closeServer {
+				mockServer.Close()
+			}
+			err := client.VerifyCredentials(context.Background())
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestPapi_GetAccountID(t *testing.T) {
+	tests := map[string]struct {
+		responseStatus int
+		responseBody   string
+		expected       string
+		withError      func(*testing.T, error)
+	}{
+		"200 OK": {
+			responseStatus: http.StatusOK,
+			responseBody:   `{"accountId": "act_1-1TJZFB", "contracts": {"items": []}}`,
+			expected:       "act_1-1TJZFB",
+		},
+		"account ID missing": {
+			responseStatus: http.StatusOK,
+			responseBody:   `{"contracts": {"items": []}}`,
+			withError: func(t *testing.T, err error) {
+				assert.True(t, errors.Is(err, ErrGetAccountID), "want: %s; got: %s", ErrGetAccountID, err)
+			},
+		},
+		"500 internal server error": {
+			responseStatus: http.StatusInternalServerError,
+			responseBody: `
+{
+	"type": "internal_error",
+    "title": "Internal Server Error",
+    "detail": "Error fetching contracts",
+    "status": 500
+}`,
+			withError: func(t *testing.T, err error) {
+				want := &Error{
+					Type:       "internal_error",
+					Title:      "Internal Server Error",
+					Detail:     "Error fetching contracts",
+					StatusCode: http.StatusInternalServerError,
+				}
+				assert.True(t, errors.Is(err, want), "want: %s; got: %s", want, err)
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var calls int32
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&calls, 1)
+				assert.Equal(t, "/papi/v1/contracts", r.URL.String())
+				assert.Equal(t, http.MethodGet, r.Method)
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			defer mockServer.Close()
+			client := mockAPIClient(t, mockServer)
+			result, err := client.GetAccountID(context.Background())
+			if test.withError != nil {
+				test.withError(t, err)
+				// failed lookups are not cached
+				_, err = client.GetAccountID(context.Background())
+				assert.Error(t, err)
+				assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, result)
+
+			// the cached account ID is returned without another request
+			result, err = client.GetAccountID(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, result)
+			assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+		})
+	}
+}
+
+func TestPapi_GetAccountID_NotBlockedByPendingLookup(t *testing.T) {
+	entered := make(chan struct{})
+	release := make(chan struct{})
+	var once sync.Once
+	mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		once.Do(func() { close(entered) })
+		<-release
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"accountId": "act_1-1TJZFB", "contracts": {"items": []}}`))
+		assert.NoError(t, err)
+	}))
+	defer mockServer.Close()
+	client := mockAPIClient(t, mockServer)
+
+	first := make(chan error)
+	go func() {
+		_, err := client.GetAccountID(context.Background())
+		first <- err
+	}()
+	<-entered
+
+	// a caller whose context is canceled returns right away instead of waiting for the pending lookup
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	second := make(chan error)
+	go func() {
+		_, err := client.GetAccountID(ctx)
+		second <- err
+	}()
+	select {
+	case err := <-second:
+		assert.True(t, errors.Is(err, ErrGetContracts), "want: %s; got: %s", ErrGetContracts, err)
+	case <-time.After(5 * time.Second):
+		close(release)
+		t.Fatal("GetAccountID blocked by the pending lookup")
+	}
+
+	close(release)
+	require.NoError(t, <-first)
+	result, err := client.GetAccountID(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "act_1-1TJZFB", result)
+}
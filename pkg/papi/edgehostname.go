@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strings"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/edgegriderr"
@@ -188,6 +189,9 @@ var (
 
 // GetEdgeHostnames id used to list edge hostnames for provided group and contract IDs
 func (p *papi) GetEdgeHostnames(ctx context.Context, params GetEdgeHostnamesRequest) (*GetEdgeHostnamesResponse, error) {
+	if err := p.applyDefaultLocation(&params.ContractID, &params.GroupID); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrGetEdgeHostnames, ErrStructValidation, err)
+	}
 	if err := params.Validate(); err != nil {
 		return nil, fmt.Errorf("%s: %w: %s", ErrGetEdgeHostnames, ErrStructValidation, err)
 	}
@@ -223,6 +227,9 @@ func (p *papi) GetEdgeHostnames(ctx context.Context, params GetEdgeHostnamesRequ
 
 // GetEdgeHostname id used to fetch edge hostname with given ID for provided group and contract IDs
 func (p *papi) GetEdgeHostname(ctx context.Context, params GetEdgeHostnameRequest) (*GetEdgeHostnamesResponse, error) {
+	if err := p.applyDefaultLocation(&params.ContractID, &params.GroupID); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrGetEdgeHostname, ErrStructValidation, err)
+	}
 	if err := params.Validate(); err != nil {
 		return nil, fmt.Errorf("%s: %w: %s", ErrGetEdgeHostname, ErrStructValidation, err)
 	}
@@ -232,7 +239,7 @@ func (p *papi) GetEdgeHostname(ctx context.Context, params GetEdgeHostnameReques
 
 	getURL := fmt.Sprintf(
 		"/papi/v1/edgehostnames/%s?contractId=%s&groupId=%s",
-		params.EdgeHostnameID,
+		url.PathEscape(params.EdgeHostnameID),
 		params.ContractID,
 		params.GroupID,
 	)
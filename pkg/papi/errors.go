@@ -6,6 +6,24 @@ import (
 	"fmt"
 	"io/ioutil"
 	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/edgegriderr"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/session"
+)
+
+var (
+	// ErrInvalidErrorLocation is returned when error location is not a valid JSON pointer
+	ErrInvalidErrorLocation = errors.New("invalid error location")
+
+	// ErrPropertyNotFound matches an API error returned when a request refers to a property which does not exist,
+	// e.g. activating a property with a wrong PropertyID, use errors.Is to check for it
+	ErrPropertyNotFound = errors.New("property not found")
+
+	// ErrPreconditionFailed matches an API error returned when the resource has changed since the etag sent
+	// in the If-Match header was read, use errors.Is to check for it
+	ErrPreconditionFailed = errors.New("precondition failed")
 )
 
 type (
@@ -23,9 +41,48 @@ type (
 		LimitKey      string          `json:"limitKey"`
 		Limit         int             `json:"limit"`
 		Remaining     int             `json:"remaining"`
+		RetryAfter    int             `json:"retryAfter,omitempty"`
+
+		raw []byte
+		err error
 	}
 )
 
+const (
+	// problemTypePropertyNotFoundSuffix ends the problem type of an error returned when a property does not exist
+	problemTypePropertyNotFoundSuffix = "/property-not-found"
+
+	// LimitKeyDefaultCertsPerContract is the limit key of the number of secure by default certificates per contract
+	LimitKeyDefaultCertsPerContract = "DEFAULT_CERTS_PER_CONTRACT"
+)
+
+// DefaultCertLimits is the secure by default certificate limit of a contract
+type DefaultCertLimits struct {
+	Limit     int
+	Remaining int
+}
+
+// BulkError aggregates the errors of an operation performed on multiple items, such as activating several properties.
+// Items are identified by their index in the request and, if known, their ID
+type BulkError struct {
+	// Total is the number of items in the operation
+	Total int
+	// Failures are the errors of the failed items, in the order they were added
+	Failures []BulkItemError
+}
+
+// BulkItemError is the error of a single item of a bulk operation
+type BulkItemError struct {
+	Index int
+	ID    string
+	Err   error
+}
+
+// NewBulkError returns an empty BulkError for an operation on total items
+func NewBulkError(total int) *BulkError {
+	return &BulkError{Total: total}
+}
+
 // Error parses an error from the response
 func (p *papi) Error(r *http.Response) error {
 	var e Error
@@ -36,22 +93,62 @@ func (p *papi) Error(r *http.Response) error {
 	if err != nil {
 		p.Log(r.Request.Context()).Errorf("reading error response body: %s", err)
 		e.StatusCode = r.StatusCode
-		e.Title = fmt.Sprintf("Failed to read error body")
+		e.Title = "Failed to read error body"
 		e.Detail = err.Error()
+		e.err = err
 		return &e
 	}
 
-	if err := json.Unmarshal(body, &e); err != nil {
+	if len(body) > 0 && !edgegriderr.IsJSONContent(r.Header.Get("Content-Type"), body) {
+		// e.g. an HTML error page returned by a proxy or gateway in front of the API
+		p.Log(r.Request.Context()).Errorf("API error response is not JSON: %s", r.Header.Get("Content-Type"))
+		e.Title = "Non-JSON error response"
+		e.Detail = edgegriderr.BodySnippet(body)
+	} else if err := json.Unmarshal(body, &e); err != nil {
 		p.Log(r.Request.Context()).Errorf("could not unmarshal API error: %s", err)
-		e.Title = fmt.Sprintf("Failed to unmarshal error body")
-		e.Detail = err.Error()
+		e = Error{Title: "Failed to unmarshal error body", Detail: err.Error()}
+		if len(body) > 0 {
+			// the content type may be missing or wrong, so the body is reported as it is
+			e.Title = "Non-JSON error response"
+			e.Detail = edgegriderr.BodySnippet(body)
+		}
 	}
 
 	e.StatusCode = r.StatusCode
+	e.raw = body
+	e.applyHeaders(r.Header)
 
 	return &e
 }
 
+// applyHeaders sets RetryAfter to the seconds of the Retry-After header and fills in the rate limit details
+// from the response headers, as some 429 responses only report them there, with an empty or non-JSON body.
+// Limits present in the body take precedence
+func (e *Error) applyHeaders(h http.Header) {
+	if seconds, err := strconv.Atoi(h.Get("Retry-After")); err == nil && seconds >= 0 {
+		e.RetryAfter = seconds
+	}
+	if e.StatusCode != http.StatusTooManyRequests || e.Limit != 0 {
+		return
+	}
+	if rl, ok := session.ParseRateLimit(h); ok {
+		e.Limit = rl.Limit
+		e.Remaining = rl.Remaining
+	}
+}
+
+// Raw returns the body of the error response exactly as received, e.g. to read problem attributes which are not
+// mapped to Error fields. It is nil if the error was not parsed from a response or its body could not be read
+func (e *Error) Raw() []byte {
+	return e.raw
+}
+
+// Unwrap returns the error which occurred while reading the error response body, e.g. session.ErrResponseBodyTooLarge,
+// or nil if the body was read
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
 func (e *Error) Error() string {
 	msg, err := json.MarshalIndent(e, "", "\t")
 	if err != nil {
@@ -62,6 +159,13 @@ func (e *Error) Error() string {
 
 // Is handles error comparisons
 func (e *Error) Is(target error) bool {
+	if target == ErrPropertyNotFound {
+		return e.StatusCode == http.StatusNotFound && strings.HasSuffix(e.Type, problemTypePropertyNotFoundSuffix)
+	}
+	if target == ErrPreconditionFailed {
+		return e.StatusCode == http.StatusPreconditionFailed
+	}
+
 	var t *Error
 	if !errors.As(target, &t) {
 		return false
@@ -77,3 +181,112 @@ func (e *Error) Is(target error) bool {
 
 	return e.Error() == t.Error()
 }
+
+// Add records the error of the item with the given index and ID, nil errors are ignored
+func (e *BulkError) Add(index int, id string, err error) {
+	if err == nil {
+		return
+	}
+	e.Failures = append(e.Failures, BulkItemError{Index: index, ID: id, Err: err})
+}
+
+// AllSucceeded reports whether no item has failed
+func (e *BulkError) AllSucceeded() bool {
+	return len(e.Failures) == 0
+}
+
+// ErrorOrNil returns the BulkError if any item has failed and nil otherwise,
+// so that a bulk operation does not return a non-nil error interface holding no failures
+func (e *BulkError) ErrorOrNil() error {
+	if e.AllSucceeded() {
+		return nil
+	}
+	return e
+}
+
+// Each calls fn for every failed item, in the order they were added
+func (e *BulkError) Each(fn func(BulkItemError)) {
+	for _, failure := range e.Failures {
+		fn(failure)
+	}
+}
+
+func (e *BulkError) Error() string {
+	messages := make([]string, 0, len(e.Failures))
+	for _, failure := range e.Failures {
+		messages = append(messages, failure.Error())
+	}
+	return fmt.Sprintf("%d of %d items failed: %s", len(e.Failures), e.Total, strings.Join(messages, "; "))
+}
+
+// Is reports whether the error of any failed item matches the target
+func (e *BulkError) Is(target error) bool {
+	for _, failure := range e.Failures {
+		if errors.Is(failure.Err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+func (e BulkItemError) Error() string {
+	if e.ID == "" {
+		return fmt.Sprintf("item %d: %s", e.Index, e.Err)
+	}
+	return fmt.Sprintf("item %d (%s): %s", e.Index, e.ID, e.Err)
+}
+
+// Unwrap returns the error of the item
+func (e BulkItemError) Unwrap() error {
+	return e.Err
+}
+
+// GetDefaultCertLimits extracts the secure by default certificate limit from an API error returned by any papi call
+// The API reports the limit only when rejecting a request, it returns false if the error does not carry it
+func GetDefaultCertLimits(err error) (*DefaultCertLimits, bool) {
+	var e *Error
+	if !errors.As(err, &e) || e.LimitKey != LimitKeyDefaultCertsPerContract {
+		return nil, false
+	}
+	return &DefaultCertLimits{Limit: e.Limit, Remaining: e.Remaining}, true
+}
+
+// IsDefaultCertLimitReached reports whether the error was caused by reaching the secure by default certificate limit of the contract
+func IsDefaultCertLimitReached(err error) bool {
+	limits, ok := GetDefaultCertLimits(err)
+	return ok && limits.Remaining == 0
+}
+
+// InstanceReference returns the request reference embedded in Instance, which is its fragment,
+// e.g. "4b6b0f6f" for ".../properties/prp_175780/activations#4b6b0f6f", to be quoted in support tickets.
+// It returns an empty string if Instance has no fragment
+func (e *Error) InstanceReference() string {
+	i := strings.LastIndex(e.Instance, "#")
+	if i < 0 {
+		return ""
+	}
+	return e.Instance[i+1:]
+}
+
+// ErrorLocationPath returns ErrorLocation parsed into a list of path segments, see ParseErrorLocation
+func (e *Error) ErrorLocationPath() ([]string, error) {
+	return ParseErrorLocation(e.ErrorLocation)
+}
+
+// ParseErrorLocation parses an error location, which is a JSON pointer into the rule tree
+// (e.g. "#/rules/children/0/behaviors/1"), into a list of unescaped path segments
+func ParseErrorLocation(location string) ([]string, error) {
+	pointer := strings.TrimPrefix(location, "#")
+	if pointer == "" {
+		return []string{}, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidErrorLocation, location)
+	}
+
+	segments := strings.Split(pointer[1:], "/")
+	for i, segment := range segments {
+		segments[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(segment)
+	}
+	return segments, nil
+}
@@ -2,16 +2,31 @@ package papi
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
 	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
 	"strings"
 	"testing"
 
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/edgegrid"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/edgegriderr"
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/session"
 	"github.com/stretchr/testify/require"
 	"github.com/tj/assert"
 )
 
+const htmlErrorPage = `<html>
+<head><title>502 Bad Gateway</title></head>
+<body>
+<h1>Bad Gateway</h1>
+<p>The proxy server received an invalid response from an upstream server.</p>
+</body>
+</html>`
+
 func TestNewError(t *testing.T) {
 	sess, err := session.New()
 	require.NoError(t, err)
@@ -41,6 +56,7 @@ func TestNewError(t *testing.T) {
 				Title:      "b",
 				Detail:     "c",
 				StatusCode: http.StatusInternalServerError,
+				raw:        []byte(`{"type":"a","title":"b","detail":"c"}`),
 			},
 		},
 		"invalid response body, assign status code": {
@@ -53,9 +69,68 @@ func TestNewError(t *testing.T) {
 				Request: req,
 			},
 			expected: &Error{
-				Title:      "Failed to unmarshal error body",
-				Detail:     "invalid character 'e' in literal true (expecting 'r')",
+				Title:      "Non-JSON error response",
+				Detail:     "test",
 				StatusCode: http.StatusInternalServerError,
+				raw:        []byte(`test`),
+			},
+		},
+		"HTML error page with JSON content type": {
+			response: &http.Response{
+				Status:     "Bad Gateway",
+				StatusCode: http.StatusBadGateway,
+				Header:     http.Header{"Content-Type": []string{"application/json"}},
+				Body:       ioutil.NopCloser(strings.NewReader(htmlErrorPage)),
+				Request:    req,
+			},
+			expected: &Error{
+				Title:      "Non-JSON error response",
+				Detail:     htmlErrorPage,
+				StatusCode: http.StatusBadGateway,
+				raw:        []byte(htmlErrorPage),
+			},
+		},
+		"empty body": {
+			response: &http.Response{
+				Status:     "Not Found",
+				StatusCode: http.StatusNotFound,
+				Body:       ioutil.NopCloser(strings.NewReader("")),
+				Request:    req,
+			},
+			expected: &Error{
+				Title:      "Failed to unmarshal error body",
+				Detail:     "unexpected end of JSON input",
+				StatusCode: http.StatusNotFound,
+				raw:        []byte(""),
+			},
+		},
+		"HTML error page, status code 502": {
+			response: &http.Response{
+				Status:     "Bad Gateway",
+				StatusCode: http.StatusBadGateway,
+				Header:     http.Header{"Content-Type": []string{"text/html; charset=iso-8859-1"}},
+				Body:       ioutil.NopCloser(strings.NewReader(htmlErrorPage + "caf\xe9")),
+				Request:    req,
+			},
+			expected: &Error{
+				Title:      "Non-JSON error response",
+				Detail:     htmlErrorPage + "caf\uFFFD",
+				StatusCode: http.StatusBadGateway,
+				raw:        []byte(htmlErrorPage + "caf\xe9"),
+			},
+		},
+		"HTML error page without content type, truncated": {
+			response: &http.Response{
+				Status:     "Bad Gateway",
+				StatusCode: http.StatusBadGateway,
+				Body:       ioutil.NopCloser(strings.NewReader(htmlErrorPage + strings.Repeat("<p>padding</p>", 100))),
+				Request:    req,
+			},
+			expected: &Error{
+				Title:      "Non-JSON error response",
+				Detail:     (htmlErrorPage + strings.Repeat("<p>padding</p>", 100))[:edgegriderr.MaxBodySnippetLength],
+				StatusCode: http.StatusBadGateway,
+				raw:        []byte(htmlErrorPage + strings.Repeat("<p>padding</p>", 100)),
 			},
 		},
 	}
@@ -66,3 +141,380 @@ func TestNewError(t *testing.T) {
 		})
 	}
 }
+
+func TestError_Raw(t *testing.T) {
+	sess, err := session.New()
+	require.NoError(t, err)
+	req, err := http.NewRequestWithContext(context.TODO(), http.MethodGet, "/", nil)
+	require.NoError(t, err)
+
+	body := `{
+	"type": "https://problems.luna.akamaiapis.net/papi/v0/http/bad-request",
+	"title": "Bad Request",
+	"detail": "The request is invalid",
+	"status": 400,
+	"requestId": "e3a2b1c4"
+}`
+	apiErr := Client(sess).(*papi).Error(&http.Response{
+		StatusCode: http.StatusBadRequest,
+		Header:     http.Header{"Content-Type": []string{"application/problem+json"}},
+		Body:       ioutil.NopCloser(strings.NewReader(body)),
+		Request:    req,
+	})
+
+	var e *Error
+	require.True(t, errors.As(apiErr, &e))
+	assert.Equal(t, []byte(body), e.Raw())
+	assert.Nil(t, (&Error{Title: "not parsed"}).Raw())
+}
+
+func TestError_ResponseBodyTooLarge(t *testing.T) {
+	mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, err := w.Write([]byte(`{"type": "bad-request", "title": "Bad Request", "detail": "` + strings.Repeat("a", 1024) + `"}`))
+		assert.NoError(t, err)
+	}))
+	defer mockServer.Close()
+	certPool := x509.NewCertPool()
+	certPool.AddCert(mockServer.Certificate())
+	httpClient := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				RootCAs: certPool,
+			},
+		},
+	}
+	sess, err := session.New(session.WithClient(httpClient), session.WithSigner(&edgegrid.Config{}),
+		session.WithBaseURL(mockServer.URL), session.WithMaxResponseBodySize(256))
+	require.NoError(t, err)
+
+	_, err = Client(sess).GetProperty(context.Background(), GetPropertyRequest{
+		PropertyID: "prp_175780",
+		ContractID: "ctr_1-1TJZFW",
+		GroupID:    "grp_15166",
+	})
+	assert.True(t, errors.Is(err, session.ErrResponseBodyTooLarge), "want: %s; got: %s", session.ErrResponseBodyTooLarge, err)
+	var e *Error
+	require.True(t, errors.As(err, &e))
+	assert.Equal(t, "Failed to read error body", e.Title)
+	assert.Equal(t, http.StatusBadRequest, e.StatusCode)
+}
+
+func TestParseErrorLocation(t *testing.T) {
+	tests := map[string]struct {
+		given     string
+		expected  []string
+		withError error
+	}{
+		"behavior in child rule": {
+			given:    "#/rules/children/0/behaviors/1",
+			expected: []string{"rules", "children", "0", "behaviors", "1"},
+		},
+		"escaped segments": {
+			given:    "#/rules/behaviors/0/options/a~1b~0c",
+			expected: []string{"rules", "behaviors", "0", "options", "a/b~c"},
+		},
+		"pointer without fragment": {
+			given:    "/rules/criteria/2",
+			expected: []string{"rules", "criteria", "2"},
+		},
+		"empty location": {
+			given:    "",
+			expected: []string{},
+		},
+		"invalid pointer": {
+			given:     "#rules/behaviors/0",
+			withError: ErrInvalidErrorLocation,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			e := Error{ErrorLocation: test.given}
+			res, err := e.ErrorLocationPath()
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, res)
+		})
+	}
+}
+
+func TestError_InstanceReference(t *testing.T) {
+	tests := map[string]struct {
+		instance string
+		expected string
+	}{
+		"absolute instance URL": {
+			instance: "https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net/papi/v1/properties/prp_175780/activations#4b6b0f6f",
+			expected: "4b6b0f6f",
+		},
+		"relative instance URL": {
+			instance: "/papi/v1/properties/prp_173136/versions/3/rules#err_100",
+			expected: "err_100",
+		},
+		"instance without fragment": {
+			instance: "/papi/v1/properties/prp_173136/versions/3/rules",
+			expected: "",
+		},
+		"empty instance": {
+			instance: "",
+			expected: "",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			e := Error{Instance: test.instance}
+			assert.Equal(t, test.expected, e.InstanceReference())
+		})
+	}
+}
+
+func TestGetDefaultCertLimits(t *testing.T) {
+	sess, err := session.New()
+	require.NoError(t, err)
+	req, err := http.NewRequestWithContext(context.TODO(), http.MethodPatch, "/", nil)
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		body            string
+		expectedLimits  *DefaultCertLimits
+		expectedReached bool
+	}{
+		"limit reached": {
+			body: `
+{
+	"type": "https://problems.luna.akamaiapis.net/papi/v0/property-version-hostname/default-cert-provisioning-unavailable",
+	"title": "Unable to provision default certificate",
+	"detail": "The limit of default certificates for this contract has been reached",
+	"status": 429,
+	"limitKey": "DEFAULT_CERTS_PER_CONTRACT",
+	"limit": 25,
+	"remaining": 0
+}`,
+			expectedLimits:  &DefaultCertLimits{Limit: 25, Remaining: 0},
+			expectedReached: true,
+		},
+		"limit not reached": {
+			body: `
+{
+	"type": "https://problems.luna.akamaiapis.net/papi/v0/property-version-hostname/default-cert-provisioning-unavailable",
+	"title": "Unable to provision default certificate",
+	"status": 400,
+	"limitKey": "DEFAULT_CERTS_PER_CONTRACT",
+	"limit": 25,
+	"remaining": 3
+}`,
+			expectedLimits: &DefaultCertLimits{Limit: 25, Remaining: 3},
+		},
+		"other limit": {
+			body: `
+{
+	"type": "https://problems.luna.akamaiapis.net/papi/v0/rate-limit",
+	"title": "Too many requests",
+	"status": 429,
+	"limitKey": "ACTIVATIONS_PER_HOUR",
+	"limit": 100,
+	"remaining": 0
+}`,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			apiErr := Client(sess).(*papi).Error(&http.Response{
+				StatusCode: http.StatusTooManyRequests,
+				Body:       ioutil.NopCloser(strings.NewReader(test.body)),
+				Request:    req,
+			})
+			err := fmt.Errorf("%s: %w", ErrUpdatePropertyVersionHostnames, apiErr)
+
+			limits, ok := GetDefaultCertLimits(err)
+			assert.Equal(t, test.expectedLimits != nil, ok)
+			assert.Equal(t, test.expectedLimits, limits)
+			assert.Equal(t, test.expectedReached, IsDefaultCertLimitReached(err))
+		})
+	}
+
+	_, ok := GetDefaultCertLimits(errors.New("not an API error"))
+	assert.False(t, ok)
+}
+
+func TestError_RateLimitHeaders(t *testing.T) {
+	sess, err := session.New()
+	require.NoError(t, err)
+	req, err := http.NewRequestWithContext(context.TODO(), http.MethodGet, "/", nil)
+	require.NoError(t, err)
+
+	rateLimitHeaders := http.Header{
+		"X-Ratelimit-Limit":     []string{"100"},
+		"X-Ratelimit-Remaining": []string{"0"},
+		"Retry-After":           []string{"30"},
+	}
+	tests := map[string]struct {
+		statusCode         int
+		header             http.Header
+		body               string
+		expectedLimitKey   string
+		expectedLimit      int
+		expectedRemaining  int
+		expectedRetryAfter int
+	}{
+		"empty body with headers only": {
+			statusCode:         http.StatusTooManyRequests,
+			header:             rateLimitHeaders,
+			expectedLimit:      100,
+			expectedRetryAfter: 30,
+		},
+		"non-JSON body with headers only": {
+			statusCode:        http.StatusTooManyRequests,
+			header:            http.Header{"Content-Type": []string{"text/html"}, "X-Ratelimit-Limit": []string{"100"}, "X-Ratelimit-Remaining": []string{"2"}},
+			body:              "<html><body>Too many requests</body></html>",
+			expectedLimit:     100,
+			expectedRemaining: 2,
+		},
+		"limits in body take precedence": {
+			statusCode: http.StatusTooManyRequests,
+			header:     rateLimitHeaders,
+			body: `
+{
+	"type": "https://problems.luna.akamaiapis.net/papi/v0/rate-limit",
+	"title": "Too many requests",
+	"status": 429,
+	"limitKey": "ACTIVATIONS_PER_HOUR",
+	"limit": 20,
+	"remaining": 0
+}`,
+			expectedLimitKey:   "ACTIVATIONS_PER_HOUR",
+			expectedLimit:      20,
+			expectedRetryAfter: 30,
+		},
+		"limits not read from headers of other errors": {
+			statusCode:         http.StatusServiceUnavailable,
+			header:             rateLimitHeaders,
+			expectedRetryAfter: 30,
+		},
+		"no headers": {
+			statusCode: http.StatusTooManyRequests,
+			header:     http.Header{},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			apiErr := Client(sess).(*papi).Error(&http.Response{
+				StatusCode: test.statusCode,
+				Header:     test.header,
+				Body:       ioutil.NopCloser(strings.NewReader(test.body)),
+				Request:    req,
+			})
+
+			var e *Error
+			require.True(t, errors.As(apiErr, &e))
+			assert.Equal(t, test.statusCode, e.StatusCode)
+			assert.Equal(t, test.expectedLimitKey, e.LimitKey)
+			assert.Equal(t, test.expectedLimit, e.Limit)
+			assert.Equal(t, test.expectedRemaining, e.Remaining)
+			assert.Equal(t, test.expectedRetryAfter, e.RetryAfter)
+		})
+	}
+}
+
+func TestError_IsPropertyNotFound(t *testing.T) {
+	sess, err := session.New()
+	require.NoError(t, err)
+	req, err := http.NewRequestWithContext(context.TODO(), http.MethodPost, "/", nil)
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		statusCode int
+		body       string
+		expected   bool
+	}{
+		"property not found": {
+			statusCode: http.StatusNotFound,
+			body: `
+{
+	"type": "https://problems.luna.akamaiapis.net/papi/v0/property-not-found",
+	"title": "Property not found",
+	"detail": "The property prp_175780 does not exist or you do not have access to it",
+	"instance": "https://akaa-baseurl-xxxxxxxxxxx-xxxxxxxxxxxxx.luna.akamaiapis.net/papi/v1/properties/prp_175780/activations#4b6b0f6f",
+	"status": 404
+}`,
+			expected: true,
+		},
+		"other resource not found": {
+			statusCode: http.StatusNotFound,
+			body: `
+{
+	"type": "https://problems.luna.akamaiapis.net/papi/v0/property-version-not-found",
+	"title": "Property version not found",
+	"status": 404
+}`,
+		},
+		"property not found type with other status": {
+			statusCode: http.StatusForbidden,
+			body: `
+{
+	"type": "https://problems.luna.akamaiapis.net/papi/v0/property-not-found",
+	"title": "Property not found",
+	"status": 403
+}`,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			apiErr := Client(sess).(*papi).Error(&http.Response{
+				StatusCode: test.statusCode,
+				Body:       ioutil.NopCloser(strings.NewReader(test.body)),
+				Request:    req,
+			})
+			err := fmt.Errorf("%s: %w", ErrCreateActivation, apiErr)
+			assert.Equal(t, test.expected, errors.Is(err, ErrPropertyNotFound))
+		})
+	}
+}
+
+func TestBulkError(t *testing.T) {
+	notFound := &Error{
+		Type:       "https://problems.luna.akamaiapis.net/papi/v0/property-not-found",
+		Title:      "Property not found",
+		StatusCode: http.StatusNotFound,
+	}
+	bulkErr := NewBulkError(4)
+	bulkErr.Add(0, "prp_175780", nil)
+	bulkErr.Add(1, "prp_175781", fmt.Errorf("%s: %w", ErrCreateActivation, notFound))
+	bulkErr.Add(2, "prp_175782", nil)
+	bulkErr.Add(3, "", ErrStructValidation)
+
+	assert.False(t, bulkErr.AllSucceeded())
+	assert.Equal(t, bulkErr, bulkErr.ErrorOrNil())
+
+	var indexes []int
+	var ids []string
+	bulkErr.Each(func(failure BulkItemError) {
+		indexes = append(indexes, failure.Index)
+		ids = append(ids, failure.ID)
+	})
+	assert.Equal(t, []int{1, 3}, indexes)
+	assert.Equal(t, []string{"prp_175781", ""}, ids)
+
+	var err error = bulkErr
+	assert.True(t, errors.Is(err, ErrPropertyNotFound))
+	assert.True(t, errors.Is(err, ErrStructValidation))
+	assert.False(t, errors.Is(err, ErrGetActivations))
+	assert.True(t, strings.HasPrefix(err.Error(), "2 of 4 items failed: item 1 (prp_175781): creating activation: API error"), err.Error())
+	assert.True(t, strings.HasSuffix(err.Error(), "; item 3: struct validation"), err.Error())
+
+	var target *BulkError
+	require.True(t, errors.As(fmt.Errorf("activating properties: %w", err), &target))
+	assert.Len(t, target.Failures, 2)
+}
+
+func TestBulkError_AllSucceeded(t *testing.T) {
+	bulkErr := NewBulkError(2)
+	bulkErr.Add(0, "prp_175780", nil)
+	bulkErr.Add(1, "prp_175781", nil)
+
+	assert.True(t, bulkErr.AllSucceeded())
+	assert.Nil(t, bulkErr.ErrorOrNil())
+}
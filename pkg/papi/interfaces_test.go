@@ -0,0 +1,44 @@
+package papi_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/edgegrid"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/papi"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/session"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInterfaces(t *testing.T) {
+	sess, err := session.New(session.WithSigner(&edgegrid.Config{}))
+	require.NoError(t, err)
+	implementations := map[string]interface{}{
+		"client": papi.Client(sess),
+		"mock":   &papi.Mock{},
+	}
+	interfaces := []reflect.Type{
+		reflect.TypeOf((*papi.Groups)(nil)).Elem(),
+		reflect.TypeOf((*papi.Contracts)(nil)).Elem(),
+		reflect.TypeOf((*papi.Activations)(nil)).Elem(),
+		reflect.TypeOf((*papi.CPCodes)(nil)).Elem(),
+		reflect.TypeOf((*papi.Properties)(nil)).Elem(),
+		reflect.TypeOf((*papi.PropertyVersions)(nil)).Elem(),
+		reflect.TypeOf((*papi.EdgeHostnames)(nil)).Elem(),
+		reflect.TypeOf((*papi.Products)(nil)).Elem(),
+		reflect.TypeOf((*papi.Search)(nil)).Elem(),
+		reflect.TypeOf((*papi.PropertyVersionHostnames)(nil)).Elem(),
+		reflect.TypeOf((*papi.ClientSettings)(nil)).Elem(),
+		reflect.TypeOf((*papi.PropertyRules)(nil)).Elem(),
+		reflect.TypeOf((*papi.RuleFormats)(nil)).Elem(),
+	}
+
+	for name, implementation := range implementations {
+		t.Run(name, func(t *testing.T) {
+			for _, i := range interfaces {
+				assert.True(t, reflect.TypeOf(implementation).Implements(i), "%T does not implement %s", implementation, i)
+			}
+		})
+	}
+}
@@ -39,8 +39,25 @@ type (
 
 	// UpdateRuleTreeFn is any function having the same signature as UpdateRuleTree
 	UpdateRuleTreeFn func(context.Context, UpdateRulesRequest) (*UpdateRulesResponse, error)
+
+	// CreateActivationFn is any function having the same signature as CreateActivation
+	CreateActivationFn func(context.Context, CreateActivationRequest) (*CreateActivationResponse, error)
+
+	// GetActivationsFn is any function having the same signature as GetActivations
+	GetActivationsFn func(context.Context, GetActivationsRequest) (*GetActivationsResponse, error)
+
+	// GetActivationFn is any function having the same signature as GetActivation
+	GetActivationFn func(context.Context, GetActivationRequest) (*GetActivationResponse, error)
+
+	// GetPropertyVersionFn is any function having the same signature as GetPropertyVersion
+	GetPropertyVersionFn func(context.Context, GetPropertyVersionRequest) (*GetPropertyVersionsResponse, error)
+
+	// SearchPropertiesFn is any function having the same signature as SearchProperties
+	SearchPropertiesFn func(context.Context, SearchRequest) (*SearchResponse, error)
 )
 
+var _ PAPI = &Mock{}
+
 func (p *Mock) GetGroups(ctx context.Context) (*GetGroupsResponse, error) {
 	args := p.Called(ctx)
 
@@ -61,6 +78,18 @@ func (p *Mock) GetContracts(ctx context.Context) (*GetContractsResponse, error)
 	return args.Get(0).(*GetContractsResponse), args.Error(1)
 }
 
+func (p *Mock) VerifyCredentials(ctx context.Context) error {
+	args := p.Called(ctx)
+
+	return args.Error(0)
+}
+
+func (p *Mock) GetAccountID(ctx context.Context) (string, error) {
+	args := p.Called(ctx)
+
+	return args.String(0), args.Error(1)
+}
+
 func (p *Mock) CreateActivation(ctx context.Context, r CreateActivationRequest) (*CreateActivationResponse, error) {
 	args := p.Called(ctx, r)
 
@@ -71,6 +100,16 @@ func (p *Mock) CreateActivation(ctx context.Context, r CreateActivationRequest)
 	return args.Get(0).(*CreateActivationResponse), args.Error(1)
 }
 
+func (p *Mock) CreateActivationAcknowledgingWarnings(ctx context.Context, r CreateActivationAcknowledgingWarningsRequest) (*CreateActivationResponse, error) {
+	args := p.Called(ctx, r)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*CreateActivationResponse), args.Error(1)
+}
+
 func (p *Mock) GetActivations(ctx context.Context, r GetActivationsRequest) (*GetActivationsResponse, error) {
 	args := p.Called(ctx, r)
 
@@ -81,6 +120,36 @@ func (p *Mock) GetActivations(ctx context.Context, r GetActivationsRequest) (*Ge
 	return args.Get(0).(*GetActivationsResponse), args.Error(1)
 }
 
+func (p *Mock) ListGroupActivations(ctx context.Context, r ListGroupActivationsRequest) (*ListGroupActivationsResponse, error) {
+	args := p.Called(ctx, r)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*ListGroupActivationsResponse), args.Error(1)
+}
+
+func (p *Mock) RollbackActivation(ctx context.Context, r RollbackActivationRequest) (*RollbackActivationResponse, error) {
+	args := p.Called(ctx, r)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*RollbackActivationResponse), args.Error(1)
+}
+
+func (p *Mock) GetActivationsByNetwork(ctx context.Context, r GetActivationsRequest) (*GetActivationsByNetworkResponse, error) {
+	args := p.Called(ctx, r)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*GetActivationsByNetworkResponse), args.Error(1)
+}
+
 func (p *Mock) GetActivation(ctx context.Context, r GetActivationRequest) (*GetActivationResponse, error) {
 	args := p.Called(ctx, r)
 
@@ -91,6 +160,16 @@ func (p *Mock) GetActivation(ctx context.Context, r GetActivationRequest) (*GetA
 	return args.Get(0).(*GetActivationResponse), args.Error(1)
 }
 
+func (p *Mock) GetActivationErrors(ctx context.Context, r GetActivationErrorsRequest) (*GetActivationErrorsResponse, error) {
+	args := p.Called(ctx, r)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*GetActivationErrorsResponse), args.Error(1)
+}
+
 func (p *Mock) CancelActivation(ctx context.Context, r CancelActivationRequest) (*CancelActivationResponse, error) {
 	args := p.Called(ctx, r)
 
@@ -101,6 +180,26 @@ func (p *Mock) CancelActivation(ctx context.Context, r CancelActivationRequest)
 	return args.Get(0).(*CancelActivationResponse), args.Error(1)
 }
 
+func (p *Mock) WaitForActivation(ctx context.Context, r WaitForActivationRequest) (*GetActivationResponse, error) {
+	args := p.Called(ctx, r)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*GetActivationResponse), args.Error(1)
+}
+
+func (p *Mock) WatchActivation(ctx context.Context, r WaitForActivationRequest) (<-chan ActivationUpdate, error) {
+	args := p.Called(ctx, r)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(<-chan ActivationUpdate), args.Error(1)
+}
+
 func (p *Mock) GetCPCodes(ctx context.Context, r GetCPCodesRequest) (*GetCPCodesResponse, error) {
 	args := p.Called(ctx, r)
 
@@ -177,6 +276,16 @@ func (p *Mock) GetProperty(ctx context.Context, r GetPropertyRequest) (*GetPrope
 	return args.Get(0).(*GetPropertyResponse), args.Error(1)
 }
 
+func (p *Mock) ResolvePropertyLocation(ctx context.Context, r ResolvePropertyLocationRequest) (*PropertyLocation, error) {
+	args := p.Called(ctx, r)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*PropertyLocation), args.Error(1)
+}
+
 func (p *Mock) RemoveProperty(ctx context.Context, r RemovePropertyRequest) (*RemovePropertyResponse, error) {
 	args := p.Called(ctx, r)
 
@@ -217,6 +326,56 @@ func (p *Mock) CreatePropertyVersion(ctx context.Context, r CreatePropertyVersio
 	return args.Get(0).(*CreatePropertyVersionResponse), args.Error(1)
 }
 
+func (p *Mock) CreatePropertyVersionAndGet(ctx context.Context, r CreatePropertyVersionRequest) (*GetPropertyVersionsResponse, error) {
+	args := p.Called(ctx, r)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*GetPropertyVersionsResponse), args.Error(1)
+}
+
+func (p *Mock) GetPropertyVersionRange(ctx context.Context, r GetPropertyVersionRangeRequest) (*GetPropertyVersionRangeResponse, error) {
+	args := p.Called(ctx, r)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*GetPropertyVersionRangeResponse), args.Error(1)
+}
+
+func (p *Mock) GetActiveVersionOnNetwork(ctx context.Context, r GetActiveVersionOnNetworkRequest) (*PropertyVersionGetItem, error) {
+	args := p.Called(ctx, r)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*PropertyVersionGetItem), args.Error(1)
+}
+
+func (p *Mock) WaitForPropertyVersionStatus(ctx context.Context, r WaitForPropertyVersionStatusRequest) (*GetPropertyVersionsResponse, error) {
+	args := p.Called(ctx, r)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*GetPropertyVersionsResponse), args.Error(1)
+}
+
+func (p *Mock) CanEditPropertyVersion(ctx context.Context, r GetPropertyVersionRequest) (*CanEditPropertyVersionResponse, error) {
+	args := p.Called(ctx, r)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*CanEditPropertyVersionResponse), args.Error(1)
+}
+
 func (p *Mock) GetLatestVersion(ctx context.Context, r GetLatestVersionRequest) (*GetPropertyVersionsResponse, error) {
 	args := p.Called(ctx, r)
 
@@ -297,6 +456,16 @@ func (p *Mock) SearchProperties(ctx context.Context, r SearchRequest) (*SearchRe
 	return args.Get(0).(*SearchResponse), args.Error(1)
 }
 
+func (p *Mock) SearchPropertiesMulti(ctx context.Context, r []SearchRequest) (*SearchResponse, error) {
+	args := p.Called(ctx, r)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*SearchResponse), args.Error(1)
+}
+
 func (p *Mock) GetPropertyVersionHostnames(ctx context.Context, r GetPropertyVersionHostnamesRequest) (*GetPropertyVersionHostnamesResponse, error) {
 	args := p.Called(ctx, r)
 
@@ -343,6 +512,52 @@ func (p *Mock) GetRuleTree(ctx context.Context, r GetRuleTreeRequest) (*GetRuleT
 	return args.Get(0).(*GetRuleTreeResponse), args.Error(1)
 }
 
+func (p *Mock) UpdatePropertyVersionNote(ctx context.Context, r UpdatePropertyVersionNoteRequest) (*UpdateRulesResponse, error) {
+	args := p.Called(ctx, r)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*UpdateRulesResponse), args.Error(1)
+}
+
+func (p *Mock) ExportPropertyVersion(ctx context.Context, r ExportPropertyVersionRequest) (*PropertyVersionBundle, error) {
+	args := p.Called(ctx, r)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*PropertyVersionBundle), args.Error(1)
+}
+
+func (p *Mock) ImportPropertyVersion(ctx context.Context, r ImportPropertyVersionRequest) (*UpdateRulesResponse, error) {
+	args := p.Called(ctx, r)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*UpdateRulesResponse), args.Error(1)
+}
+
+func (p *Mock) FindPropertiesUsingBehavior(ctx context.Context, r FindPropertiesUsingBehaviorRequest) (*FindPropertiesUsingBehaviorResponse, error) {
+	args := p.Called(ctx, r)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*FindPropertiesUsingBehaviorResponse), args.Error(1)
+}
+
+func (p *Mock) FingerprintRuleTree(ctx context.Context, r GetRuleTreeRequest) (string, error) {
+	args := p.Called(ctx, r)
+
+	return args.String(0), args.Error(1)
+}
+
 func (p *Mock) UpdateRuleTree(ctx context.Context, r UpdateRulesRequest) (*UpdateRulesResponse, error) {
 	args := p.Called(ctx, r)
 
@@ -353,6 +568,12 @@ func (p *Mock) UpdateRuleTree(ctx context.Context, r UpdateRulesRequest) (*Updat
 	return args.Get(0).(*UpdateRulesResponse), args.Error(1)
 }
 
+func (p *Mock) GetRuleTreeETag(ctx context.Context, r GetRuleTreeETagRequest) (string, error) {
+	args := p.Called(ctx, r)
+
+	return args.String(0), args.Error(1)
+}
+
 func (p *Mock) GetRuleFormats(ctx context.Context) (*GetRuleFormatsResponse, error) {
 	args := p.Called(ctx)
 
@@ -487,3 +708,63 @@ func (p *Mock) OnUpdateRuleTree(ctx, req interface{}, impl UpdateRuleTreeFn) *mo
 
 	return call
 }
+
+func (p *Mock) OnCreateActivation(ctx, req interface{}, impl CreateActivationFn) *mock.Call {
+	call := p.On("CreateActivation", ctx, req)
+	call.Run(func(CallArgs mock.Arguments) {
+		callCtx := CallArgs.Get(0).(context.Context)
+		callReq := CallArgs.Get(1).(CreateActivationRequest)
+
+		call.Return(impl(callCtx, callReq))
+	})
+
+	return call
+}
+
+func (p *Mock) OnGetActivations(ctx, req interface{}, impl GetActivationsFn) *mock.Call {
+	call := p.On("GetActivations", ctx, req)
+	call.Run(func(CallArgs mock.Arguments) {
+		callCtx := CallArgs.Get(0).(context.Context)
+		callReq := CallArgs.Get(1).(GetActivationsRequest)
+
+		call.Return(impl(callCtx, callReq))
+	})
+
+	return call
+}
+
+func (p *Mock) OnGetActivation(ctx, req interface{}, impl GetActivationFn) *mock.Call {
+	call := p.On("GetActivation", ctx, req)
+	call.Run(func(CallArgs mock.Arguments) {
+		callCtx := CallArgs.Get(0).(context.Context)
+		callReq := CallArgs.Get(1).(GetActivationRequest)
+
+		call.Return(impl(callCtx, callReq))
+	})
+
+	return call
+}
+
+func (p *Mock) OnGetPropertyVersion(ctx, req interface{}, impl GetPropertyVersionFn) *mock.Call {
+	call := p.On("GetPropertyVersion", ctx, req)
+	call.Run(func(CallArgs mock.Arguments) {
+		callCtx := CallArgs.Get(0).(context.Context)
+		callReq := CallArgs.Get(1).(GetPropertyVersionRequest)
+
+		call.Return(impl(callCtx, callReq))
+	})
+
+	return call
+}
+
+func (p *Mock) OnSearchProperties(ctx, req interface{}, impl SearchPropertiesFn) *mock.Call {
+	call := p.On("SearchProperties", ctx, req)
+	call.Run(func(CallArgs mock.Arguments) {
+		callCtx := CallArgs.Get(0).(context.Context)
+		callReq := CallArgs.Get(1).(SearchRequest)
+
+		call.Return(impl(callCtx, callReq))
+	})
+
+	return call
+}
@@ -0,0 +1,52 @@
+package papi
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMock(t *testing.T) {
+	ctx := context.Background()
+	m := &Mock{}
+	var client PAPI = m
+
+	m.On("GetActivations", ctx, GetActivationsRequest{PropertyID: "prp_1"}).
+		Return(&GetActivationsResponse{Activations: ActivationsItems{Items: []*Activation{{ActivationID: "atv_1"}}}}, nil).Once()
+	m.On("GetActivations", ctx, GetActivationsRequest{PropertyID: "prp_2"}).
+		Return(nil, ErrGetActivations).Once()
+	m.OnCreateActivation(ctx, mock.Anything, func(_ context.Context, r CreateActivationRequest) (*CreateActivationResponse, error) {
+		return &CreateActivationResponse{ActivationID: "atv_" + r.PropertyID}, nil
+	}).Once()
+	m.OnGetPropertyVersion(mock.Anything, mock.Anything, func(_ context.Context, r GetPropertyVersionRequest) (*GetPropertyVersionsResponse, error) {
+		return &GetPropertyVersionsResponse{Version: PropertyVersionGetItem{PropertyVersion: r.PropertyVersion}}, nil
+	}).Once()
+	m.OnSearchProperties(ctx, SearchRequest{Key: SearchKeyPropertyName, Value: "example.com"}, func(context.Context, SearchRequest) (*SearchResponse, error) {
+		return &SearchResponse{Versions: SearchItems{Items: []SearchItem{{PropertyID: "prp_1"}}}}, nil
+	}).Once()
+
+	activations, err := client.GetActivations(ctx, GetActivationsRequest{PropertyID: "prp_1"})
+	require.NoError(t, err)
+	assert.Equal(t, "atv_1", activations.Activations.Items[0].ActivationID)
+
+	_, err = client.GetActivations(ctx, GetActivationsRequest{PropertyID: "prp_2"})
+	assert.True(t, errors.Is(err, ErrGetActivations), "want: %s; got: %s", ErrGetActivations, err)
+
+	activation, err := client.CreateActivation(ctx, CreateActivationRequest{PropertyID: "prp_3"})
+	require.NoError(t, err)
+	assert.Equal(t, "atv_prp_3", activation.ActivationID)
+
+	version, err := client.GetPropertyVersion(ctx, GetPropertyVersionRequest{PropertyID: "prp_1", PropertyVersion: 4})
+	require.NoError(t, err)
+	assert.Equal(t, 4, version.Version.PropertyVersion)
+
+	search, err := client.SearchProperties(ctx, SearchRequest{Key: SearchKeyPropertyName, Value: "example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, "prp_1", search.Versions.Items[0].PropertyID)
+
+	m.AssertExpectations(t)
+}
@@ -2,9 +2,21 @@
 package papi
 
 import (
+	"bytes"
+	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime"
 	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/poll"
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/session"
 	"github.com/spf13/cast"
 )
@@ -38,11 +50,47 @@ type (
 	papi struct {
 		session.Session
 		usePrefixes bool
+		apiVersion  string
+		clock       poll.Clock
+
+		defaultContractID string
+		defaultGroupID    string
+
+		activationNote         string
+		activationNotifyEmails []string
+		activationNoteCheck    NoteValidator
+		activationEmailCheck   NotifyEmailFilter
+
+		locationCache *locationCache
+
+		accountMu sync.Mutex
+		accountID string
+
+		problemDetection bool
+
+		retry *retryPolicy
+
+		operationTimeouts map[OperationType]time.Duration
+	}
+
+	retryPolicy struct {
+		maxRetries   int
+		delay        time.Duration
+		problemTypes map[string]bool
 	}
 
 	// Option defines a PAPI option
 	Option func(*papi)
 
+	// OperationType is a category of requests sharing a default timeout, see WithOperationTimeout
+	OperationType string
+
+	// NoteValidator checks the note of an activation before it is submitted, returning an error rejects the activation
+	NoteValidator func(note string) error
+
+	// NotifyEmailFilter reports whether an activation notification may be sent to the email address
+	NotifyEmailFilter func(email string) bool
+
 	// ClientFunc is a papi client new method, this can used for mocking
 	ClientFunc func(sess session.Session, opts ...Option) PAPI
 
@@ -57,11 +105,42 @@ type (
 	}
 )
 
+const (
+	// DefaultAPIVersion is the PAPI version used in request paths unless overridden with WithAPIVersion
+	DefaultAPIVersion = "v1"
+
+	// OperationRead covers GET and HEAD requests
+	OperationRead OperationType = "read"
+	// OperationWrite covers requests modifying resources, other than activations
+	OperationWrite OperationType = "write"
+	// OperationActivation covers requests creating or canceling activations, which can take much longer than other writes
+	OperationActivation OperationType = "activation"
+)
+
+// papi implements each of the interfaces composing PAPI
+var (
+	_ Groups                   = (*papi)(nil)
+	_ Contracts                = (*papi)(nil)
+	_ Activations              = (*papi)(nil)
+	_ CPCodes                  = (*papi)(nil)
+	_ Properties               = (*papi)(nil)
+	_ PropertyVersions         = (*papi)(nil)
+	_ EdgeHostnames            = (*papi)(nil)
+	_ Products                 = (*papi)(nil)
+	_ Search                   = (*papi)(nil)
+	_ PropertyVersionHostnames = (*papi)(nil)
+	_ ClientSettings           = (*papi)(nil)
+	_ PropertyRules            = (*papi)(nil)
+	_ RuleFormats              = (*papi)(nil)
+)
+
 // Client returns a new papi Client instance with the specified controller
 func Client(sess session.Session, opts ...Option) PAPI {
 	p := &papi{
 		Session:     sess,
 		usePrefixes: true,
+		apiVersion:  DefaultAPIVersion,
+		clock:       poll.SystemClock(),
 	}
 
 	for _, opt := range opts {
@@ -78,10 +157,283 @@ func WithUsePrefixes(usePrefixes bool) Option {
 	}
 }
 
+// WithAPIVersion sets the PAPI version path segment used by all requests, e.g. "v2" to send requests to /papi/v2/...
+func WithAPIVersion(version string) Option {
+	return func(p *papi) {
+		p.apiVersion = version
+	}
+}
+
+// WithClock sets the clock used by the polling helpers, e.g. to use a fake clock in tests
+func WithClock(clock poll.Clock) Option {
+	return func(p *papi) {
+		p.clock = clock
+	}
+}
+
+// WithDefaultContract sets the contract used by list and get requests which do not provide their own ContractID
+func WithDefaultContract(contractID string) Option {
+	return func(p *papi) {
+		p.defaultContractID = contractID
+	}
+}
+
+// WithDefaultGroup sets the group used by list and get requests which do not provide their own GroupID
+func WithDefaultGroup(groupID string) Option {
+	return func(p *papi) {
+		p.defaultGroupID = groupID
+	}
+}
+
+// WithActivationDefaults sets the note and notification emails used by CreateActivation
+// when the request does not provide its own
+func WithActivationDefaults(note string, notifyEmails ...string) Option {
+	return func(p *papi) {
+		p.activationNote = note
+		p.activationNotifyEmails = append([]string(nil), notifyEmails...)
+	}
+}
+
+// WithActivationNoteValidator sets a validator run by CreateActivation on the activation note, after the defaults are applied,
+// e.g. to require a ticket reference with RequireNotePattern. Notes are not validated by default
+func WithActivationNoteValidator(validator NoteValidator) Option {
+	return func(p *papi) {
+		p.activationNoteCheck = validator
+	}
+}
+
+// WithNotifyEmailAllowlist sets a filter run by CreateActivation on the notification emails, after the defaults are applied,
+// e.g. to allow only approved distribution lists with AllowEmails or AllowEmailDomains. Activations notifying any address
+// rejected by the filter fail with ErrStructValidation listing those addresses. Emails are not restricted by default
+func WithNotifyEmailAllowlist(allowed NotifyEmailFilter) Option {
+	return func(p *papi) {
+		p.activationEmailCheck = allowed
+	}
+}
+
+// WithLocationCache enables caching of the property contract and group resolved by ResolvePropertyLocation
+// Cached locations are never refreshed, so a property moved to another group keeps resolving to the old one
+func WithLocationCache() Option {
+	return func(p *papi) {
+		p.locationCache = &locationCache{locations: make(map[string]PropertyLocation)}
+	}
+}
+
+// WithProblemDetection makes requests fail with an *Error when a successful response carries an application/problem+json body,
+// which some endpoints return to describe a logical failure. Disabled by default.
+func WithProblemDetection(enabled bool) Option {
+	return func(p *papi) {
+		p.problemDetection = enabled
+	}
+}
+
+// WithRetryOnProblemTypes retries failed requests up to maxRetries times, but only if the response status is
+// 429 or 503, or the problem type of the error body is one of problemTypes. Other failures are returned immediately,
+// so that requests which are not safe to repeat, such as activations, are only retried on known transient errors.
+// The Retry-After header is used as the time between attempts if present, otherwise delay is used.
+func WithRetryOnProblemTypes(maxRetries int, delay time.Duration, problemTypes ...string) Option {
+	return func(p *papi) {
+		p.retry = &retryPolicy{
+			maxRetries:   maxRetries,
+			delay:        delay,
+			problemTypes: make(map[string]bool, len(problemTypes)),
+		}
+		for _, problemType := range problemTypes {
+			p.retry.problemTypes[problemType] = true
+		}
+	}
+}
+
+// WithOperationTimeout sets the default timeout of every request of the operation type, including its retries.
+// The context of the call still applies, so a shorter deadline set by the caller wins. Requests are not bounded by default
+func WithOperationTimeout(operation OperationType, timeout time.Duration) Option {
+	return func(p *papi) {
+		if p.operationTimeouts == nil {
+			p.operationTimeouts = make(map[OperationType]time.Duration)
+		}
+		p.operationTimeouts[operation] = timeout
+	}
+}
+
+// operationType returns the category of the request used to look up its default timeout
+func operationType(r *http.Request) OperationType {
+	switch {
+	case r.Method == http.MethodGet || r.Method == http.MethodHead:
+		return OperationRead
+	case strings.Contains(r.URL.Path, "/activations"):
+		return OperationActivation
+	default:
+		return OperationWrite
+	}
+}
+
+// applyDefaultLocation sets empty contractID and groupID to the client defaults, either may be nil if the request has no such field
+// The defaults must use the ctr_ and grp_ prefixes if and only if the client uses prefixes, as they are sent to the API as given
+func (p *papi) applyDefaultLocation(contractID, groupID *string) error {
+	if contractID != nil && *contractID == "" && p.defaultContractID != "" {
+		if err := p.checkDefaultPrefix("contract", p.defaultContractID, "ctr_"); err != nil {
+			return err
+		}
+		*contractID = p.defaultContractID
+	}
+	if groupID != nil && *groupID == "" && p.defaultGroupID != "" {
+		if err := p.checkDefaultPrefix("group", p.defaultGroupID, "grp_"); err != nil {
+			return err
+		}
+		*groupID = p.defaultGroupID
+	}
+	return nil
+}
+
+func (p *papi) checkDefaultPrefix(name, id, prefix string) error {
+	if hasPrefix := strings.HasPrefix(id, prefix); hasPrefix != p.usePrefixes {
+		if p.usePrefixes {
+			return fmt.Errorf("default %s %q must start with %q when prefixes are used", name, id, prefix)
+		}
+		return fmt.Errorf("default %s %q must not start with %q when prefixes are not used", name, id, prefix)
+	}
+	return nil
+}
+
 // Exec overrides the session.Exec to add papi options
 func (p *papi) Exec(r *http.Request, out interface{}, in ...interface{}) (*http.Response, error) {
 	// explicitly add the PAPI-Use-Prefixes header
 	r.Header.Set("PAPI-Use-Prefixes", cast.ToString(p.usePrefixes))
 
-	return p.Session.Exec(r, out, in...)
+	// all requests are built against the default version, replace the path segment if a different one is configured
+	if prefix := "/papi/" + DefaultAPIVersion + "/"; p.apiVersion != DefaultAPIVersion && strings.HasPrefix(r.URL.Path, prefix) {
+		r.URL.Path = "/papi/" + p.apiVersion + "/" + strings.TrimPrefix(r.URL.Path, prefix)
+		if r.URL.RawPath != "" {
+			r.URL.RawPath = "/papi/" + p.apiVersion + "/" + strings.TrimPrefix(r.URL.RawPath, prefix)
+		}
+	}
+
+	if timeout := p.operationTimeouts[operationType(r)]; timeout > 0 {
+		ctx, cancel := context.WithTimeout(r.Context(), timeout)
+		defer cancel()
+		r = r.WithContext(ctx)
+		resp, err := p.exec(r, out, in...)
+		if err != nil {
+			return nil, err
+		}
+		// the body is read before the context is canceled, so that callers can still parse error responses
+		body, err := ioutil.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		resp.Body = ioutil.NopCloser(bytes.NewBuffer(body))
+		return resp, nil
+	}
+
+	return p.exec(r, out, in...)
+}
+
+// exec sends the request, retrying it according to the retry policy
+func (p *papi) exec(r *http.Request, out interface{}, in ...interface{}) (*http.Response, error) {
+	// signing modifies the query and the headers of the request, e.g. adding the account switch key,
+	// so they are restored before each retry to sign the request again from its original state
+	rawQuery := r.URL.RawQuery
+	header := r.Header.Clone()
+
+	resp, err := p.Session.Exec(r, out, in...)
+	for attempt := 0; err == nil && p.shouldRetry(r, resp, attempt, len(in) > 0); attempt++ {
+		delay := p.retry.delay
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+			delay = time.Duration(seconds) * time.Second
+		}
+		p.Log(r.Context()).Debugf("retrying request after %s, attempt %d of %d", resp.Status, attempt+1, p.retry.maxRetries)
+		_, _ = io.Copy(ioutil.Discard, resp.Body)
+		_ = resp.Body.Close()
+
+		select {
+		case <-r.Context().Done():
+			return nil, r.Context().Err()
+		case <-p.clock.After(delay):
+		}
+
+		if len(in) == 0 && r.GetBody != nil {
+			if r.Body, err = r.GetBody(); err != nil {
+				return nil, err
+			}
+		}
+		r.URL.RawQuery = rawQuery
+		r.Header = header.Clone()
+		resp, err = p.Session.Exec(r, out, in...)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if p.problemDetection && resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices && isProblemResponse(resp) {
+		return nil, p.Error(resp)
+	}
+
+	return resp, nil
+}
+
+// shouldRetry reports whether the failed request should be sent again according to the retry policy
+func (p *papi) shouldRetry(r *http.Request, resp *http.Response, attempt int, hasInput bool) bool {
+	if p.retry == nil || attempt >= p.retry.maxRetries || resp.StatusCode < http.StatusBadRequest {
+		return false
+	}
+	// the body of the request can only be sent again if it is marshaled from the input or can be recreated
+	if !hasInput && r.GetBody == nil && r.Body != nil && r.Body != http.NoBody {
+		return false
+	}
+	if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable {
+		return true
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body = ioutil.NopCloser(bytes.NewBuffer(body))
+	if err != nil {
+		return false
+	}
+	var problem struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(body, &problem); err != nil {
+		return false
+	}
+	return p.retry.problemTypes[problem.Type]
+}
+
+// isProblemResponse reports whether the response body is a problem details object, as defined in RFC 7807
+func isProblemResponse(resp *http.Response) bool {
+	mediaType, _, err := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	return err == nil && mediaType == "application/problem+json"
+}
+
+// forEachConcurrently calls fn with the index of every item, running at most limit calls at the same time, and waits for all of them to return.
+// Errors returned by fn are added to the returned BulkError under the ID of the item.
+// If ctx is done before every call has started, the remaining items are skipped and ctx.Err() is returned
+func forEachConcurrently(ctx context.Context, ids []string, limit int, fn func(i int) error) (*BulkError, error) {
+	errs := make([]error, len(ids))
+	sem := make(chan struct{}, limit)
+	var wg sync.WaitGroup
+	for i := range ids {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			wg.Wait()
+			return nil, ctx.Err()
+		}
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			errs[i] = fn(i)
+		}(i)
+	}
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	bulkErr := NewBulkError(len(ids))
+	for i, err := range errs {
+		bulkErr.Add(i, ids[i], err)
+	}
+	return bulkErr, nil
 }
@@ -1,20 +1,26 @@
 package papi
 
 import (
+	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"errors"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/edgegrid"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/poll"
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/session"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
-func mockAPIClient(t *testing.T, mockServer *httptest.Server) PAPI {
+func mockAPIClient(t *testing.T, mockServer *httptest.Server, opts ...Option) PAPI {
 	serverURL, err := url.Parse(mockServer.URL)
 	require.NoError(t, err)
 	certPool := x509.NewCertPool()
@@ -28,12 +34,13 @@ func mockAPIClient(t *testing.T, mockServer *httptest.Server) PAPI {
 	}
 	s, err := session.New(session.WithClient(httpClient), session.WithSigner(&edgegrid.Config{Host: serverURL.Host}))
 	assert.NoError(t, err)
-	return Client(s)
+	return Client(s, opts...)
 }
 
 func TestClient(t *testing.T) {
 	sess, err := session.New()
 	require.NoError(t, err)
+	clock := poll.NewFakeClock(time.Now())
 	tests := map[string]struct {
 		options  []Option
 		expected *papi
@@ -43,6 +50,8 @@ func TestClient(t *testing.T) {
 			expected: &papi{
 				Session:     sess,
 				usePrefixes: true,
+				apiVersion:  DefaultAPIVersion,
+				clock:       poll.SystemClock(),
 			},
 		},
 		"papi prefixes set to false": {
@@ -50,6 +59,92 @@ func TestClient(t *testing.T) {
 			expected: &papi{
 				Session:     sess,
 				usePrefixes: false,
+				apiVersion:  DefaultAPIVersion,
+				clock:       poll.SystemClock(),
+			},
+		},
+		"api version": {
+			options: []Option{WithAPIVersion("v2")},
+			expected: &papi{
+				Session:     sess,
+				usePrefixes: true,
+				apiVersion:  "v2",
+				clock:       poll.SystemClock(),
+			},
+		},
+		"location cache": {
+			options: []Option{WithLocationCache()},
+			expected: &papi{
+				Session:       sess,
+				usePrefixes:   true,
+				apiVersion:    DefaultAPIVersion,
+				clock:         poll.SystemClock(),
+				locationCache: &locationCache{locations: map[string]PropertyLocation{}},
+			},
+		},
+		"problem detection": {
+			options: []Option{WithProblemDetection(true)},
+			expected: &papi{
+				Session:          sess,
+				usePrefixes:      true,
+				apiVersion:       DefaultAPIVersion,
+				clock:            poll.SystemClock(),
+				problemDetection: true,
+			},
+		},
+		"custom clock": {
+			options: []Option{WithClock(clock)},
+			expected: &papi{
+				Session:     sess,
+				usePrefixes: true,
+				apiVersion:  DefaultAPIVersion,
+				clock:       clock,
+			},
+		},
+		"retry on problem types": {
+			options: []Option{WithRetryOnProblemTypes(2, time.Second, "https://problems.example.net/papi/v0/transient")},
+			expected: &papi{
+				Session:     sess,
+				usePrefixes: true,
+				apiVersion:  DefaultAPIVersion,
+				clock:       poll.SystemClock(),
+				retry: &retryPolicy{
+					maxRetries:   2,
+					delay:        time.Second,
+					problemTypes: map[string]bool{"https://problems.example.net/papi/v0/transient": true},
+				},
+			},
+		},
+		"activation defaults": {
+			options: []Option{WithActivationDefaults("routine release", "ops@example.com")},
+			expected: &papi{
+				Session:                sess,
+				usePrefixes:            true,
+				apiVersion:             DefaultAPIVersion,
+				clock:                  poll.SystemClock(),
+				activationNote:         "routine release",
+				activationNotifyEmails: []string{"ops@example.com"},
+			},
+		},
+		"default contract and group": {
+			options: []Option{WithDefaultContract("ctr_1-1TJZFW"), WithDefaultGroup("grp_15166")},
+			expected: &papi{
+				Session:           sess,
+				usePrefixes:       true,
+				apiVersion:        DefaultAPIVersion,
+				clock:             poll.SystemClock(),
+				defaultContractID: "ctr_1-1TJZFW",
+				defaultGroupID:    "grp_15166",
+			},
+		},
+		"operation timeouts": {
+			options: []Option{WithOperationTimeout(OperationRead, 10*time.Second), WithOperationTimeout(OperationActivation, 5*time.Minute)},
+			expected: &papi{
+				Session:           sess,
+				usePrefixes:       true,
+				apiVersion:        DefaultAPIVersion,
+				clock:             poll.SystemClock(),
+				operationTimeouts: map[OperationType]time.Duration{OperationRead: 10 * time.Second, OperationActivation: 5 * time.Minute},
 			},
 		},
 	}
@@ -60,3 +155,653 @@ func TestClient(t *testing.T) {
 		})
 	}
 }
+
+func TestPapi_WithAPIVersion(t *testing.T) {
+	tests := map[string]struct {
+		options      []Option
+		call         func(context.Context, PAPI) error
+		responseBody string
+		expectedPath string
+	}{
+		"default version": {
+			call: func(ctx context.Context, client PAPI) error {
+				_, err := client.GetContracts(ctx)
+				return err
+			},
+			expectedPath: "/papi/v1/contracts",
+		},
+		"search": {
+			options: []Option{WithAPIVersion("v2")},
+			call: func(ctx context.Context, client PAPI) error {
+				_, err := client.SearchProperties(ctx, SearchRequest{Key: SearchKeyPropertyName, Value: "example.com"})
+				return err
+			},
+			expectedPath: "/papi/v2/search/find-by-value",
+		},
+		"property version": {
+			options: []Option{WithAPIVersion("v2")},
+			call: func(ctx context.Context, client PAPI) error {
+				_, err := client.GetPropertyVersion(ctx, GetPropertyVersionRequest{PropertyID: "prp_175780", PropertyVersion: 2, ContractID: "ctr_1-1TJZFW", GroupID: "grp_15166"})
+				return err
+			},
+			responseBody: `{"versions": {"items": [{"propertyVersion": 2}]}}`,
+			expectedPath: "/papi/v2/properties/prp_175780/versions/2?contractId=ctr_1-1TJZFW&groupId=grp_15166",
+		},
+		"activations": {
+			options: []Option{WithAPIVersion("v2")},
+			call: func(ctx context.Context, client PAPI) error {
+				_, err := client.GetActivations(ctx, GetActivationsRequest{PropertyID: "prp_175780", ContractID: "ctr_1-1TJZFW", GroupID: "grp_15166"})
+				return err
+			},
+			expectedPath: "/papi/v2/properties/prp_175780/activations?contractId=ctr_1-1TJZFW&groupId=grp_15166",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, test.expectedPath, r.URL.String())
+				w.WriteHeader(http.StatusOK)
+				body := test.responseBody
+				if body == "" {
+					body = `{}`
+				}
+				_, err := w.Write([]byte(body))
+				assert.NoError(t, err)
+			}))
+			defer mockServer.Close()
+			client := mockAPIClient(t, mockServer, test.options...)
+			assert.NoError(t, test.call(context.Background(), client))
+		})
+	}
+}
+
+func TestPapi_WithDefaultContractAndGroup(t *testing.T) {
+	tests := map[string]struct {
+		options      []Option
+		call         func(context.Context, PAPI) error
+		responseBody string
+		expectedPath string
+		withError    error
+	}{
+		"defaults applied": {
+			options: []Option{WithDefaultContract("ctr_1-1TJZFW"), WithDefaultGroup("grp_15166")},
+			call: func(ctx context.Context, client PAPI) error {
+				_, err := client.GetProperties(ctx, GetPropertiesRequest{})
+				return err
+			},
+			expectedPath: "/papi/v1/properties?contractId=ctr_1-1TJZFW&groupId=grp_15166",
+		},
+		"defaults overridden by request": {
+			options: []Option{WithDefaultContract("ctr_1-1TJZFW"), WithDefaultGroup("grp_15166")},
+			call: func(ctx context.Context, client PAPI) error {
+				_, err := client.GetCPCodes(ctx, GetCPCodesRequest{ContractID: "ctr_2-3CV382", GroupID: "grp_27182"})
+				return err
+			},
+			expectedPath: "/papi/v1/cpcodes?contractId=ctr_2-3CV382&groupId=grp_27182",
+		},
+		"only group overridden": {
+			options: []Option{WithDefaultContract("ctr_1-1TJZFW"), WithDefaultGroup("grp_15166")},
+			call: func(ctx context.Context, client PAPI) error {
+				_, err := client.GetEdgeHostnames(ctx, GetEdgeHostnamesRequest{GroupID: "grp_27182"})
+				return err
+			},
+			expectedPath: "/papi/v1/edgehostnames?contractId=ctr_1-1TJZFW&groupId=grp_27182",
+		},
+		"contract only request": {
+			options: []Option{WithDefaultContract("ctr_1-1TJZFW"), WithDefaultGroup("grp_15166")},
+			call: func(ctx context.Context, client PAPI) error {
+				_, err := client.GetProducts(ctx, GetProductsRequest{})
+				return err
+			},
+			expectedPath: "/papi/v1/products?contractId=ctr_1-1TJZFW",
+		},
+		"defaults applied to rule tree etag": {
+			options: []Option{WithDefaultContract("ctr_1-1TJZFW"), WithDefaultGroup("grp_15166")},
+			call: func(ctx context.Context, client PAPI) error {
+				_, err := client.GetRuleTreeETag(ctx, GetRuleTreeETagRequest{PropertyID: "prp_175780", PropertyVersion: 3})
+				return err
+			},
+			expectedPath: "/papi/v1/properties/prp_175780/versions/3/rules?contractId=ctr_1-1TJZFW&groupId=grp_15166",
+		},
+		"defaults applied to activation cancellation": {
+			options: []Option{WithDefaultContract("ctr_1-1TJZFW"), WithDefaultGroup("grp_15166")},
+			call: func(ctx context.Context, client PAPI) error {
+				_, err := client.CancelActivation(ctx, CancelActivationRequest{PropertyID: "prp_175780", ActivationID: "atv_1696855"})
+				return err
+			},
+			expectedPath: "/papi/v1/properties/prp_175780/activations/atv_1696855?contractId=ctr_1-1TJZFW&groupId=grp_15166",
+		},
+		"defaults applied to activation errors": {
+			options: []Option{WithDefaultContract("ctr_1-1TJZFW"), WithDefaultGroup("grp_15166")},
+			call: func(ctx context.Context, client PAPI) error {
+				_, err := client.GetActivationErrors(ctx, GetActivationErrorsRequest{PropertyID: "prp_175780", ActivationID: "atv_1696855"})
+				return err
+			},
+			responseBody: `{"activations": {"items": [{"activationId": "atv_1696855", "status": "ACTIVE"}]}}`,
+			expectedPath: "/papi/v1/properties/prp_175780/activations/atv_1696855?contractId=ctr_1-1TJZFW&groupId=grp_15166",
+		},
+		"defaults applied to available behaviors": {
+			options: []Option{WithDefaultContract("ctr_1-1TJZFW"), WithDefaultGroup("grp_15166")},
+			call: func(ctx context.Context, client PAPI) error {
+				_, err := client.GetAvailableBehaviors(ctx, GetFeaturesRequest{PropertyID: "prp_175780", PropertyVersion: 3})
+				return err
+			},
+			expectedPath: "/papi/v1/properties/prp_175780/versions/3/available-behaviors?contractId=ctr_1-1TJZFW&groupId=grp_15166",
+		},
+		"defaults applied to available criteria": {
+			options: []Option{WithDefaultContract("ctr_1-1TJZFW"), WithDefaultGroup("grp_15166")},
+			call: func(ctx context.Context, client PAPI) error {
+				_, err := client.GetAvailableCriteria(ctx, GetFeaturesRequest{PropertyID: "prp_175780", PropertyVersion: 3})
+				return err
+			},
+			expectedPath: "/papi/v1/properties/prp_175780/versions/3/available-criteria?contractId=ctr_1-1TJZFW&groupId=grp_15166",
+		},
+		"defaults without prefixes": {
+			options: []Option{WithUsePrefixes(false), WithDefaultContract("1-1TJZFW"), WithDefaultGroup("15166")},
+			call: func(ctx context.Context, client PAPI) error {
+				_, err := client.GetProperties(ctx, GetPropertiesRequest{})
+				return err
+			},
+			expectedPath: "/papi/v1/properties?contractId=1-1TJZFW&groupId=15166",
+		},
+		"default without prefix when prefixes are used": {
+			options: []Option{WithDefaultContract("1-1TJZFW"), WithDefaultGroup("grp_15166")},
+			call: func(ctx context.Context, client PAPI) error {
+				_, err := client.GetProperties(ctx, GetPropertiesRequest{})
+				return err
+			},
+			withError: ErrStructValidation,
+		},
+		"default with prefix when prefixes are not used": {
+			options: []Option{WithUsePrefixes(false), WithDefaultContract("1-1TJZFW"), WithDefaultGroup("grp_15166")},
+			call: func(ctx context.Context, client PAPI) error {
+				_, err := client.GetProperties(ctx, GetPropertiesRequest{})
+				return err
+			},
+			withError: ErrStructValidation,
+		},
+		"no defaults": {
+			call: func(ctx context.Context, client PAPI) error {
+				_, err := client.GetProperties(ctx, GetPropertiesRequest{})
+				return err
+			},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, test.expectedPath, r.URL.String())
+				w.Header().Set("ETag", `"1a2b3c"`)
+				w.WriteHeader(http.StatusOK)
+				body := test.responseBody
+				if body == "" {
+					body = `{}`
+				}
+				_, err := w.Write([]byte(body))
+				assert.NoError(t, err)
+			}))
+			defer mockServer.Close()
+			client := mockAPIClient(t, mockServer, test.options...)
+			err := test.call(context.Background(), client)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestPapi_PathParametersEscaping(t *testing.T) {
+	tests := map[string]struct {
+		options            []Option
+		call               func(context.Context, PAPI) error
+		responseBody       string
+		expectedRequestURI string
+	}{
+		"property ID with slash": {
+			call: func(ctx context.Context, client PAPI) error {
+				_, err := client.GetProperty(ctx, GetPropertyRequest{PropertyID: "prp_1/../prp_2"})
+				return err
+			},
+			responseBody:       `{"properties": {"items": [{"propertyId": "prp_1"}]}}`,
+			expectedRequestURI: "/papi/v1/properties/prp_1%2F..%2Fprp_2",
+		},
+		"property ID with space": {
+			call: func(ctx context.Context, client PAPI) error {
+				_, err := client.GetPropertyVersion(ctx, GetPropertyVersionRequest{PropertyID: "prp 1", PropertyVersion: 1, ContractID: "ctr_1", GroupID: "grp_1"})
+				return err
+			},
+			responseBody:       `{"versions": {"items": [{"propertyVersion": 1}]}}`,
+			expectedRequestURI: "/papi/v1/properties/prp%201/versions/1?contractId=ctr_1&groupId=grp_1",
+		},
+		"activation ID with question mark": {
+			call: func(ctx context.Context, client PAPI) error {
+				_, err := client.GetActivation(ctx, GetActivationRequest{PropertyID: "prp_1", ActivationID: "atv_1?x=y", ContractID: "ctr_1", GroupID: "grp_1"})
+				return err
+			},
+			responseBody:       `{"activations": {"items": [{"activationId": "atv_1?x=y"}]}}`,
+			expectedRequestURI: "/papi/v1/properties/prp_1/activations/atv_1%3Fx=y?contractId=ctr_1&groupId=grp_1",
+		},
+		"edge hostname ID with slash": {
+			call: func(ctx context.Context, client PAPI) error {
+				_, err := client.GetEdgeHostname(ctx, GetEdgeHostnameRequest{EdgeHostnameID: "ehn_1/2", ContractID: "ctr_1", GroupID: "grp_1"})
+				return err
+			},
+			responseBody:       `{"edgeHostnames": {"items": [{"edgeHostnameId": "ehn_1/2"}]}}`,
+			expectedRequestURI: "/papi/v1/edgehostnames/ehn_1%2F2?contractId=ctr_1&groupId=grp_1",
+		},
+		"escaped path with overridden API version": {
+			options: []Option{WithAPIVersion("v2")},
+			call: func(ctx context.Context, client PAPI) error {
+				_, err := client.GetRuleTree(ctx, GetRuleTreeRequest{PropertyID: "prp_1/2", PropertyVersion: 3, ContractID: "ctr_1", GroupID: "grp_1"})
+				return err
+			},
+			responseBody:       `{"rules": {"name": "default"}}`,
+			expectedRequestURI: "/papi/v2/properties/prp_1%2F2/versions/3/rules?contractId=ctr_1&groupId=grp_1&validateRules=false",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, test.expectedRequestURI, r.RequestURI)
+				w.WriteHeader(http.StatusOK)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			defer mockServer.Close()
+			client := mockAPIClient(t, mockServer, test.options...)
+			assert.NoError(t, test.call(context.Background(), client))
+		})
+	}
+}
+
+func TestPapi_WithProblemDetection(t *testing.T) {
+	problemBody := `
+{
+	"type": "https://problems.luna.akamaiapis.net/papi/v1/contracts/unavailable",
+	"title": "Contracts unavailable",
+	"detail": "Contracts could not be fetched for the account",
+	"status": 200
+}`
+	tests := map[string]struct {
+		options        []Option
+		contentType    string
+		responseBody   string
+		expectedResult *GetContractsResponse
+		withError      string
+	}{
+		"problem body with detection enabled": {
+			options:      []Option{WithProblemDetection(true)},
+			contentType:  "application/problem+json; charset=utf-8",
+			responseBody: problemBody,
+			withError:    "Contracts could not be fetched for the account",
+		},
+		"problem body with detection disabled": {
+			contentType:    "application/problem+json",
+			responseBody:   problemBody,
+			expectedResult: &GetContractsResponse{},
+		},
+		"regular body with detection enabled": {
+			options:      []Option{WithProblemDetection(true)},
+			contentType:  "application/json",
+			responseBody: `{"accountId": "act_1-1TJZFB", "contracts": {"items": [{"contractId": "ctr_1-1TJZH5"}]}}`,
+			expectedResult: &GetContractsResponse{
+				AccountID: "act_1-1TJZFB",
+				Contracts: ContractsItems{Items: []*Contract{{ContractID: "ctr_1-1TJZH5"}}},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", test.contentType)
+				w.WriteHeader(http.StatusOK)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			defer mockServer.Close()
+			client := mockAPIClient(t, mockServer, test.options...)
+			result, err := client.GetContracts(context.Background())
+			if test.withError != "" {
+				require.Error(t, err)
+				assert.True(t, errors.Is(err, ErrGetContracts), "want: %s; got: %s", ErrGetContracts, err)
+				assert.Contains(t, err.Error(), test.withError)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResult, result)
+		})
+	}
+}
+
+func TestPapi_WithRetryOnProblemTypes(t *testing.T) {
+	transientProblem := `
+{
+	"type": "https://problems.example.net/papi/v0/transient",
+	"title": "Temporary failure",
+	"detail": "The activation could not be submitted, try again later",
+	"status": 500
+}`
+	permanentProblem := `
+{
+	"type": "https://problems.example.net/papi/v0/property-version-not-found",
+	"title": "Not found",
+	"detail": "The property version does not exist",
+	"status": 404
+}`
+	created := `
+{
+	"activationLink": "/papi/v1/properties/prp_175780/activations/atv_67037?contractId=ctr_1-1TJZFW&groupId=grp_15166"
+}`
+	type response struct {
+		status int
+		body   string
+	}
+	tests := map[string]struct {
+		options          []Option
+		responses        []response
+		expectedCalls    int32
+		expectedResponse *CreateActivationResponse
+		withError        error
+	}{
+		"retryable problem type": {
+			options: []Option{WithRetryOnProblemTypes(2, 0, "https://problems.example.net/papi/v0/transient")},
+			responses: []response{
+				{status: http.StatusInternalServerError, body: transientProblem},
+				{status: http.StatusCreated, body: created},
+			},
+			expectedCalls: 2,
+			expectedResponse: &CreateActivationResponse{
+				ActivationID:   "atv_67037",
+				ActivationLink: "/papi/v1/properties/prp_175780/activations/atv_67037?contractId=ctr_1-1TJZFW&groupId=grp_15166",
+			},
+		},
+		"service unavailable is always retryable": {
+			options: []Option{WithRetryOnProblemTypes(2, 0)},
+			responses: []response{
+				{status: http.StatusServiceUnavailable},
+				{status: http.StatusTooManyRequests},
+				{status: http.StatusCreated, body: created},
+			},
+			expectedCalls: 3,
+			expectedResponse: &CreateActivationResponse{
+				ActivationID:   "atv_67037",
+				ActivationLink: "/papi/v1/properties/prp_175780/activations/atv_67037?contractId=ctr_1-1TJZFW&groupId=grp_15166",
+			},
+		},
+		"non-retryable problem type": {
+			options: []Option{WithRetryOnProblemTypes(2, 0, "https://problems.example.net/papi/v0/transient")},
+			responses: []response{
+				{status: http.StatusNotFound, body: permanentProblem},
+			},
+			expectedCalls: 1,
+			withError: &Error{
+				Type:       "https://problems.example.net/papi/v0/property-version-not-found",
+				Title:      "Not found",
+				Detail:     "The property version does not exist",
+				StatusCode: http.StatusNotFound,
+			},
+		},
+		"retries exhausted": {
+			options: []Option{WithRetryOnProblemTypes(1, 0, "https://problems.example.net/papi/v0/transient")},
+			responses: []response{
+				{status: http.StatusInternalServerError, body: transientProblem},
+				{status: http.StatusInternalServerError, body: transientProblem},
+				{status: http.StatusCreated, body: created},
+			},
+			expectedCalls: 2,
+			withError: &Error{
+				Type:       "https://problems.example.net/papi/v0/transient",
+				Title:      "Temporary failure",
+				Detail:     "The activation could not be submitted, try again later",
+				StatusCode: http.StatusInternalServerError,
+			},
+		},
+		"retry disabled": {
+			responses: []response{
+				{status: http.StatusInternalServerError, body: transientProblem},
+				{status: http.StatusCreated, body: created},
+			},
+			expectedCalls: 1,
+			withError: &Error{
+				Type:       "https://problems.example.net/papi/v0/transient",
+				Title:      "Temporary failure",
+				Detail:     "The activation could not be submitted, try again later",
+				StatusCode: http.StatusInternalServerError,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var calls int32
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				call := atomic.AddInt32(&calls, 1)
+				body, err := ioutil.ReadAll(r.Body)
+				assert.NoError(t, err)
+				assert.Contains(t, string(body), `"propertyVersion":1`)
+				res := test.responses[call-1]
+				w.WriteHeader(res.status)
+				_, err = w.Write([]byte(res.body))
+				assert.NoError(t, err)
+			}))
+			defer mockServer.Close()
+			client := mockAPIClient(t, mockServer, test.options...)
+			result, err := client.CreateActivation(context.Background(), CreateActivationRequest{
+				PropertyID: "prp_175780",
+				ContractID: "ctr_1-1TJZFW",
+				GroupID:    "grp_15166",
+				Activation: Activation{
+					PropertyVersion: 1,
+					Network:         ActivationNetworkStaging,
+					NotifyEmails:    []string{"you@example.com"},
+				},
+			})
+			assert.Equal(t, test.expectedCalls, atomic.LoadInt32(&calls))
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestPapi_WithRetryOnProblemTypes_AccountSwitchKey(t *testing.T) {
+	var queries []string
+	mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		queries = append(queries, r.URL.RawQuery)
+		if len(queries) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"accountId": "act_1-1TJZFB", "contracts": {"items": []}}`))
+		assert.NoError(t, err)
+	}))
+	defer mockServer.Close()
+	serverURL, err := url.Parse(mockServer.URL)
+	require.NoError(t, err)
+	certPool := x509.NewCertPool()
+	certPool.AddCert(mockServer.Certificate())
+	httpClient := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				RootCAs: certPool,
+			},
+		},
+	}
+	s, err := session.New(session.WithClient(httpClient), session.WithSigner(&edgegrid.Config{Host: serverURL.Host, AccountKey: "1-ABCDE"}))
+	require.NoError(t, err)
+	client := Client(s, WithRetryOnProblemTypes(2, 0))
+
+	_, err = client.GetContracts(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"accountSwitchKey=1-ABCDE", "accountSwitchKey=1-ABCDE"}, queries)
+}
+
+func TestPapi_WithOperationTimeout(t *testing.T) {
+	const responseDelay = 200 * time.Millisecond
+	mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(responseDelay):
+		case <-r.Context().Done():
+			return
+		}
+		var err error
+		switch r.Method {
+		case http.MethodGet:
+			w.WriteHeader(http.StatusOK)
+			_, err = w.Write([]byte(`{"activations": {"items": []}}`))
+		case http.MethodPost:
+			w.WriteHeader(http.StatusCreated)
+			_, err = w.Write([]byte(`{"activationLink": "/papi/v1/properties/prp_175780/activations/atv_67037"}`))
+		case http.MethodPut:
+			w.WriteHeader(http.StatusBadRequest)
+			_, err = w.Write([]byte(`{"type": "bad_request", "title": "Bad Request", "status": 400}`))
+		}
+		assert.NoError(t, err)
+	}))
+	defer mockServer.Close()
+	client := mockAPIClient(t, mockServer,
+		WithOperationTimeout(OperationRead, 20*time.Millisecond),
+		WithOperationTimeout(OperationWrite, 5*time.Second),
+		WithOperationTimeout(OperationActivation, 5*time.Second),
+	)
+	activation := CreateActivationRequest{
+		PropertyID: "prp_175780",
+		Activation: Activation{
+			PropertyVersion: 1,
+			Network:         ActivationNetworkStaging,
+			NotifyEmails:    []string{"you@example.com"},
+		},
+	}
+
+	t.Run("activation uses its timeout", func(t *testing.T) {
+		result, err := client.CreateActivation(context.Background(), activation)
+		require.NoError(t, err)
+		assert.Equal(t, "atv_67037", result.ActivationID)
+	})
+
+	t.Run("read uses a shorter timeout", func(t *testing.T) {
+		_, err := client.GetActivations(context.Background(), GetActivationsRequest{PropertyID: "prp_175780"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), context.DeadlineExceeded.Error())
+	})
+
+	t.Run("error body is readable after write", func(t *testing.T) {
+		_, err := client.UpdateRuleTree(context.Background(), UpdateRulesRequest{
+			PropertyID:      "prp_175780",
+			PropertyVersion: 1,
+			Rules:           RulesUpdate{Rules: Rules{Name: "default"}},
+		})
+		want := &Error{Type: "bad_request", Title: "Bad Request", StatusCode: http.StatusBadRequest}
+		assert.True(t, errors.Is(err, want), "want: %s; got: %s", want, err)
+	})
+
+	t.Run("shorter context deadline wins", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		_, err := client.CreateActivation(ctx, activation)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), context.DeadlineExceeded.Error())
+	})
+}
+
+func TestOperationType(t *testing.T) {
+	tests := map[string]struct {
+		method   string
+		path     string
+		expected OperationType
+	}{
+		"get":               {method: http.MethodGet, path: "/papi/v1/properties", expected: OperationRead},
+		"head":              {method: http.MethodHead, path: "/papi/v1/properties/prp_1/versions/1/rules", expected: OperationRead},
+		"list activations":  {method: http.MethodGet, path: "/papi/v1/properties/prp_1/activations", expected: OperationRead},
+		"create activation": {method: http.MethodPost, path: "/papi/v1/properties/prp_1/activations", expected: OperationActivation},
+		"cancel activation": {method: http.MethodDelete, path: "/papi/v1/properties/prp_1/activations/atv_1", expected: OperationActivation},
+		"update rules":      {method: http.MethodPut, path: "/papi/v1/properties/prp_1/versions/1/rules", expected: OperationWrite},
+		"create property":   {method: http.MethodPost, path: "/papi/v1/properties", expected: OperationWrite},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			r, err := http.NewRequest(test.method, test.path, nil)
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, operationType(r))
+		})
+	}
+}
+
+func TestForEachConcurrently(t *testing.T) {
+	failure := errors.New("failure")
+	tests := map[string]struct {
+		ids              []string
+		limit            int
+		failing          map[int]bool
+		expectedFailures []BulkItemError
+	}{
+		"all items succeed": {
+			ids:   []string{"a", "b", "c"},
+			limit: 2,
+		},
+		"failures reported in item order": {
+			ids:     []string{"a", "b", "c", "d"},
+			limit:   4,
+			failing: map[int]bool{1: true, 3: true},
+			expectedFailures: []BulkItemError{
+				{Index: 1, ID: "b", Err: failure},
+				{Index: 3, ID: "d", Err: failure},
+			},
+		},
+		"no items": {
+			limit: 1,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var calls, inFlight, maxInFlight int32
+			bulkErr, err := forEachConcurrently(context.Background(), test.ids, test.limit, func(i int) error {
+				atomic.AddInt32(&calls, 1)
+				current := atomic.AddInt32(&inFlight, 1)
+				defer atomic.AddInt32(&inFlight, -1)
+				for {
+					max := atomic.LoadInt32(&maxInFlight)
+					if current <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, current) {
+						break
+					}
+				}
+				time.Sleep(5 * time.Millisecond)
+				if test.failing[i] {
+					return failure
+				}
+				return nil
+			})
+			require.NoError(t, err)
+			assert.Equal(t, int32(len(test.ids)), atomic.LoadInt32(&calls))
+			assert.LessOrEqual(t, atomic.LoadInt32(&maxInFlight), int32(test.limit))
+			assert.Equal(t, len(test.ids), bulkErr.Total)
+			assert.Equal(t, test.expectedFailures, bulkErr.Failures)
+		})
+	}
+}
+
+func TestForEachConcurrently_ContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var calls int32
+	bulkErr, err := forEachConcurrently(ctx, []string{"a", "b", "c"}, 1, func(i int) error {
+		atomic.AddInt32(&calls, 1)
+		// let the next item wait for a free slot before the context is canceled
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+		return nil
+	})
+	assert.Nil(t, bulkErr)
+	assert.True(t, errors.Is(err, context.Canceled), "want: %s; got: %s", context.Canceled, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+}
@@ -56,6 +56,9 @@ var (
 
 // GetProducts is used to list all products for a given contract
 func (p *papi) GetProducts(ctx context.Context, params GetProductsRequest) (*GetProductsResponse, error) {
+	if err := p.applyDefaultLocation(&params.ContractID, nil); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrGetProducts, ErrStructValidation, err)
+	}
 	if err := params.Validate(); err != nil {
 		return nil, fmt.Errorf("%s: %w: %s", ErrGetProducts, ErrStructValidation, err)
 	}
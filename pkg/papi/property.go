@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"sync"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/edgegriderr"
 	validation "github.com/go-ozzo/ozzo-validation/v4"
@@ -19,6 +20,10 @@ type (
 		CreateProperty(ctx context.Context, params CreatePropertyRequest) (*CreatePropertyResponse, error)
 		GetProperty(ctx context.Context, params GetPropertyRequest) (*GetPropertyResponse, error)
 		RemoveProperty(ctx context.Context, params RemovePropertyRequest) (*RemovePropertyResponse, error)
+
+		// ResolvePropertyLocation looks up the contract and group of a property, so that calls requiring them
+		// can be made knowing only the property ID
+		ResolvePropertyLocation(ctx context.Context, params ResolvePropertyLocationRequest) (*PropertyLocation, error)
 	}
 
 	// PropertyCloneFrom optionally identifies another property instance to clone when making a POST request to create a new property
@@ -83,6 +88,23 @@ type (
 		PropertyLink string `json:"propertyLink"`
 	}
 
+	// ResolvePropertyLocationRequest is the argument for ResolvePropertyLocation
+	ResolvePropertyLocationRequest struct {
+		PropertyID string
+	}
+
+	// PropertyLocation is the contract and group a property belongs to
+	PropertyLocation struct {
+		ContractID string
+		GroupID    string
+	}
+
+	// locationCache stores resolved property locations, see WithLocationCache
+	locationCache struct {
+		sync.Mutex
+		locations map[string]PropertyLocation
+	}
+
 	// GetPropertyRequest is the argument for GetProperty
 	GetPropertyRequest struct {
 		ContractID string
@@ -152,6 +174,13 @@ func (v GetPropertyRequest) Validate() error {
 	}.Filter()
 }
 
+// Validate validates ResolvePropertyLocationRequest
+func (v ResolvePropertyLocationRequest) Validate() error {
+	return validation.Errors{
+		"PropertyID": validation.Validate(v.PropertyID, validation.Required),
+	}.Filter()
+}
+
 // Validate validates RemovePropertyRequest
 func (v RemovePropertyRequest) Validate() error {
 	return validation.Errors{
@@ -168,9 +197,14 @@ var (
 	ErrCreateProperty = errors.New("creating property")
 	// ErrRemoveProperty represents error when removing property fails
 	ErrRemoveProperty = errors.New("removing property")
+	// ErrResolvePropertyLocation represents error when resolving property contract and group fails
+	ErrResolvePropertyLocation = errors.New("resolving property location")
 )
 
 func (p *papi) GetProperties(ctx context.Context, params GetPropertiesRequest) (*GetPropertiesResponse, error) {
+	if err := p.applyDefaultLocation(&params.ContractID, &params.GroupID); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrGetProperties, ErrStructValidation, err)
+	}
 	if err := params.Validate(); err != nil {
 		return nil, fmt.Errorf("%s: %w: %s", ErrGetProperties, ErrStructValidation, err)
 	}
@@ -241,6 +275,14 @@ func (p *papi) CreateProperty(ctx context.Context, params CreatePropertyRequest)
 }
 
 func (p *papi) GetProperty(ctx context.Context, params GetPropertyRequest) (*GetPropertyResponse, error) {
+	if err := p.applyDefaultLocation(&params.ContractID, &params.GroupID); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrGetProperty, ErrStructValidation, err)
+	}
+	return p.getProperty(ctx, params)
+}
+
+// getProperty fetches the property with the contract and group of the request as they are, without the client defaults
+func (p *papi) getProperty(ctx context.Context, params GetPropertyRequest) (*GetPropertyResponse, error) {
 	if err := params.Validate(); err != nil {
 		return nil, fmt.Errorf("%s: %w: %s", ErrGetProperty, ErrStructValidation, err)
 	}
@@ -252,7 +294,7 @@ func (p *papi) GetProperty(ctx context.Context, params GetPropertyRequest) (*Get
 
 	uri, err := url.Parse(fmt.Sprintf(
 		"/papi/v1/properties/%s",
-		params.PropertyID),
+		url.PathEscape(params.PropertyID)),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("%w: failed to parse url: %s", ErrGetProperty, err)
@@ -300,7 +342,7 @@ func (p *papi) RemoveProperty(ctx context.Context, params RemovePropertyRequest)
 
 	uri, err := url.Parse(fmt.Sprintf(
 		"/papi/v1/properties/%s",
-		params.PropertyID),
+		url.PathEscape(params.PropertyID)),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("%w: failed parse url: %s", ErrRemoveProperty, err)
@@ -330,3 +372,49 @@ func (p *papi) RemoveProperty(ctx context.Context, params RemovePropertyRequest)
 
 	return &rval, nil
 }
+
+func (p *papi) ResolvePropertyLocation(ctx context.Context, params ResolvePropertyLocationRequest) (*PropertyLocation, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrResolvePropertyLocation, ErrStructValidation, err)
+	}
+
+	logger := p.Log(ctx)
+	logger.Debug("ResolvePropertyLocation")
+
+	if location, ok := p.locationCache.get(params.PropertyID); ok {
+		return &location, nil
+	}
+
+	// the property may be outside of the default contract and group, so they are not sent
+	property, err := p.getProperty(ctx, GetPropertyRequest{PropertyID: params.PropertyID})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ErrResolvePropertyLocation, err)
+	}
+
+	location := PropertyLocation{
+		ContractID: property.Property.ContractID,
+		GroupID:    property.Property.GroupID,
+	}
+	p.locationCache.set(params.PropertyID, location)
+
+	return &location, nil
+}
+
+func (c *locationCache) get(propertyID string) (PropertyLocation, bool) {
+	if c == nil {
+		return PropertyLocation{}, false
+	}
+	c.Lock()
+	defer c.Unlock()
+	location, ok := c.locations[propertyID]
+	return location, ok
+}
+
+func (c *locationCache) set(propertyID string, location PropertyLocation) {
+	if c == nil {
+		return
+	}
+	c.Lock()
+	defer c.Unlock()
+	c.locations[propertyID] = location
+}
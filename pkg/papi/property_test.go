@@ -421,3 +421,117 @@ func TestPapi_RemoveProperty(t *testing.T) {
 		})
 	}
 }
+
+func TestPapi_ResolvePropertyLocation(t *testing.T) {
+	propertyBody := `
+{
+	"properties": {
+		"items": [
+			{
+				"accountId": "act_1-1TJZFB",
+				"contractId": "ctr_1-1TJZFW",
+				"groupId": "grp_15225",
+				"propertyId": "prp_175780",
+				"propertyName": "example.com",
+				"latestVersion": 2,
+				"assetId": "aid_101"
+			}
+		]
+	}
+}`
+	tests := map[string]struct {
+		request          ResolvePropertyLocationRequest
+		options          []Option
+		responseStatus   int
+		responseBody     string
+		calls            int
+		expectedRequests int
+		expectedResponse *PropertyLocation
+		withError        error
+	}{
+		"200 OK": {
+			request:          ResolvePropertyLocationRequest{PropertyID: "prp_175780"},
+			responseStatus:   http.StatusOK,
+			responseBody:     propertyBody,
+			calls:            2,
+			expectedRequests: 2,
+			expectedResponse: &PropertyLocation{ContractID: "ctr_1-1TJZFW", GroupID: "grp_15225"},
+		},
+		"200 OK with cache": {
+			request:          ResolvePropertyLocationRequest{PropertyID: "prp_175780"},
+			options:          []Option{WithLocationCache()},
+			responseStatus:   http.StatusOK,
+			responseBody:     propertyBody,
+			calls:            2,
+			expectedRequests: 1,
+			expectedResponse: &PropertyLocation{ContractID: "ctr_1-1TJZFW", GroupID: "grp_15225"},
+		},
+		"default contract and group not applied": {
+			request:          ResolvePropertyLocationRequest{PropertyID: "prp_175780"},
+			options:          []Option{WithDefaultContract("ctr_2-3CV382"), WithDefaultGroup("grp_27182")},
+			responseStatus:   http.StatusOK,
+			responseBody:     propertyBody,
+			calls:            1,
+			expectedRequests: 1,
+			expectedResponse: &PropertyLocation{ContractID: "ctr_1-1TJZFW", GroupID: "grp_15225"},
+		},
+		"missing property": {
+			request:          ResolvePropertyLocationRequest{PropertyID: "prp_175780"},
+			responseStatus:   http.StatusOK,
+			responseBody:     `{"properties": {"items": []}}`,
+			calls:            1,
+			expectedRequests: 1,
+			withError:        ErrNotFound,
+		},
+		"404 not found": {
+			request:        ResolvePropertyLocationRequest{PropertyID: "prp_175780"},
+			responseStatus: http.StatusNotFound,
+			responseBody: `
+{
+	"type": "not_found",
+	"title": "Not Found",
+	"detail": "The system was unable to locate the requested resource",
+	"status": 404
+}`,
+			calls:            1,
+			expectedRequests: 1,
+			withError: &Error{
+				Type:       "not_found",
+				Title:      "Not Found",
+				Detail:     "The system was unable to locate the requested resource",
+				StatusCode: http.StatusNotFound,
+			},
+		},
+		"validation error": {
+			request:   ResolvePropertyLocationRequest{},
+			calls:     1,
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var requests int
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				assert.Equal(t, "/papi/v1/properties/prp_175780", r.URL.String())
+				assert.Equal(t, http.MethodGet, r.Method)
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			defer mockServer.Close()
+			client := mockAPIClient(t, mockServer, test.options...)
+			for i := 0; i < test.calls; i++ {
+				result, err := client.ResolvePropertyLocation(context.Background(), test.request)
+				if test.withError != nil {
+					assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+					continue
+				}
+				require.NoError(t, err)
+				assert.Equal(t, test.expectedResponse, result)
+			}
+			assert.Equal(t, test.expectedRequests, requests)
+		})
+	}
+}
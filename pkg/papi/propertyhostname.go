@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 )
@@ -131,6 +132,9 @@ var (
 )
 
 func (p *papi) GetPropertyVersionHostnames(ctx context.Context, params GetPropertyVersionHostnamesRequest) (*GetPropertyVersionHostnamesResponse, error) {
+	if err := p.applyDefaultLocation(&params.ContractID, &params.GroupID); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrGetPropertyVersionHostnames, ErrStructValidation, err)
+	}
 	if err := params.Validate(); err != nil {
 		return nil, fmt.Errorf("%s: %w: %s", ErrGetPropertyVersionHostnames, ErrStructValidation, err)
 	}
@@ -140,7 +144,7 @@ func (p *papi) GetPropertyVersionHostnames(ctx context.Context, params GetProper
 
 	getURL := fmt.Sprintf(
 		"/papi/v1/properties/%s/versions/%d/hostnames?contractId=%s&groupId=%s&validateHostnames=%t&includeCertStatus=%t",
-		params.PropertyID,
+		url.PathEscape(params.PropertyID),
 		params.PropertyVersion,
 		params.ContractID,
 		params.GroupID,
@@ -174,7 +178,7 @@ func (p *papi) UpdatePropertyVersionHostnames(ctx context.Context, params Update
 
 	putURL := fmt.Sprintf(
 		"/papi/v1/properties/%s/versions/%v/hostnames?contractId=%s&groupId=%s&validateHostnames=%t&includeCertStatus=%t",
-		params.PropertyID,
+		url.PathEscape(params.PropertyID),
 		params.PropertyVersion,
 		params.ContractID,
 		params.GroupID,
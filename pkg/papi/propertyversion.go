@@ -5,9 +5,14 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
+	"regexp"
 	"strconv"
+	"time"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/edgegriderr"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/poll"
+	"github.com/apex/log"
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 )
 
@@ -27,10 +32,27 @@ type (
 		// See: https://developer.akamai.com/api/core_features/property_manager/v1.html#postpropertyversions
 		CreatePropertyVersion(context.Context, CreatePropertyVersionRequest) (*CreatePropertyVersionResponse, error)
 
+		// CreatePropertyVersionAndGet creates a new property version and fetches its details
+		CreatePropertyVersionAndGet(context.Context, CreatePropertyVersionRequest) (*GetPropertyVersionsResponse, error)
+
+		// GetPropertyVersionRange concurrently fetches property versions From through To, e.g. for release notes
+		GetPropertyVersionRange(context.Context, GetPropertyVersionRangeRequest) (*GetPropertyVersionRangeResponse, error)
+
 		// GetLatestVersion fetches latest property version
 		// See: https://developer.akamai.com/api/core_features/property_manager/v1.html#getlatestversion
 		GetLatestVersion(context.Context, GetLatestVersionRequest) (*GetPropertyVersionsResponse, error)
 
+		// GetActiveVersionOnNetwork fetches the property version currently active on the given network
+		GetActiveVersionOnNetwork(context.Context, GetActiveVersionOnNetworkRequest) (*PropertyVersionGetItem, error)
+
+		// WaitForPropertyVersionStatus polls the property version until its status on the network reaches the expected one
+		// or the context is done, e.g. to follow a version from PENDING to ACTIVE without tracking the activation
+		WaitForPropertyVersionStatus(context.Context, WaitForPropertyVersionStatusRequest) (*GetPropertyVersionsResponse, error)
+
+		// CanEditPropertyVersion fetches the property version and reports whether its rule tree and hostnames can be modified,
+		// which is only the case if it has never been activated on either network
+		CanEditPropertyVersion(context.Context, GetPropertyVersionRequest) (*CanEditPropertyVersionResponse, error)
+
 		// GetAvailableBehaviors fetches a list of behaviors applied to property version
 		// See: https://developer.akamai.com/api/core_features/property_manager/v1.html#getavailablebehaviors
 		GetAvailableBehaviors(context.Context, GetFeaturesRequest) (*GetFeaturesCriteriaResponse, error)
@@ -61,6 +83,18 @@ type (
 		Version      PropertyVersionGetItem
 	}
 
+	// CanEditPropertyVersionResponse tells whether the property version can be edited, and if not, why
+	CanEditPropertyVersionResponse struct {
+		Editable bool
+		Reason   string
+	}
+
+	// ActiveVersions are the property versions active on each network, 0 if no version is active on it
+	ActiveVersions struct {
+		Staging    int
+		Production int
+	}
+
 	// PropertyVersionItems contains collection of property version details
 	PropertyVersionItems struct {
 		Items []PropertyVersionGetItem `json:"items"`
@@ -87,6 +121,25 @@ type (
 		GroupID         string
 	}
 
+	// GetPropertyVersionRangeRequest contains path and query params used for fetching a range of property versions
+	GetPropertyVersionRangeRequest struct {
+		PropertyID string
+		ContractID string
+		GroupID    string
+		From       int
+		To         int
+		// MaxConcurrency limits the number of versions fetched at the same time, DefaultVersionRangeConcurrency is used if not set
+		MaxConcurrency int
+	}
+
+	// GetPropertyVersionRangeResponse contains the property versions fetched by GetPropertyVersionRange, ordered by version number
+	// Versions which could not be fetched are omitted from Versions and their errors are stored in Errors, with the version number as ID.
+	// Errors is nil if every version was fetched
+	GetPropertyVersionRangeResponse struct {
+		Versions []PropertyVersionGetItem
+		Errors   *BulkError
+	}
+
 	// CreatePropertyVersionRequest contains path and query params, as well as request body required to execute POST /versions request
 	CreatePropertyVersionRequest struct {
 		PropertyID string
@@ -97,13 +150,16 @@ type (
 
 	// PropertyVersionCreate contains request body used in POST /versions request
 	PropertyVersionCreate struct {
-		CreateFromVersion     int    `json:"createFromVersion"`
+		CreateFromVersion int `json:"createFromVersion"`
+		// CreateFromVersionEtag, if set, is the etag of the version to create from, as returned in the Etag field
+		// of the property version or rule tree, e.g. "a9dfe78cf93090516bde891d009eaf57". It is sent as is,
+		// so it has to be at most 128 printable characters without whitespace or quotes
 		CreateFromVersionEtag string `json:"createFromVersionEtag,omitempty"`
 	}
 
 	// CreatePropertyVersionResponse contains a link returned after creating new property version and version number of this version
 	CreatePropertyVersionResponse struct {
-		VersionLink     string `json:"versionLink"`
+		VersionLink     Link `json:"versionLink"`
 		PropertyVersion int
 	}
 
@@ -115,6 +171,27 @@ type (
 		GroupID     string
 	}
 
+	// GetActiveVersionOnNetworkRequest contains path and query params used for fetching the version active on a network
+	GetActiveVersionOnNetworkRequest struct {
+		PropertyID string
+		ContractID string
+		GroupID    string
+		Network    ActivationNetwork
+	}
+
+	// WaitForPropertyVersionStatusRequest is the request for waiting until a property version reaches a status on a network
+	WaitForPropertyVersionStatusRequest struct {
+		PropertyID      string
+		PropertyVersion int
+		ContractID      string
+		GroupID         string
+		Network         ActivationNetwork
+		Status          VersionStatus
+
+		// PollInterval is the time between status checks, DefaultActivationPollInterval is used if it is not set
+		PollInterval time.Duration
+	}
+
 	// GetFeaturesRequest contains path and query params required to fetch both available behaviors and available criteria for a property
 	GetFeaturesRequest struct {
 		PropertyID      string
@@ -136,6 +213,7 @@ type (
 		ProductID          string                `json:"productId"`
 		RuleFormat         string                `json:"ruleFormat"`
 		AvailableBehaviors AvailableFeatureItems `json:"availableBehaviors"`
+		AvailableCriteria  AvailableFeatureItems `json:"availableCriteria"`
 	}
 
 	// AvailableFeatureItems contains a slice of AvailableFeature items
@@ -147,6 +225,17 @@ type (
 	VersionStatus string
 )
 
+// maxEtagLength is the maximum length of an etag accepted by PropertyVersionCreate validation
+const maxEtagLength = 128
+
+// validEtag matches etags made of printable ASCII characters other than whitespace and quotes
+var validEtag = regexp.MustCompile(`^[!#-~]+$`)
+
+const (
+	// DefaultVersionRangeConcurrency is the default number of versions fetched at the same time by GetPropertyVersionRange
+	DefaultVersionRangeConcurrency = 5
+)
+
 const (
 	// VersionStatusActive const
 	VersionStatusActive VersionStatus = "ACTIVE"
@@ -177,6 +266,36 @@ func (v GetPropertyVersionRequest) Validate() error {
 	}.Filter()
 }
 
+// Validate validates GetPropertyVersionRangeRequest
+func (v GetPropertyVersionRangeRequest) Validate() error {
+	return validation.Errors{
+		"PropertyID":     validation.Validate(v.PropertyID, validation.Required),
+		"From":           validation.Validate(v.From, validation.Required, validation.Min(1)),
+		"To":             validation.Validate(v.To, validation.Required, validation.Min(v.From)),
+		"MaxConcurrency": validation.Validate(v.MaxConcurrency, validation.Min(0)),
+	}.Filter()
+}
+
+// Validate validates GetActiveVersionOnNetworkRequest
+func (v GetActiveVersionOnNetworkRequest) Validate() error {
+	return validation.Errors{
+		"PropertyID": validation.Validate(v.PropertyID, validation.Required),
+		"Network":    validation.Validate(v.Network, validation.Required, validation.In(ActivationNetworkStaging, ActivationNetworkProduction)),
+	}.Filter()
+}
+
+// Validate validates WaitForPropertyVersionStatusRequest
+func (v WaitForPropertyVersionStatusRequest) Validate() error {
+	return validation.Errors{
+		"PropertyID":      validation.Validate(v.PropertyID, validation.Required),
+		"PropertyVersion": validation.Validate(v.PropertyVersion, validation.Required),
+		"Network":         validation.Validate(v.Network, validation.Required, validation.In(ActivationNetworkStaging, ActivationNetworkProduction)),
+		"Status": validation.Validate(v.Status, validation.Required,
+			validation.In(VersionStatusActive, VersionStatusInactive, VersionStatusPending, VersionStatusDeactivated)),
+		"PollInterval": validation.Validate(v.PollInterval, validation.Min(time.Duration(0))),
+	}.Filter()
+}
+
 // Validate validates CreatePropertyVersionRequest
 func (v CreatePropertyVersionRequest) Validate() error {
 	errs := validation.Errors{
@@ -186,10 +305,22 @@ func (v CreatePropertyVersionRequest) Validate() error {
 	return edgegriderr.ParseValidationErrors(errs)
 }
 
+// validateWithLocation validates CreatePropertyVersionRequest, requiring ContractID and GroupID as well
+func (v CreatePropertyVersionRequest) validateWithLocation() error {
+	errs := validation.Errors{
+		"PropertyID": validation.Validate(v.PropertyID, validation.Required),
+		"ContractID": validation.Validate(v.ContractID, validation.Required),
+		"GroupID":    validation.Validate(v.GroupID, validation.Required),
+		"Version":    validation.Validate(v.Version),
+	}
+	return edgegriderr.ParseValidationErrors(errs)
+}
+
 // Validate validates PropertyVersionCreate
 func (v PropertyVersionCreate) Validate() error {
 	return validation.Errors{
-		"CreateFromVersion": validation.Validate(v.CreateFromVersion, validation.Required),
+		"CreateFromVersion":     validation.Validate(v.CreateFromVersion, validation.Required),
+		"CreateFromVersionEtag": validation.Validate(v.CreateFromVersionEtag, validation.Length(0, maxEtagLength), validation.Match(validEtag)),
 	}.Filter()
 }
 
@@ -218,34 +349,123 @@ var (
 	ErrGetLatestVersion = errors.New("fetching latest property version")
 	// ErrCreatePropertyVersion represents error when creating property version fails
 	ErrCreatePropertyVersion = errors.New("creating property version")
+	// ErrCreatePropertyVersionAndGet represents error when creating and fetching property version fails
+	ErrCreatePropertyVersionAndGet = errors.New("creating and fetching property version")
+	// ErrGetPropertyVersionRange represents error when fetching a range of property versions fails
+	ErrGetPropertyVersionRange = errors.New("fetching property version range")
+	// ErrGetActiveVersionOnNetwork represents error when fetching the property version active on a network fails
+	ErrGetActiveVersionOnNetwork = errors.New("fetching property version active on network")
+	// ErrWaitForPropertyVersionStatus represents error when waiting for property version status fails
+	ErrWaitForPropertyVersionStatus = errors.New("waiting for property version status")
+	// ErrCanEditPropertyVersion represents error when checking whether property version can be edited fails
+	ErrCanEditPropertyVersion = errors.New("checking whether property version can be edited")
 	// ErrGetAvailableBehaviors represents error when fetching available behaviors fails
 	ErrGetAvailableBehaviors = errors.New("fetching available behaviors")
 	// ErrGetAvailableCriteria represents error when fetching available criteria fails
 	ErrGetAvailableCriteria = errors.New("fetching available criteria")
 )
 
+// Find returns the feature with the given name
+func (i AvailableFeatureItems) Find(name string) (AvailableFeature, bool) {
+	for _, feature := range i.Items {
+		if feature.Name == name {
+			return feature, true
+		}
+	}
+	return AvailableFeature{}, false
+}
+
+// FindBehavior returns the available behavior with the given name, see AvailableFeatureItems.Find
+func (r *GetFeaturesCriteriaResponse) FindBehavior(name string) (AvailableFeature, bool) {
+	return r.AvailableBehaviors.Find(name)
+}
+
+// FindCriteria returns the available criteria with the given name, see AvailableFeatureItems.Find
+func (r *GetFeaturesCriteriaResponse) FindCriteria(name string) (AvailableFeature, bool) {
+	return r.AvailableCriteria.Find(name)
+}
+
+// ActiveVersions returns the versions which are active on the staging and production networks, based on their statuses
+func (r GetPropertyVersionsResponse) ActiveVersions() ActiveVersions {
+	var active ActiveVersions
+	for _, version := range r.Versions.Items {
+		if version.StagingStatus == VersionStatusActive {
+			active.Staging = version.PropertyVersion
+		}
+		if version.ProductionStatus == VersionStatusActive {
+			active.Production = version.PropertyVersion
+		}
+	}
+	return active
+}
+
+// StuckPendingVersions returns the versions which are PENDING on any network and were last updated more than maxAge before now,
+// e.g. to alert on activations which do not progress. Versions with an UpdatedDate which is not in RFC 3339 format are skipped
+func (r GetPropertyVersionsResponse) StuckPendingVersions(maxAge time.Duration, now time.Time) []PropertyVersionGetItem {
+	var stuck []PropertyVersionGetItem
+	for _, version := range r.Versions.Items {
+		if version.StagingStatus != VersionStatusPending && version.ProductionStatus != VersionStatusPending {
+			continue
+		}
+		updated, err := time.Parse(time.RFC3339, version.UpdatedDate)
+		if err != nil || now.Sub(updated) <= maxAge {
+			continue
+		}
+		stuck = append(stuck, version)
+	}
+	return stuck
+}
+
+// Editable reports whether the version can be edited, which requires it to be INACTIVE on both networks.
+// Versions which are, or have been, activated on any network are read-only, the reason tells which network prevents the edit
+func (v PropertyVersionGetItem) Editable() (bool, string) {
+	if v.StagingStatus != VersionStatusInactive {
+		return false, fmt.Sprintf("version %d is %s on %s", v.PropertyVersion, v.StagingStatus, ActivationNetworkStaging)
+	}
+	if v.ProductionStatus != VersionStatusInactive {
+		return false, fmt.Sprintf("version %d is %s on %s", v.PropertyVersion, v.ProductionStatus, ActivationNetworkProduction)
+	}
+	return true, ""
+}
+
 // GetPropertyVersions returns list of property versions for give propertyID, contractID and groupID
 func (p *papi) GetPropertyVersions(ctx context.Context, params GetPropertyVersionsRequest) (*GetPropertyVersionsResponse, error) {
+	if err := p.applyDefaultLocation(&params.ContractID, &params.GroupID); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrGetPropertyVersions, ErrStructValidation, err)
+	}
 	if err := params.Validate(); err != nil {
 		return nil, fmt.Errorf("%s: %w: %s", ErrGetPropertyVersions, ErrStructValidation, err)
 	}
 
-	logger := p.Log(ctx)
+	logger := p.Log(ctx).WithFields(log.Fields{
+		"property_id": params.PropertyID,
+	})
 	logger.Debug("GetPropertyVersions")
 
-	getURL := fmt.Sprintf(
-		"/papi/v1/properties/%s/versions?contractId=%s&groupId=%s",
-		params.PropertyID,
-		params.ContractID,
-		params.GroupID,
+	uri, err := url.Parse(fmt.Sprintf(
+		"/papi/v1/properties/%s/versions",
+		url.PathEscape(params.PropertyID)),
 	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to parse url: %s", ErrGetPropertyVersions, err)
+	}
+	// contract and group are optional, the API infers them if the account is unambiguous
+	q := uri.Query()
+	if params.ContractID != "" {
+		q.Add("contractId", params.ContractID)
+	}
+	if params.GroupID != "" {
+		q.Add("groupId", params.GroupID)
+	}
 	if params.Limit != 0 {
-		getURL += fmt.Sprintf("&limit=%d", params.Limit)
+		q.Add("limit", strconv.Itoa(params.Limit))
 	}
 	if params.Offset != 0 {
-		getURL += fmt.Sprintf("&offset=%d", params.Offset)
+		q.Add("offset", strconv.Itoa(params.Offset))
 	}
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, getURL, nil)
+	uri.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, uri.String(), nil)
 	if err != nil {
 		return nil, fmt.Errorf("%w: failed to create request: %s", ErrGetPropertyVersions, err)
 	}
@@ -265,16 +485,21 @@ func (p *papi) GetPropertyVersions(ctx context.Context, params GetPropertyVersio
 
 // GetLatestVersion returns either the latest property version overall, or the latest ACTIVE version on production or staging network
 func (p *papi) GetLatestVersion(ctx context.Context, params GetLatestVersionRequest) (*GetPropertyVersionsResponse, error) {
+	if err := p.applyDefaultLocation(&params.ContractID, &params.GroupID); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrGetLatestVersion, ErrStructValidation, err)
+	}
 	if err := params.Validate(); err != nil {
 		return nil, fmt.Errorf("%s: %w: %s", ErrGetLatestVersion, ErrStructValidation, err)
 	}
 
-	logger := p.Log(ctx)
+	logger := p.Log(ctx).WithFields(log.Fields{
+		"property_id": params.PropertyID,
+	})
 	logger.Debug("GetLatestVersion")
 
 	getURL := fmt.Sprintf(
 		"/papi/v1/properties/%s/versions/latest?contractId=%s&groupId=%s",
-		params.PropertyID,
+		url.PathEscape(params.PropertyID),
 		params.ContractID,
 		params.GroupID,
 	)
@@ -304,16 +529,22 @@ func (p *papi) GetLatestVersion(ctx context.Context, params GetLatestVersionRequ
 
 // GetPropertyVersion returns property version with provided version number
 func (p *papi) GetPropertyVersion(ctx context.Context, params GetPropertyVersionRequest) (*GetPropertyVersionsResponse, error) {
+	if err := p.applyDefaultLocation(&params.ContractID, &params.GroupID); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrGetPropertyVersion, ErrStructValidation, err)
+	}
 	if err := params.Validate(); err != nil {
 		return nil, fmt.Errorf("%s: %w: %s", ErrGetPropertyVersion, ErrStructValidation, err)
 	}
 
-	logger := p.Log(ctx)
+	logger := p.Log(ctx).WithFields(log.Fields{
+		"property_id": params.PropertyID,
+		"version":     params.PropertyVersion,
+	})
 	logger.Debug("GetPropertyVersion")
 
 	getURL := fmt.Sprintf(
 		"/papi/v1/properties/%s/versions/%d?contractId=%s&groupId=%s",
-		params.PropertyID,
+		url.PathEscape(params.PropertyID),
 		params.PropertyVersion,
 		params.ContractID,
 		params.GroupID,
@@ -345,12 +576,14 @@ func (p *papi) CreatePropertyVersion(ctx context.Context, request CreateProperty
 		return nil, fmt.Errorf("%s: %w:\n%s", ErrCreatePropertyVersion, ErrStructValidation, err)
 	}
 
-	logger := p.Log(ctx)
+	logger := p.Log(ctx).WithFields(log.Fields{
+		"property_id": request.PropertyID,
+	})
 	logger.Debug("CreatePropertyVersion")
 
 	getURL := fmt.Sprintf(
 		"/papi/v1/properties/%s/versions?contractId=%s&groupId=%s",
-		request.PropertyID,
+		url.PathEscape(request.PropertyID),
 		request.ContractID,
 		request.GroupID,
 	)
@@ -368,9 +601,9 @@ func (p *papi) CreatePropertyVersion(ctx context.Context, request CreateProperty
 	if resp.StatusCode != http.StatusCreated {
 		return nil, fmt.Errorf("%s: %w", ErrCreatePropertyVersion, p.Error(resp))
 	}
-	propertyVersion, err := ResponseLinkParse(version.VersionLink)
+	propertyVersion, err := version.VersionLink.ID()
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w: %s", ErrCreatePropertyVersion, ErrInvalidResponseLink, err)
+		return nil, fmt.Errorf("%s: %w", ErrCreatePropertyVersion, err)
 	}
 	versionNumber, err := strconv.Atoi(propertyVersion)
 	if err != nil {
@@ -380,18 +613,204 @@ func (p *papi) CreatePropertyVersion(ctx context.Context, request CreateProperty
 	return &version, nil
 }
 
+// CreatePropertyVersionAndGet creates a new property version and returns its details, fetched using the version from the returned link
+func (p *papi) CreatePropertyVersionAndGet(ctx context.Context, request CreatePropertyVersionRequest) (*GetPropertyVersionsResponse, error) {
+	if err := request.validateWithLocation(); err != nil {
+		return nil, fmt.Errorf("%s: %w:\n%s", ErrCreatePropertyVersionAndGet, ErrStructValidation, err)
+	}
+
+	logger := p.Log(ctx).WithFields(log.Fields{
+		"property_id": request.PropertyID,
+	})
+	logger.Debug("CreatePropertyVersionAndGet")
+
+	created, err := p.CreatePropertyVersion(ctx, request)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ErrCreatePropertyVersionAndGet, err)
+	}
+
+	version, err := p.GetPropertyVersion(ctx, GetPropertyVersionRequest{
+		PropertyID:      request.PropertyID,
+		PropertyVersion: created.PropertyVersion,
+		ContractID:      request.ContractID,
+		GroupID:         request.GroupID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ErrCreatePropertyVersionAndGet, err)
+	}
+
+	return version, nil
+}
+
+// GetPropertyVersionRange fetches property versions From through To, with a bounded number of concurrent requests
+func (p *papi) GetPropertyVersionRange(ctx context.Context, params GetPropertyVersionRangeRequest) (*GetPropertyVersionRangeResponse, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrGetPropertyVersionRange, ErrStructValidation, err)
+	}
+
+	logger := p.Log(ctx).WithFields(log.Fields{
+		"property_id": params.PropertyID,
+	})
+	logger.Debug("GetPropertyVersionRange")
+
+	concurrency := params.MaxConcurrency
+	if concurrency == 0 {
+		concurrency = DefaultVersionRangeConcurrency
+	}
+
+	count := params.To - params.From + 1
+	ids := make([]string, count)
+	for i := range ids {
+		ids[i] = strconv.Itoa(params.From + i)
+	}
+	versions := make([]*PropertyVersionGetItem, count)
+	bulkErr, err := forEachConcurrently(ctx, ids, concurrency, func(i int) error {
+		version, err := p.GetPropertyVersion(ctx, GetPropertyVersionRequest{
+			PropertyID:      params.PropertyID,
+			PropertyVersion: params.From + i,
+			ContractID:      params.ContractID,
+			GroupID:         params.GroupID,
+		})
+		if err != nil {
+			return err
+		}
+		versions[i] = &version.Version
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ErrGetPropertyVersionRange, err)
+	}
+
+	result := GetPropertyVersionRangeResponse{
+		Versions: make([]PropertyVersionGetItem, 0, count),
+	}
+	for i := 0; i < count; i++ {
+		if versions[i] != nil {
+			result.Versions = append(result.Versions, *versions[i])
+		}
+	}
+	if !bulkErr.AllSucceeded() {
+		result.Errors = bulkErr
+	}
+	return &result, nil
+}
+
+func (p *papi) GetActiveVersionOnNetwork(ctx context.Context, params GetActiveVersionOnNetworkRequest) (*PropertyVersionGetItem, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrGetActiveVersionOnNetwork, ErrStructValidation, err)
+	}
+
+	logger := p.Log(ctx).WithFields(log.Fields{
+		"property_id": params.PropertyID,
+		"network":     params.Network,
+	})
+	logger.Debug("GetActiveVersionOnNetwork")
+
+	versions, err := p.GetPropertyVersions(ctx, GetPropertyVersionsRequest{
+		PropertyID: params.PropertyID,
+		ContractID: params.ContractID,
+		GroupID:    params.GroupID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ErrGetActiveVersionOnNetwork, err)
+	}
+
+	for _, version := range versions.Versions.Items {
+		status := version.StagingStatus
+		if params.Network == ActivationNetworkProduction {
+			status = version.ProductionStatus
+		}
+		if status == VersionStatusActive {
+			return &version, nil
+		}
+	}
+	return nil, fmt.Errorf("%s: %w: no version of %s is active on %s", ErrGetActiveVersionOnNetwork, ErrNotFound, params.PropertyID, params.Network)
+}
+
+func (p *papi) WaitForPropertyVersionStatus(ctx context.Context, params WaitForPropertyVersionStatusRequest) (*GetPropertyVersionsResponse, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrWaitForPropertyVersionStatus, ErrStructValidation, err)
+	}
+
+	logger := p.Log(ctx).WithFields(log.Fields{
+		"property_id": params.PropertyID,
+		"version":     params.PropertyVersion,
+		"network":     params.Network,
+	})
+	logger.Debug("WaitForPropertyVersionStatus")
+
+	interval := params.PollInterval
+	if interval == 0 {
+		interval = DefaultActivationPollInterval
+	}
+
+	var version *GetPropertyVersionsResponse
+	checkStatus := func(ctx context.Context) (bool, error) {
+		var err error
+		version, err = p.GetPropertyVersion(ctx, GetPropertyVersionRequest{
+			PropertyID:      params.PropertyID,
+			PropertyVersion: params.PropertyVersion,
+			ContractID:      params.ContractID,
+			GroupID:         params.GroupID,
+		})
+		if err != nil {
+			return false, err
+		}
+		status := version.Version.StagingStatus
+		if params.Network == ActivationNetworkProduction {
+			status = version.Version.ProductionStatus
+		}
+		if status != params.Status {
+			logger.Debugf("version %d is %s on %s, checking again in %s", params.PropertyVersion, status, params.Network, interval)
+			return false, nil
+		}
+		return true, nil
+	}
+
+	if err := poll.Until(ctx, checkStatus, poll.WithClock(p.clock), poll.WithInterval(interval)); err != nil {
+		return nil, fmt.Errorf("%s: %w", ErrWaitForPropertyVersionStatus, err)
+	}
+	return version, nil
+}
+
+func (p *papi) CanEditPropertyVersion(ctx context.Context, params GetPropertyVersionRequest) (*CanEditPropertyVersionResponse, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrCanEditPropertyVersion, ErrStructValidation, err)
+	}
+
+	logger := p.Log(ctx).WithFields(log.Fields{
+		"property_id": params.PropertyID,
+		"version":     params.PropertyVersion,
+	})
+	logger.Debug("CanEditPropertyVersion")
+
+	version, err := p.GetPropertyVersion(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ErrCanEditPropertyVersion, err)
+	}
+
+	editable, reason := version.Version.Editable()
+	return &CanEditPropertyVersionResponse{Editable: editable, Reason: reason}, nil
+}
+
 // GetAvailableBehaviors lists available behaviors for given property version
 func (p *papi) GetAvailableBehaviors(ctx context.Context, params GetFeaturesRequest) (*GetFeaturesCriteriaResponse, error) {
+	if err := p.applyDefaultLocation(&params.ContractID, &params.GroupID); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrGetAvailableBehaviors, ErrStructValidation, err)
+	}
 	if err := params.Validate(); err != nil {
 		return nil, fmt.Errorf("%s: %w: %s", ErrGetAvailableBehaviors, ErrStructValidation, err)
 	}
 
-	logger := p.Log(ctx)
+	logger := p.Log(ctx).WithFields(log.Fields{
+		"property_id": params.PropertyID,
+		"version":     params.PropertyVersion,
+	})
 	logger.Debug("GetAvailableBehaviors")
 
 	getURL := fmt.Sprintf(
 		"/papi/v1/properties/%s/versions/%d/available-behaviors?contractId=%s&groupId=%s",
-		params.PropertyID,
+		url.PathEscape(params.PropertyID),
 		params.PropertyVersion,
 		params.ContractID,
 		params.GroupID,
@@ -416,16 +835,22 @@ func (p *papi) GetAvailableBehaviors(ctx context.Context, params GetFeaturesRequ
 
 // GetAvailableCriteria lists available criteria for given property version
 func (p *papi) GetAvailableCriteria(ctx context.Context, params GetFeaturesRequest) (*GetFeaturesCriteriaResponse, error) {
+	if err := p.applyDefaultLocation(&params.ContractID, &params.GroupID); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrGetAvailableCriteria, ErrStructValidation, err)
+	}
 	if err := params.Validate(); err != nil {
 		return nil, fmt.Errorf("%s: %w: %s", ErrGetAvailableCriteria, ErrStructValidation, err)
 	}
 
-	logger := p.Log(ctx)
+	logger := p.Log(ctx).WithFields(log.Fields{
+		"property_id": params.PropertyID,
+		"version":     params.PropertyVersion,
+	})
 	logger.Debug("GetAvailableCriteria")
 
 	getURL := fmt.Sprintf(
 		"/papi/v1/properties/%s/versions/%d/available-criteria?contractId=%s&groupId=%s",
-		params.PropertyID,
+		url.PathEscape(params.PropertyID),
 		params.PropertyVersion,
 		params.ContractID,
 		params.GroupID,
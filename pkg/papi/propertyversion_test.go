@@ -2,11 +2,20 @@ package papi
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/poll"
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/session"
+	"github.com/apex/log"
+	"github.com/apex/log/handlers/memory"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -101,6 +110,32 @@ func TestPapi_GetPropertyVersions(t *testing.T) {
 				assert.True(t, errors.Is(err, want), "want: %s; got: %s", want, err)
 			},
 		},
+		"contract and group omitted when empty": {
+			params: GetPropertyVersionsRequest{
+				PropertyID: "propertyID",
+			},
+			responseStatus: http.StatusOK,
+			responseBody:   `{"propertyId": "propertyID", "versions": {"items": []}}`,
+			expectedPath:   "/papi/v1/properties/propertyID/versions",
+			expectedResponse: &GetPropertyVersionsResponse{
+				PropertyID: "propertyID",
+				Versions:   PropertyVersionItems{Items: []PropertyVersionGetItem{}},
+			},
+		},
+		"group omitted when empty": {
+			params: GetPropertyVersionsRequest{
+				PropertyID: "propertyID",
+				ContractID: "contract",
+				Limit:      5,
+			},
+			responseStatus: http.StatusOK,
+			responseBody:   `{"propertyId": "propertyID", "versions": {"items": []}}`,
+			expectedPath:   "/papi/v1/properties/propertyID/versions?contractId=contract&limit=5",
+			expectedResponse: &GetPropertyVersionsResponse{
+				PropertyID: "propertyID",
+				Versions:   PropertyVersionItems{Items: []PropertyVersionGetItem{}},
+			},
+		},
 		"empty property ID": {
 			params: GetPropertyVersionsRequest{
 				PropertyID: "",
@@ -394,6 +429,59 @@ func TestPapi_CreatePropertyVersion(t *testing.T) {
 				assert.Contains(t, err.Error(), "CreateFromVersion")
 			},
 		},
+		"201 Created from etag": {
+			params: CreatePropertyVersionRequest{
+				PropertyID: "propertyID",
+				ContractID: "contract",
+				GroupID:    "group",
+				Version: PropertyVersionCreate{
+					CreateFromVersion:     1,
+					CreateFromVersionEtag: "a9dfe78cf93090516bde891d009eaf57",
+				},
+			},
+			responseStatus: http.StatusCreated,
+			responseBody: `
+{
+    "versionLink": "/papi/v1/properties/propertyID/versions/2?contractId=contract&groupId=group"
+}`,
+			expectedPath: "/papi/v1/properties/propertyID/versions?contractId=contract&groupId=group",
+			expectedResponse: &CreatePropertyVersionResponse{
+				VersionLink:     "/papi/v1/properties/propertyID/versions/2?contractId=contract&groupId=group",
+				PropertyVersion: 2,
+			},
+		},
+		"malformed CreateFromVersionEtag": {
+			params: CreatePropertyVersionRequest{
+				PropertyID: "propertyID",
+				ContractID: "contract",
+				GroupID:    "group",
+				Version: PropertyVersionCreate{
+					CreateFromVersion:     1,
+					CreateFromVersionEtag: "\"a9dfe78c f93090516\"\n",
+				},
+			},
+			withError: func(t *testing.T, err error) {
+				want := ErrStructValidation
+				assert.True(t, errors.Is(err, want), "want: %s; got: %s", want, err)
+				assert.Contains(t, err.Error(), "CreateFromVersionEtag")
+			},
+		},
+		"too long CreateFromVersionEtag": {
+			params: CreatePropertyVersionRequest{
+				PropertyID: "propertyID",
+				ContractID: "contract",
+				GroupID:    "group",
+				Version: PropertyVersionCreate{
+					CreateFromVersion:     1,
+					CreateFromVersionEtag: strings.Repeat("a", 129),
+				},
+			},
+			withError: func(t *testing.T, err error) {
+				want := ErrStructValidation
+				assert.True(t, errors.Is(err, want), "want: %s; got: %s", want, err)
+				assert.Contains(t, err.Error(), "CreateFromVersionEtag")
+			},
+		},
 		"invalid location": {
 			params: CreatePropertyVersionRequest{
 				PropertyID: "propertyID",
@@ -870,3 +958,875 @@ func TestPapi_GetAvailableCriteria(t *testing.T) {
 		})
 	}
 }
+
+func TestPapi_CreatePropertyVersionAndGet(t *testing.T) {
+	tests := map[string]struct {
+		params           CreatePropertyVersionRequest
+		createStatus     int
+		createBody       string
+		getStatus        int
+		getBody          string
+		expectedGetPath  string
+		expectedResponse *GetPropertyVersionsResponse
+		withError        func(*testing.T, error)
+	}{
+		"201 Created, 200 OK": {
+			params: CreatePropertyVersionRequest{
+				PropertyID: "propertyID",
+				ContractID: "contract",
+				GroupID:    "group",
+				Version: PropertyVersionCreate{
+					CreateFromVersion: 1,
+				},
+			},
+			createStatus: http.StatusCreated,
+			createBody: `
+{
+    "versionLink": "/papi/v1/properties/propertyID/versions/2?contractId=contract&groupId=group"
+}`,
+			getStatus: http.StatusOK,
+			getBody: `
+{
+    "propertyId": "propertyID",
+    "propertyName": "mytestproperty.com",
+    "accountId": "accountID",
+    "contractId": "contract",
+    "groupId": "group",
+    "assetId": "assetID",
+    "versions": {
+        "items": [
+            {
+                "propertyVersion": 2,
+                "updatedByUser": "user",
+                "updatedDate": "2020-09-14T19:06:13Z",
+                "productionStatus": "INACTIVE",
+                "stagingStatus": "INACTIVE",
+                "etag": "etag",
+                "productId": "productID",
+                "ruleFormat": "v2020-09-16"
+            }
+        ]
+    }
+}`,
+			expectedGetPath: "/papi/v1/properties/propertyID/versions/2?contractId=contract&groupId=group",
+			expectedResponse: &GetPropertyVersionsResponse{
+				PropertyID:   "propertyID",
+				PropertyName: "mytestproperty.com",
+				AccountID:    "accountID",
+				ContractID:   "contract",
+				GroupID:      "group",
+				AssetID:      "assetID",
+				Versions: PropertyVersionItems{
+					Items: []PropertyVersionGetItem{
+						{
+							Etag:             "etag",
+							ProductID:        "productID",
+							ProductionStatus: VersionStatusInactive,
+							PropertyVersion:  2,
+							RuleFormat:       "v2020-09-16",
+							StagingStatus:    VersionStatusInactive,
+							UpdatedByUser:    "user",
+							UpdatedDate:      "2020-09-14T19:06:13Z",
+						},
+					},
+				},
+				Version: PropertyVersionGetItem{
+					Etag:             "etag",
+					ProductID:        "productID",
+					ProductionStatus: VersionStatusInactive,
+					PropertyVersion:  2,
+					RuleFormat:       "v2020-09-16",
+					StagingStatus:    VersionStatusInactive,
+					UpdatedByUser:    "user",
+					UpdatedDate:      "2020-09-14T19:06:13Z",
+				},
+			},
+		},
+		"invalid version link": {
+			params: CreatePropertyVersionRequest{
+				PropertyID: "propertyID",
+				ContractID: "contract",
+				GroupID:    "group",
+				Version: PropertyVersionCreate{
+					CreateFromVersion: 1,
+				},
+			},
+			createStatus: http.StatusCreated,
+			createBody: `
+{
+    "versionLink": "/papi/v1/properties/propertyID/versions/abc?contractId=contract&groupId=group"
+}`,
+			withError: func(t *testing.T, err error) {
+				want := ErrInvalidResponseLink
+				assert.True(t, errors.Is(err, want), "want: %s; got: %s", want, err)
+			},
+		},
+		"404 Not Found on get": {
+			params: CreatePropertyVersionRequest{
+				PropertyID: "propertyID",
+				ContractID: "contract",
+				GroupID:    "group",
+				Version: PropertyVersionCreate{
+					CreateFromVersion: 1,
+				},
+			},
+			createStatus: http.StatusCreated,
+			createBody: `
+{
+    "versionLink": "/papi/v1/properties/propertyID/versions/2?contractId=contract&groupId=group"
+}`,
+			getStatus: http.StatusNotFound,
+			getBody: `
+{
+    "type": "not_found",
+    "title": "Not Found",
+    "detail": "Version not found",
+    "status": 404
+}`,
+			expectedGetPath: "/papi/v1/properties/propertyID/versions/2?contractId=contract&groupId=group",
+			withError: func(t *testing.T, err error) {
+				want := &Error{
+					Type:       "not_found",
+					Title:      "Not Found",
+					Detail:     "Version not found",
+					StatusCode: http.StatusNotFound,
+				}
+				assert.True(t, errors.Is(err, want), "want: %s; got: %s", want, err)
+			},
+		},
+		"empty contract and group ID": {
+			params: CreatePropertyVersionRequest{
+				PropertyID: "propertyID",
+				Version: PropertyVersionCreate{
+					CreateFromVersion: 1,
+				},
+			},
+			withError: func(t *testing.T, err error) {
+				want := ErrStructValidation
+				assert.True(t, errors.Is(err, want), "want: %s; got: %s", want, err)
+				assert.Contains(t, err.Error(), "ContractID")
+				assert.Contains(t, err.Error(), "GroupID")
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodPost {
+					assert.Equal(t, "/papi/v1/properties/propertyID/versions?contractId=contract&groupId=group", r.URL.String())
+					w.WriteHeader(test.createStatus)
+					_, err := w.Write([]byte(test.createBody))
+					assert.NoError(t, err)
+					return
+				}
+				assert.Equal(t, http.MethodGet, r.Method)
+				assert.Equal(t, test.expectedGetPath, r.URL.String())
+				w.WriteHeader(test.getStatus)
+				_, err := w.Write([]byte(test.getBody))
+				assert.NoError(t, err)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.CreatePropertyVersionAndGet(context.Background(), test.params)
+			if test.withError != nil {
+				test.withError(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestPapi_GetPropertyVersionRange(t *testing.T) {
+	versionBody := func(version int) string {
+		return fmt.Sprintf(`
+{
+    "propertyId": "prp_175780",
+    "propertyName": "example.com",
+    "accountId": "act_1-1TJZFB",
+    "contractId": "ctr_1-1TJZH5",
+    "groupId": "grp_15225",
+    "versions": {
+        "items": [
+            {
+                "propertyVersion": %d,
+                "updatedByUser": "jsmith",
+                "productionStatus": "INACTIVE",
+                "stagingStatus": "INACTIVE",
+                "etag": "71573b922222811ab%d",
+                "productId": "prd_Alta",
+                "ruleFormat": "v2016-11-15"
+            }
+        ]
+    }
+}`, version, version)
+	}
+	versionItem := func(version int) PropertyVersionGetItem {
+		return PropertyVersionGetItem{
+			Etag:             fmt.Sprintf("71573b922222811ab%d", version),
+			ProductID:        "prd_Alta",
+			ProductionStatus: VersionStatusInactive,
+			PropertyVersion:  version,
+			RuleFormat:       "v2016-11-15",
+			StagingStatus:    VersionStatusInactive,
+			UpdatedByUser:    "jsmith",
+		}
+	}
+
+	tests := map[string]struct {
+		params           GetPropertyVersionRangeRequest
+		missingVersions  map[int]bool
+		expectedCalls    int32
+		expectedResponse *GetPropertyVersionRangeResponse
+		expectedErrors   map[string]error
+		withError        error
+	}{
+		"all versions found": {
+			params: GetPropertyVersionRangeRequest{
+				PropertyID: "prp_175780",
+				ContractID: "ctr_1-1TJZH5",
+				GroupID:    "grp_15225",
+				From:       2,
+				To:         4,
+			},
+			expectedCalls: 3,
+			expectedResponse: &GetPropertyVersionRangeResponse{
+				Versions: []PropertyVersionGetItem{versionItem(2), versionItem(3), versionItem(4)},
+			},
+		},
+		"missing version in the middle": {
+			params: GetPropertyVersionRangeRequest{
+				PropertyID:     "prp_175780",
+				ContractID:     "ctr_1-1TJZH5",
+				GroupID:        "grp_15225",
+				From:           1,
+				To:             5,
+				MaxConcurrency: 2,
+			},
+			missingVersions: map[int]bool{3: true},
+			expectedCalls:   5,
+			expectedResponse: &GetPropertyVersionRangeResponse{
+				Versions: []PropertyVersionGetItem{versionItem(1), versionItem(2), versionItem(4), versionItem(5)},
+			},
+			expectedErrors: map[string]error{
+				"3": &Error{
+					Type:       "not_found",
+					Title:      "Not Found",
+					Detail:     "The system was unable to locate the requested resource",
+					StatusCode: http.StatusNotFound,
+				},
+			},
+		},
+		"validation error - to before from": {
+			params: GetPropertyVersionRangeRequest{
+				PropertyID: "prp_175780",
+				From:       5,
+				To:         1,
+			},
+			withError: ErrStructValidation,
+		},
+		"validation error - missing property ID": {
+			params: GetPropertyVersionRangeRequest{
+				From: 1,
+				To:   2,
+			},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var calls, inFlight, maxInFlight int32
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				atomic.AddInt32(&calls, 1)
+				current := atomic.AddInt32(&inFlight, 1)
+				defer atomic.AddInt32(&inFlight, -1)
+				for {
+					max := atomic.LoadInt32(&maxInFlight)
+					if current <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, current) {
+						break
+					}
+				}
+				// give other requests a chance to run concurrently
+				time.Sleep(10 * time.Millisecond)
+
+				assert.Equal(t, http.MethodGet, r.Method)
+				var version int
+				_, err := fmt.Sscanf(r.URL.Path, "/papi/v1/properties/prp_175780/versions/%d", &version)
+				require.NoError(t, err)
+				assert.Equal(t, "contractId=ctr_1-1TJZH5&groupId=grp_15225", r.URL.RawQuery)
+				if test.missingVersions[version] {
+					w.WriteHeader(http.StatusNotFound)
+					_, err = w.Write([]byte(`
+{
+    "type": "not_found",
+    "title": "Not Found",
+    "detail": "The system was unable to locate the requested resource",
+    "status": 404
+}`))
+					assert.NoError(t, err)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+				_, err = w.Write([]byte(versionBody(version)))
+				assert.NoError(t, err)
+			}))
+			defer mockServer.Close()
+			client := mockAPIClient(t, mockServer)
+			result, err := client.GetPropertyVersionRange(context.Background(), test.params)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedCalls, atomic.LoadInt32(&calls))
+			concurrency := int32(test.params.MaxConcurrency)
+			if concurrency == 0 {
+				concurrency = DefaultVersionRangeConcurrency
+			}
+			assert.LessOrEqual(t, atomic.LoadInt32(&maxInFlight), concurrency)
+			assert.Equal(t, test.expectedResponse.Versions, result.Versions)
+			if test.expectedErrors == nil {
+				assert.Nil(t, result.Errors)
+				return
+			}
+			require.NotNil(t, result.Errors)
+			assert.Len(t, result.Errors.Failures, len(test.expectedErrors))
+			result.Errors.Each(func(failure BulkItemError) {
+				expected := test.expectedErrors[failure.ID]
+				assert.True(t, errors.Is(failure, expected), "want: %s; got: %s", expected, failure)
+			})
+		})
+	}
+}
+
+func TestPapi_GetPropertyVersionRange_ContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cancel()
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"versions": {"items": [{"propertyVersion": 1}]}}`))
+		assert.NoError(t, err)
+	}))
+	defer mockServer.Close()
+	client := mockAPIClient(t, mockServer)
+
+	_, err := client.GetPropertyVersionRange(ctx, GetPropertyVersionRangeRequest{
+		PropertyID:     "prp_175780",
+		From:           1,
+		To:             10,
+		MaxConcurrency: 1,
+	})
+	assert.True(t, errors.Is(err, context.Canceled), "want: %s; got: %s", context.Canceled, err)
+}
+
+func TestGetPropertyVersionsResponse_ActiveVersions(t *testing.T) {
+	tests := map[string]struct {
+		responseBody string
+		expected     ActiveVersions
+	}{
+		"different versions active on each network": {
+			responseBody: `
+{
+    "propertyId": "prp_175780",
+    "versions": {
+        "items": [
+            {"propertyVersion": 4, "stagingStatus": "ACTIVE", "productionStatus": "INACTIVE"},
+            {"propertyVersion": 3, "stagingStatus": "DEACTIVATED", "productionStatus": "INACTIVE"},
+            {"propertyVersion": 2, "stagingStatus": "INACTIVE", "productionStatus": "ACTIVE"},
+            {"propertyVersion": 1, "stagingStatus": "INACTIVE", "productionStatus": "DEACTIVATED"}
+        ]
+    }
+}`,
+			expected: ActiveVersions{Staging: 4, Production: 2},
+		},
+		"same version active on both networks": {
+			responseBody: `
+{
+    "propertyId": "prp_175780",
+    "versions": {
+        "items": [
+            {"propertyVersion": 2, "stagingStatus": "PENDING", "productionStatus": "INACTIVE"},
+            {"propertyVersion": 1, "stagingStatus": "ACTIVE", "productionStatus": "ACTIVE"}
+        ]
+    }
+}`,
+			expected: ActiveVersions{Staging: 1, Production: 1},
+		},
+		"nothing active": {
+			responseBody: `
+{
+    "propertyId": "prp_175780",
+    "versions": {
+        "items": [
+            {"propertyVersion": 1, "stagingStatus": "INACTIVE", "productionStatus": "INACTIVE"}
+        ]
+    }
+}`,
+			expected: ActiveVersions{},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var res GetPropertyVersionsResponse
+			require.NoError(t, json.Unmarshal([]byte(test.responseBody), &res))
+			assert.Equal(t, test.expected, res.ActiveVersions())
+		})
+	}
+}
+
+func TestGetPropertyVersionsResponse_StuckPendingVersions(t *testing.T) {
+	responseBody := `
+{
+    "propertyId": "prp_175780",
+    "versions": {
+        "items": [
+            {"propertyVersion": 5, "stagingStatus": "PENDING", "productionStatus": "INACTIVE", "updatedDate": "2022-10-28T11:55:00Z"},
+            {"propertyVersion": 4, "stagingStatus": "ACTIVE", "productionStatus": "PENDING", "updatedDate": "2022-10-28T10:00:00Z"},
+            {"propertyVersion": 3, "stagingStatus": "PENDING", "productionStatus": "INACTIVE", "updatedDate": "2022-10-28T09:00:00Z"},
+            {"propertyVersion": 2, "stagingStatus": "PENDING", "productionStatus": "INACTIVE", "updatedDate": "not a date"},
+            {"propertyVersion": 1, "stagingStatus": "ACTIVE", "productionStatus": "ACTIVE", "updatedDate": "2022-10-01T10:00:00Z"}
+        ]
+    }
+}`
+	now := time.Date(2022, 10, 28, 12, 0, 0, 0, time.UTC)
+	tests := map[string]struct {
+		maxAge   time.Duration
+		expected []int
+	}{
+		"long pending versions": {
+			maxAge:   time.Hour,
+			expected: []int{4, 3},
+		},
+		"recently pending version": {
+			maxAge:   time.Minute,
+			expected: []int{5, 4, 3},
+		},
+		"no version pending longer than max age": {
+			maxAge: 24 * time.Hour,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var res GetPropertyVersionsResponse
+			require.NoError(t, json.Unmarshal([]byte(responseBody), &res))
+			var versions []int
+			for _, version := range res.StuckPendingVersions(test.maxAge, now) {
+				versions = append(versions, version.PropertyVersion)
+			}
+			assert.Equal(t, test.expected, versions)
+		})
+	}
+}
+
+func TestPapi_GetActiveVersionOnNetwork(t *testing.T) {
+	versionsBody := `
+{
+    "propertyId": "prp_175780",
+    "versions": {
+        "items": [
+            {
+                "propertyVersion": 4,
+                "productionStatus": "INACTIVE",
+                "stagingStatus": "ACTIVE",
+                "etag": "etag4"
+            },
+            {
+                "propertyVersion": 3,
+                "productionStatus": "ACTIVE",
+                "stagingStatus": "DEACTIVATED",
+                "etag": "etag3"
+            }
+        ]
+    }
+}`
+	tests := map[string]struct {
+		params           GetActiveVersionOnNetworkRequest
+		responseStatus   int
+		responseBody     string
+		expectedResponse *PropertyVersionGetItem
+		withError        error
+	}{
+		"active on staging": {
+			params:         GetActiveVersionOnNetworkRequest{PropertyID: "prp_175780", ContractID: "ctr_1-1TJZFW", GroupID: "grp_15166", Network: ActivationNetworkStaging},
+			responseStatus: http.StatusOK,
+			responseBody:   versionsBody,
+			expectedResponse: &PropertyVersionGetItem{
+				PropertyVersion:  4,
+				ProductionStatus: VersionStatusInactive,
+				StagingStatus:    VersionStatusActive,
+				Etag:             "etag4",
+			},
+		},
+		"active on production": {
+			params:         GetActiveVersionOnNetworkRequest{PropertyID: "prp_175780", ContractID: "ctr_1-1TJZFW", GroupID: "grp_15166", Network: ActivationNetworkProduction},
+			responseStatus: http.StatusOK,
+			responseBody:   versionsBody,
+			expectedResponse: &PropertyVersionGetItem{
+				PropertyVersion:  3,
+				ProductionStatus: VersionStatusActive,
+				StagingStatus:    VersionStatusDeactivated,
+				Etag:             "etag3",
+			},
+		},
+		"none active": {
+			params:         GetActiveVersionOnNetworkRequest{PropertyID: "prp_175780", ContractID: "ctr_1-1TJZFW", GroupID: "grp_15166", Network: ActivationNetworkProduction},
+			responseStatus: http.StatusOK,
+			responseBody:   `{"propertyId": "prp_175780", "versions": {"items": [{"propertyVersion": 1, "productionStatus": "INACTIVE", "stagingStatus": "ACTIVE"}]}}`,
+			withError:      ErrNotFound,
+		},
+		"invalid network": {
+			params:    GetActiveVersionOnNetworkRequest{PropertyID: "prp_175780", Network: "QA"},
+			withError: ErrStructValidation,
+		},
+		"500 internal server error": {
+			params:         GetActiveVersionOnNetworkRequest{PropertyID: "prp_175780", ContractID: "ctr_1-1TJZFW", GroupID: "grp_15166", Network: ActivationNetworkStaging},
+			responseStatus: http.StatusInternalServerError,
+			responseBody: `
+{
+	"type": "internal_error",
+    "title": "Internal Server Error",
+    "detail": "Error fetching versions",
+    "status": 500
+}`,
+			withError: &Error{
+				Type:       "internal_error",
+				Title:      "Internal Server Error",
+				Detail:     "Error fetching versions",
+				StatusCode: http.StatusInternalServerError,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/papi/v1/properties/prp_175780/versions?contractId=ctr_1-1TJZFW&groupId=grp_15166", r.URL.String())
+				assert.Equal(t, http.MethodGet, r.Method)
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			defer mockServer.Close()
+			client := mockAPIClient(t, mockServer)
+			result, err := client.GetActiveVersionOnNetwork(context.Background(), test.params)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestPapi_WaitForPropertyVersionStatus(t *testing.T) {
+	versionBody := func(stagingStatus VersionStatus) string {
+		return fmt.Sprintf(`
+{
+    "propertyId": "prp_175780",
+    "versions": {
+        "items": [
+            {
+                "propertyVersion": 2,
+                "productionStatus": "INACTIVE",
+                "stagingStatus": "%s"
+            }
+        ]
+    }
+}`, stagingStatus)
+	}
+
+	tests := map[string]struct {
+		request          WaitForPropertyVersionStatusRequest
+		statuses         []VersionStatus
+		expectedInterval time.Duration
+		withError        error
+	}{
+		"pending to active": {
+			request: WaitForPropertyVersionStatusRequest{
+				PropertyID:      "prp_175780",
+				PropertyVersion: 2,
+				ContractID:      "ctr_1-1TJZFW",
+				GroupID:         "grp_15166",
+				Network:         ActivationNetworkStaging,
+				Status:          VersionStatusActive,
+				PollInterval:    10 * time.Second,
+			},
+			statuses:         []VersionStatus{VersionStatusInactive, VersionStatusPending, VersionStatusPending, VersionStatusActive},
+			expectedInterval: 10 * time.Second,
+		},
+		"default interval": {
+			request: WaitForPropertyVersionStatusRequest{
+				PropertyID:      "prp_175780",
+				PropertyVersion: 2,
+				Network:         ActivationNetworkStaging,
+				Status:          VersionStatusActive,
+			},
+			statuses:         []VersionStatus{VersionStatusPending, VersionStatusActive},
+			expectedInterval: DefaultActivationPollInterval,
+		},
+		"already in status": {
+			request: WaitForPropertyVersionStatusRequest{
+				PropertyID:      "prp_175780",
+				PropertyVersion: 2,
+				Network:         ActivationNetworkStaging,
+				Status:          VersionStatusActive,
+			},
+			statuses: []VersionStatus{VersionStatusActive},
+		},
+		"validation error": {
+			request: WaitForPropertyVersionStatusRequest{
+				PropertyID:      "prp_175780",
+				PropertyVersion: 2,
+				Network:         ActivationNetworkStaging,
+				Status:          "ZONE_1",
+			},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var calls int32
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/papi/v1/properties/prp_175780/versions/2", r.URL.Path)
+				call := atomic.AddInt32(&calls, 1)
+				w.WriteHeader(http.StatusOK)
+				_, err := w.Write([]byte(versionBody(test.statuses[call-1])))
+				assert.NoError(t, err)
+			}))
+			defer mockServer.Close()
+			start := time.Date(2022, 10, 27, 12, 0, 0, 0, time.UTC)
+			clock := poll.NewFakeClock(start)
+			client := mockAPIClient(t, mockServer, WithClock(clock))
+
+			type result struct {
+				resp *GetPropertyVersionsResponse
+				err  error
+			}
+			done := make(chan result)
+			go func() {
+				resp, err := client.WaitForPropertyVersionStatus(context.Background(), test.request)
+				done <- result{resp, err}
+			}()
+
+			for i := 1; i < len(test.statuses); i++ {
+				clock.BlockUntil(1)
+				clock.Advance(test.expectedInterval)
+			}
+			res := <-done
+			if test.withError != nil {
+				assert.True(t, errors.Is(res.err, test.withError), "want: %s; got: %s", test.withError, res.err)
+				return
+			}
+			require.NoError(t, res.err)
+			assert.Equal(t, test.request.Status, res.resp.Version.StagingStatus)
+			assert.Equal(t, int32(len(test.statuses)), atomic.LoadInt32(&calls))
+			assert.Equal(t, start.Add(time.Duration(len(test.statuses)-1)*test.expectedInterval), clock.Now())
+		})
+	}
+}
+
+func TestPapi_WaitForPropertyVersionStatus_ContextCanceled(t *testing.T) {
+	mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"versions":{"items":[{"propertyVersion":2,"productionStatus":"PENDING","stagingStatus":"INACTIVE"}]}}`))
+		assert.NoError(t, err)
+	}))
+	defer mockServer.Close()
+	clock := poll.NewFakeClock(time.Now())
+	client := mockAPIClient(t, mockServer, WithClock(clock))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		_, err := client.WaitForPropertyVersionStatus(ctx, WaitForPropertyVersionStatusRequest{
+			PropertyID:      "prp_175780",
+			PropertyVersion: 2,
+			Network:         ActivationNetworkProduction,
+			Status:          VersionStatusActive,
+		})
+		done <- err
+	}()
+
+	clock.BlockUntil(1)
+	cancel()
+	err := <-done
+	assert.True(t, errors.Is(err, context.Canceled), "want: %s; got: %s", context.Canceled, err)
+}
+
+func TestGetFeaturesCriteriaResponse_Find(t *testing.T) {
+	var response GetFeaturesCriteriaResponse
+	require.NoError(t, json.Unmarshal([]byte(`
+{
+    "productId": "prd_Alta",
+    "ruleFormat": "v2020-09-15",
+    "availableBehaviors": {
+        "items": [
+            {
+                "name": "cpCode",
+                "schemaLink": "/papi/v1/schemas/products/prd_Alta/latest#/definitions/catalog/behaviors/cpCode"
+            },
+            {
+                "name": "origin",
+                "schemaLink": "/papi/v1/schemas/products/prd_Alta/latest#/definitions/catalog/behaviors/origin"
+            }
+        ]
+    },
+    "availableCriteria": {
+        "items": [
+            {
+                "name": "path",
+                "schemaLink": "/papi/v1/schemas/products/prd_Alta/latest#/definitions/catalog/criteria/path"
+            }
+        ]
+    }
+}`), &response))
+	unchanged := response
+
+	tests := map[string]struct {
+		find     func(string) (AvailableFeature, bool)
+		name     string
+		expected AvailableFeature
+		found    bool
+	}{
+		"behavior found": {
+			find: response.FindBehavior,
+			name: "origin",
+			expected: AvailableFeature{
+				Name:       "origin",
+				SchemaLink: "/papi/v1/schemas/products/prd_Alta/latest#/definitions/catalog/behaviors/origin",
+			},
+			found: true,
+		},
+		"behavior not found": {
+			find: response.FindBehavior,
+			name: "path",
+		},
+		"criteria found": {
+			find: response.FindCriteria,
+			name: "path",
+			expected: AvailableFeature{
+				Name:       "path",
+				SchemaLink: "/papi/v1/schemas/products/prd_Alta/latest#/definitions/catalog/criteria/path",
+			},
+			found: true,
+		},
+		"criteria not found": {
+			find: response.FindCriteria,
+			name: "cpCode",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			feature, ok := test.find(test.name)
+			assert.Equal(t, test.found, ok)
+			assert.Equal(t, test.expected, feature)
+		})
+	}
+	// lookups do not modify the response, so that it can be shared
+	assert.Equal(t, unchanged, response)
+}
+
+func TestPapi_GetPropertyVersion_LogFields(t *testing.T) {
+	mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"versions": {"items": [{"propertyVersion": 2}]}}`))
+		assert.NoError(t, err)
+	}))
+	defer mockServer.Close()
+	client := mockAPIClient(t, mockServer)
+
+	handler := memory.New()
+	ctx := session.ContextWithOptions(context.Background(),
+		session.WithContextLog(&log.Logger{Handler: handler, Level: log.DebugLevel}))
+	_, err := client.GetPropertyVersion(ctx, GetPropertyVersionRequest{PropertyID: "prp_175780", PropertyVersion: 2})
+	require.NoError(t, err)
+
+	require.NotEmpty(t, handler.Entries)
+	assert.Equal(t, "GetPropertyVersion", handler.Entries[0].Message)
+	assert.Equal(t, log.Fields{"property_id": "prp_175780", "version": 2}, handler.Entries[0].Fields)
+}
+
+func TestPapi_CanEditPropertyVersion(t *testing.T) {
+	tests := map[string]struct {
+		params           GetPropertyVersionRequest
+		responseStatus   int
+		responseBody     string
+		expectedResponse *CanEditPropertyVersionResponse
+		withError        error
+	}{
+		"inactive version is editable": {
+			params:         GetPropertyVersionRequest{PropertyID: "prp_175780", PropertyVersion: 3},
+			responseStatus: http.StatusOK,
+			responseBody: `
+{
+    "versions": {
+        "items": [{"propertyVersion": 3, "productionStatus": "INACTIVE", "stagingStatus": "INACTIVE"}]
+    }
+}`,
+			expectedResponse: &CanEditPropertyVersionResponse{Editable: true},
+		},
+		"version active on staging": {
+			params:         GetPropertyVersionRequest{PropertyID: "prp_175780", PropertyVersion: 2},
+			responseStatus: http.StatusOK,
+			responseBody: `
+{
+    "versions": {
+        "items": [{"propertyVersion": 2, "productionStatus": "INACTIVE", "stagingStatus": "ACTIVE"}]
+    }
+}`,
+			expectedResponse: &CanEditPropertyVersionResponse{Reason: "version 2 is ACTIVE on STAGING"},
+		},
+		"version deactivated on production": {
+			params:         GetPropertyVersionRequest{PropertyID: "prp_175780", PropertyVersion: 1},
+			responseStatus: http.StatusOK,
+			responseBody: `
+{
+    "versions": {
+        "items": [{"propertyVersion": 1, "productionStatus": "DEACTIVATED", "stagingStatus": "INACTIVE"}]
+    }
+}`,
+			expectedResponse: &CanEditPropertyVersionResponse{Reason: "version 1 is DEACTIVATED on PRODUCTION"},
+		},
+		"fetching version fails": {
+			params:         GetPropertyVersionRequest{PropertyID: "prp_175780", PropertyVersion: 1},
+			responseStatus: http.StatusInternalServerError,
+			responseBody:   `{"type": "internal_error", "title": "Internal Server Error", "status": 500}`,
+			withError: &Error{
+				Type:       "internal_error",
+				Title:      "Internal Server Error",
+				StatusCode: http.StatusInternalServerError,
+			},
+		},
+		"validation error": {
+			params:    GetPropertyVersionRequest{PropertyID: "prp_175780"},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, fmt.Sprintf("/papi/v1/properties/prp_175780/versions/%d", test.params.PropertyVersion), r.URL.Path)
+				assert.Equal(t, http.MethodGet, r.Method)
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			defer mockServer.Close()
+			client := mockAPIClient(t, mockServer)
+			result, err := client.CanEditPropertyVersion(context.Background(), test.params)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
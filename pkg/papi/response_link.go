@@ -3,10 +3,18 @@ package papi
 
 import (
 	"errors"
+	"fmt"
 	"net/url"
+	"path"
+	"strconv"
 	"strings"
 )
 
+type (
+	// Link is a link to a resource returned in a response, e.g. versionLink or activationLink
+	Link string
+)
+
 var (
 	// ErrInvalidResponseLink is returned when there was an error while fetching ID from location response object
 	ErrInvalidResponseLink = errors.New("response link URL is invalid")
@@ -21,3 +29,64 @@ func ResponseLinkParse(link string) (string, error) {
 	pathSplit := strings.Split(locURL.Path, "/")
 	return pathSplit[len(pathSplit)-1], nil
 }
+
+// ID returns the trailing ID from the link path, e.g. the activation ID or the version number
+func (l Link) ID() (string, error) {
+	locURL, err := url.Parse(string(l))
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", ErrInvalidResponseLink, err)
+	}
+	id := path.Base(locURL.Path)
+	if id == "" || id == "." || id == "/" {
+		return "", fmt.Errorf("%w: %q does not contain an ID", ErrInvalidResponseLink, l)
+	}
+	return id, nil
+}
+
+// ToGetPropertyVersionRequest builds a GetPropertyVersionRequest for the property version the link points to
+func (l Link) ToGetPropertyVersionRequest() (GetPropertyVersionRequest, error) {
+	propertyID, id, query, err := l.parse("versions")
+	if err != nil {
+		return GetPropertyVersionRequest{}, err
+	}
+	version, err := strconv.Atoi(id)
+	if err != nil {
+		return GetPropertyVersionRequest{}, fmt.Errorf("%w: version should be a number: %s", ErrInvalidResponseLink, id)
+	}
+	return GetPropertyVersionRequest{
+		PropertyID:      propertyID,
+		PropertyVersion: version,
+		ContractID:      query.Get("contractId"),
+		GroupID:         query.Get("groupId"),
+	}, nil
+}
+
+// ToGetActivationRequest builds a GetActivationRequest for the activation the link points to
+func (l Link) ToGetActivationRequest() (GetActivationRequest, error) {
+	propertyID, id, query, err := l.parse("activations")
+	if err != nil {
+		return GetActivationRequest{}, err
+	}
+	return GetActivationRequest{
+		PropertyID:   propertyID,
+		ActivationID: id,
+		ContractID:   query.Get("contractId"),
+		GroupID:      query.Get("groupId"),
+	}, nil
+}
+
+// parse splits a /papi/v1/properties/{propertyId}/{collection}/{id} link into its parts
+func (l Link) parse(collection string) (string, string, url.Values, error) {
+	locURL, err := url.Parse(string(l))
+	if err != nil {
+		return "", "", nil, fmt.Errorf("%w: %s", ErrInvalidResponseLink, err)
+	}
+	segments := strings.Split(strings.Trim(locURL.Path, "/"), "/")
+	if len(segments) < 4 ||
+		segments[len(segments)-4] != "properties" ||
+		segments[len(segments)-2] != collection ||
+		segments[len(segments)-3] == "" || segments[len(segments)-1] == "" {
+		return "", "", nil, fmt.Errorf("%w: %q is not a link to property %s", ErrInvalidResponseLink, l, collection)
+	}
+	return segments[len(segments)-3], segments[len(segments)-1], locURL.Query(), nil
+}
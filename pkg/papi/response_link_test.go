@@ -1,6 +1,7 @@
 package papi
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -34,3 +35,137 @@ func TestResponseLinkParse(t *testing.T) {
 		})
 	}
 }
+
+func TestLink_ID(t *testing.T) {
+	tests := map[string]struct {
+		given     Link
+		expected  string
+		withError bool
+	}{
+		"version link": {
+			given:    "/papi/v1/properties/prp_173136/versions/2?contractId=ctr_1-1TJZFB&groupId=grp_15225",
+			expected: "2",
+		},
+		"activation link": {
+			given:    "/papi/v1/properties/prp_173136/activations/atv_67037?contractId=ctr_1-1TJZFB&groupId=grp_15225",
+			expected: "atv_67037",
+		},
+		"link with trailing slash": {
+			given:    "/papi/v1/properties/prp_173136/activations/atv_67037/",
+			expected: "atv_67037",
+		},
+		"empty link": {
+			given:     "",
+			withError: true,
+		},
+		"invalid URL passed": {
+			given:     ":",
+			withError: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			res, err := test.given.ID()
+			if test.withError {
+				assert.True(t, errors.Is(err, ErrInvalidResponseLink), "want: %s; got: %s", ErrInvalidResponseLink, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, res)
+		})
+	}
+}
+
+func TestLink_ToGetPropertyVersionRequest(t *testing.T) {
+	tests := map[string]struct {
+		given     Link
+		expected  GetPropertyVersionRequest
+		withError bool
+	}{
+		"version link with query": {
+			given: "/papi/v1/properties/prp_173136/versions/2?contractId=ctr_1-1TJZFB&groupId=grp_15225",
+			expected: GetPropertyVersionRequest{
+				PropertyID:      "prp_173136",
+				PropertyVersion: 2,
+				ContractID:      "ctr_1-1TJZFB",
+				GroupID:         "grp_15225",
+			},
+		},
+		"version link without query": {
+			given: "/papi/v1/properties/prp_173136/versions/2",
+			expected: GetPropertyVersionRequest{
+				PropertyID:      "prp_173136",
+				PropertyVersion: 2,
+			},
+		},
+		"version is not a number": {
+			given:     "/papi/v1/properties/prp_173136/versions/latest?contractId=ctr_1-1TJZFB",
+			withError: true,
+		},
+		"activation link": {
+			given:     "/papi/v1/properties/prp_173136/activations/atv_67037?contractId=ctr_1-1TJZFB&groupId=grp_15225",
+			withError: true,
+		},
+		"too short link": {
+			given:     "/versions/2",
+			withError: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			res, err := test.given.ToGetPropertyVersionRequest()
+			if test.withError {
+				assert.True(t, errors.Is(err, ErrInvalidResponseLink), "want: %s; got: %s", ErrInvalidResponseLink, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, res)
+		})
+	}
+}
+
+func TestLink_ToGetActivationRequest(t *testing.T) {
+	tests := map[string]struct {
+		given     Link
+		expected  GetActivationRequest
+		withError bool
+	}{
+		"activation link with query": {
+			given: "/papi/v1/properties/prp_173136/activations/atv_67037?contractId=ctr_1-1TJZFB&groupId=grp_15225",
+			expected: GetActivationRequest{
+				PropertyID:   "prp_173136",
+				ActivationID: "atv_67037",
+				ContractID:   "ctr_1-1TJZFB",
+				GroupID:      "grp_15225",
+			},
+		},
+		"activation link with encoded query": {
+			given: "/papi/v1/properties/prp_173136/activations/atv_67037?groupId=grp_15225&contractId=ctr_1%2D1TJZFB",
+			expected: GetActivationRequest{
+				PropertyID:   "prp_173136",
+				ActivationID: "atv_67037",
+				ContractID:   "ctr_1-1TJZFB",
+				GroupID:      "grp_15225",
+			},
+		},
+		"version link": {
+			given:     "/papi/v1/properties/prp_173136/versions/2",
+			withError: true,
+		},
+		"missing activation ID": {
+			given:     "/papi/v1/properties/prp_173136/activations/",
+			withError: true,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			res, err := test.given.ToGetActivationRequest()
+			if test.withError {
+				assert.True(t, errors.Is(err, ErrInvalidResponseLink), "want: %s; got: %s", ErrInvalidResponseLink, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, res)
+		})
+	}
+}
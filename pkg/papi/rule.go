@@ -2,10 +2,16 @@ package papi
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"regexp"
+	"strconv"
+	"strings"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/edgegriderr"
 	validation "github.com/go-ozzo/ozzo-validation/v4"
@@ -22,6 +28,27 @@ type (
 		// UpdateRuleTree lists all available CP codes
 		// See: https://developer.akamai.com/api/core_features/property_manager/v1.html#putpropertyversionrules
 		UpdateRuleTree(context.Context, UpdateRulesRequest) (*UpdateRulesResponse, error)
+
+		// GetRuleTreeETag fetches only the current etag of the rule tree, which is useful for cheap change detection
+		// See: https://developer.akamai.com/api/core_features/property_manager/v1.html#getpropertyversionrules
+		GetRuleTreeETag(context.Context, GetRuleTreeETagRequest) (string, error)
+
+		// UpdatePropertyVersionNote replaces the note of a property version, which is stored as the comments of its rule tree
+		UpdatePropertyVersionNote(context.Context, UpdatePropertyVersionNoteRequest) (*UpdateRulesResponse, error)
+
+		// ExportPropertyVersion fetches the metadata and rule tree of a property version as a single bundle, e.g. for backups
+		ExportPropertyVersion(context.Context, ExportPropertyVersionRequest) (*PropertyVersionBundle, error)
+
+		// ImportPropertyVersion creates a new version of the property and updates its rule tree with the one from the bundle
+		ImportPropertyVersion(context.Context, ImportPropertyVersionRequest) (*UpdateRulesResponse, error)
+
+		// FindPropertiesUsingBehavior scans the rule trees of the latest versions of all properties in a group
+		// for a behavior, e.g. to find the properties affected by its deprecation.
+		// It fetches the rule tree of every property in the group, so it is expensive for large groups
+		FindPropertiesUsingBehavior(context.Context, FindPropertiesUsingBehaviorRequest) (*FindPropertiesUsingBehaviorResponse, error)
+
+		// FingerprintRuleTree fetches the rule tree of a property version and returns its fingerprint, see Rules.Fingerprint
+		FingerprintRuleTree(context.Context, GetRuleTreeRequest) (string, error)
 	}
 
 	// GetRuleTreeRequest contains path and query params necessary to perform GET /rules request
@@ -35,6 +62,80 @@ type (
 		RuleFormat      string
 	}
 
+	// GetRuleTreeETagRequest contains path and query params necessary to perform HEAD /rules request
+	GetRuleTreeETagRequest struct {
+		PropertyID      string
+		PropertyVersion int
+		ContractID      string
+		GroupID         string
+	}
+
+	// UpdatePropertyVersionNoteRequest contains the property version and its new note
+	UpdatePropertyVersionNoteRequest struct {
+		PropertyID      string
+		PropertyVersion int
+		ContractID      string
+		GroupID         string
+		Note            string
+	}
+
+	// ExportPropertyVersionRequest contains the property version to export
+	ExportPropertyVersionRequest struct {
+		PropertyID      string
+		PropertyVersion int
+		ContractID      string
+		GroupID         string
+	}
+
+	// PropertyVersionBundle is a serializable snapshot of a property version, with its rule tree in the version rule format
+	PropertyVersionBundle struct {
+		PropertyID      string `json:"propertyId"`
+		PropertyName    string `json:"propertyName"`
+		PropertyVersion int    `json:"propertyVersion"`
+		ContractID      string `json:"contractId"`
+		GroupID         string `json:"groupId"`
+		ProductID       string `json:"productId"`
+		RuleFormat      string `json:"ruleFormat"`
+		Note            string `json:"note,omitempty"`
+		Rules           Rules  `json:"rules"`
+	}
+
+	// ImportPropertyVersionRequest contains the property to import the bundle into, the property may differ from the exported one
+	ImportPropertyVersionRequest struct {
+		PropertyID string
+		ContractID string
+		GroupID    string
+		Bundle     PropertyVersionBundle
+	}
+
+	// FindPropertiesUsingBehaviorRequest contains the group to scan and the name of the behavior to look for
+	FindPropertiesUsingBehaviorRequest struct {
+		ContractID   string
+		GroupID      string
+		BehaviorName string
+
+		// MaxConcurrency is the maximum number of rule trees fetched at the same time.
+		// If not set, DefaultBehaviorSearchConcurrency is used
+		MaxConcurrency int
+	}
+
+	// FindPropertiesUsingBehaviorResponse contains the properties using the behavior, in the order they were listed.
+	// Fetching the rule tree of a single property does not fail the whole call, the error is reported in Errors under its PropertyID.
+	// Errors is nil if the rule tree of every property was fetched
+	FindPropertiesUsingBehaviorResponse struct {
+		Properties []PropertyBehaviorUsage
+		Errors     *BulkError
+	}
+
+	// PropertyBehaviorUsage is a property version using the behavior, with the locations of the behavior in its rule tree
+	// as JSON pointers, e.g. "#/rules/children/0/behaviors/1", see Rules.Locate
+	PropertyBehaviorUsage struct {
+		PropertyID      string
+		PropertyName    string
+		PropertyVersion int
+		Locations       []string
+	}
+
 	// GetRuleTreeResponse contains data returned by performing GET /rules request
 	GetRuleTreeResponse struct {
 		Response
@@ -102,7 +203,14 @@ type (
 		GroupID         string
 		ValidateMode    string
 		ValidateRules   bool
-		Rules           RulesUpdate
+		// RuleFormat, if set, is sent as the media type of the rule tree, e.g. "v2021-09-22" or "latest",
+		// see RuleFormatMediaType. If not set, the rule format of the property version is kept
+		RuleFormat string
+		Rules      RulesUpdate
+
+		// ETag, if set, is sent in the If-Match header, so that the request is rejected with an error matching
+		// ErrPreconditionFailed if the rule tree has changed since its etag was read
+		ETag string
 	}
 
 	// RulesUpdate is a wrapper for the request body of PUT /rules request
@@ -127,11 +235,12 @@ type (
 
 	// RuleError represents and entry in error field from PUT /rules response body
 	RuleError struct {
-		Type         string `json:"type"`
-		Title        string `json:"title"`
-		Detail       string `json:"detail"`
-		Instance     string `json:"instance"`
-		BehaviorName string `json:"behaviorName"`
+		Type          string `json:"type"`
+		Title         string `json:"title"`
+		Detail        string `json:"detail"`
+		Instance      string `json:"instance"`
+		BehaviorName  string `json:"behaviorName"`
+		ErrorLocation string `json:"errorLocation,omitempty"`
 	}
 
 	// RuleOptionsMap is a type wrapping map[string]interface{} used for adding rule options
@@ -151,10 +260,25 @@ const (
 	RuleCriteriaMustSatisfyAll RuleCriteriaMustSatisfy = "all"
 	//RuleCriteriaMustSatisfyAny const
 	RuleCriteriaMustSatisfyAny RuleCriteriaMustSatisfy = "any"
+
+	// RuleFormatLatest is the rule format which always refers to the most recent one
+	RuleFormatLatest = "latest"
+
+	// DefaultBehaviorSearchConcurrency is the default number of rule trees fetched at the same time by FindPropertiesUsingBehavior
+	DefaultBehaviorSearchConcurrency = 5
 )
 
 var validRuleFormat = regexp.MustCompile("^(latest|v\\d{4}-\\d{2}-\\d{2})$")
 
+// RuleFormatMediaType returns the media type of a rule tree in the given rule format, e.g.
+// "application/vnd.akamai.papirules.v2021-09-22+json". An empty rule format is treated as RuleFormatLatest
+func RuleFormatMediaType(ruleFormat string) string {
+	if ruleFormat == "" {
+		ruleFormat = RuleFormatLatest
+	}
+	return fmt.Sprintf("application/vnd.akamai.papirules.%s+json", ruleFormat)
+}
+
 // Validate validates GetRuleTreeRequest struct
 func (r GetRuleTreeRequest) Validate() error {
 	return validation.Errors{
@@ -165,17 +289,69 @@ func (r GetRuleTreeRequest) Validate() error {
 	}.Filter()
 }
 
+// Validate validates GetRuleTreeETagRequest struct
+func (r GetRuleTreeETagRequest) Validate() error {
+	return validation.Errors{
+		"PropertyID":      validation.Validate(r.PropertyID, validation.Required),
+		"PropertyVersion": validation.Validate(r.PropertyVersion, validation.Required),
+	}.Filter()
+}
+
 // Validate validates UpdateRulesRequest struct
 func (r UpdateRulesRequest) Validate() error {
 	errs := validation.Errors{
 		"PropertyID":      validation.Validate(r.PropertyID, validation.Required),
 		"PropertyVersion": validation.Validate(r.PropertyVersion, validation.Required),
 		"ValidateMode":    validation.Validate(r.ValidateMode, validation.In(RuleValidateModeFast, RuleValidateModeFull)),
+		"RuleFormat":      validation.Validate(r.RuleFormat, validation.Match(validRuleFormat)),
 		"Rules":           validation.Validate(r.Rules),
 	}
 	return edgegriderr.ParseValidationErrors(errs)
 }
 
+// Validate validates UpdatePropertyVersionNoteRequest struct
+func (r UpdatePropertyVersionNoteRequest) Validate() error {
+	return validation.Errors{
+		"PropertyID":      validation.Validate(r.PropertyID, validation.Required),
+		"PropertyVersion": validation.Validate(r.PropertyVersion, validation.Required),
+		"Note":            validation.Validate(r.Note, validation.Required),
+	}.Filter()
+}
+
+// Validate validates ExportPropertyVersionRequest struct
+func (r ExportPropertyVersionRequest) Validate() error {
+	return validation.Errors{
+		"PropertyID":      validation.Validate(r.PropertyID, validation.Required),
+		"PropertyVersion": validation.Validate(r.PropertyVersion, validation.Required),
+	}.Filter()
+}
+
+// Validate validates ImportPropertyVersionRequest struct
+func (r ImportPropertyVersionRequest) Validate() error {
+	return validation.Errors{
+		"PropertyID": validation.Validate(r.PropertyID, validation.Required),
+		"Bundle":     validation.Validate(r.Bundle),
+	}.Filter()
+}
+
+// Validate validates FindPropertiesUsingBehaviorRequest
+func (r FindPropertiesUsingBehaviorRequest) Validate() error {
+	return validation.Errors{
+		"ContractID":     validation.Validate(r.ContractID, validation.Required),
+		"GroupID":        validation.Validate(r.GroupID, validation.Required),
+		"BehaviorName":   validation.Validate(r.BehaviorName, validation.Required),
+		"MaxConcurrency": validation.Validate(r.MaxConcurrency, validation.Min(0)),
+	}.Filter()
+}
+
+// Validate validates PropertyVersionBundle struct
+func (b PropertyVersionBundle) Validate() error {
+	return validation.Errors{
+		"RuleFormat": validation.Validate(b.RuleFormat, validation.Required, validation.Match(validRuleFormat)),
+		"Rules":      validation.Validate(b.Rules),
+	}.Filter()
+}
+
 // Validate validates RulesUpdate struct
 func (r RulesUpdate) Validate() error {
 	return validation.Errors{
@@ -220,14 +396,123 @@ func (v RuleVariable) Validate() error {
 	}.Filter()
 }
 
+var (
+	// ErrRuleNotFound is returned when a path does not point to any node of the rule tree
+	ErrRuleNotFound = errors.New("rule tree node not found")
+)
+
+// Locate resolves a path, e.g. one returned by Error.ErrorLocationPath, against the rule tree and returns the node it points to.
+// The returned node is either *Rules, *RuleBehavior (for both behaviors and criteria), *RuleVariable or a behavior option value.
+// Paths pointing to other fields resolve to the rule, behavior or variable containing them.
+func (r *Rules) Locate(path []string) (interface{}, error) {
+	if len(path) > 0 && path[0] == "rules" {
+		path = path[1:]
+	}
+
+	rule := r
+	for len(path) >= 2 {
+		field := path[0]
+		if field != "children" && field != "behaviors" && field != "criteria" && field != "variables" {
+			break
+		}
+		idx, err := strconv.Atoi(path[1])
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid index %q of %s", ErrRuleNotFound, path[1], field)
+		}
+
+		switch field {
+		case "children":
+			if idx < 0 || idx >= len(rule.Children) {
+				return nil, fmt.Errorf("%w: rule %q has no child %d", ErrRuleNotFound, rule.Name, idx)
+			}
+			rule = &rule.Children[idx]
+			path = path[2:]
+		case "behaviors", "criteria":
+			items := rule.Behaviors
+			if field == "criteria" {
+				items = rule.Criteria
+			}
+			if idx < 0 || idx >= len(items) {
+				return nil, fmt.Errorf("%w: rule %q has no %s %d", ErrRuleNotFound, rule.Name, field, idx)
+			}
+			return locateInBehavior(&items[idx], path[2:])
+		case "variables":
+			if idx < 0 || idx >= len(rule.Variables) {
+				return nil, fmt.Errorf("%w: rule %q has no variable %d", ErrRuleNotFound, rule.Name, idx)
+			}
+			return &rule.Variables[idx], nil
+		}
+	}
+	return rule, nil
+}
+
+// FindBehavior returns the locations of all behaviors with the given name in the rule tree, including its children,
+// as JSON pointers which can be resolved with Locate, e.g. "#/rules/children/0/behaviors/1"
+func (r *Rules) FindBehavior(name string) []string {
+	var locations []string
+	var find func(rule *Rules, pointer string)
+	find = func(rule *Rules, pointer string) {
+		for i, behavior := range rule.Behaviors {
+			if behavior.Name == name {
+				locations = append(locations, fmt.Sprintf("%s/behaviors/%d", pointer, i))
+			}
+		}
+		for i := range rule.Children {
+			find(&rule.Children[i], fmt.Sprintf("%s/children/%d", pointer, i))
+		}
+	}
+	find(r, "#/rules")
+	return locations
+}
+
+// Fingerprint returns a hex encoded SHA-256 hash of the rule tree, e.g. to detect changes without comparing whole trees.
+// The tree is hashed in its JSON form, which has a fixed field order and sorted option keys, so rule trees which differ
+// only in the order of options or in formatting have the same fingerprint, while the order of rules and behaviors matters
+func (r Rules) Fingerprint() (string, error) {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256(data)
+	return hex.EncodeToString(hash[:]), nil
+}
+
+func locateInBehavior(behavior *RuleBehavior, path []string) (interface{}, error) {
+	if len(path) < 2 || path[0] != "options" {
+		return behavior, nil
+	}
+	option, ok := behavior.Options[path[1]]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q has no option %q", ErrRuleNotFound, behavior.Name, path[1])
+	}
+	return option, nil
+}
+
 var (
 	// ErrGetRuleTree represents error when fetching rule tree fails
 	ErrGetRuleTree = errors.New("fetching rule tree")
 	// ErrUpdateRuleTree represents error when updating rule tree fails
 	ErrUpdateRuleTree = errors.New("updating rule tree")
+	// ErrGetRuleTreeETag represents error when fetching rule tree etag fails
+	ErrGetRuleTreeETag = errors.New("fetching rule tree etag")
+	// ErrMissingETag is returned when a successful response has no ETag header
+	ErrMissingETag = errors.New("response has no ETag header")
+	// ErrUpdatePropertyVersionNote represents error when updating property version note fails
+	ErrUpdatePropertyVersionNote = errors.New("updating property version note")
+	// ErrExportPropertyVersion represents error when exporting property version fails
+	ErrExportPropertyVersion = errors.New("exporting property version")
+	// ErrImportPropertyVersion represents error when importing property version fails
+	ErrImportPropertyVersion = errors.New("importing property version")
+	// ErrFingerprintRuleTree represents error when fingerprinting rule tree fails
+	ErrFingerprintRuleTree = errors.New("fingerprinting rule tree")
+	// ErrFindPropertiesUsingBehavior represents error when searching properties for a behavior fails
+	ErrFindPropertiesUsingBehavior = errors.New("finding properties using behavior")
 )
 
 func (p *papi) GetRuleTree(ctx context.Context, params GetRuleTreeRequest) (*GetRuleTreeResponse, error) {
+	if err := p.applyDefaultLocation(&params.ContractID, &params.GroupID); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrGetRuleTree, ErrStructValidation, err)
+	}
 	if err := params.Validate(); err != nil {
 		return nil, fmt.Errorf("%s: %w: %s", ErrGetRuleTree, ErrStructValidation, err)
 	}
@@ -237,7 +522,7 @@ func (p *papi) GetRuleTree(ctx context.Context, params GetRuleTreeRequest) (*Get
 
 	getURL := fmt.Sprintf(
 		"/papi/v1/properties/%s/versions/%d/rules?contractId=%s&groupId=%s",
-		params.PropertyID,
+		url.PathEscape(params.PropertyID),
 		params.PropertyVersion,
 		params.ContractID,
 		params.GroupID,
@@ -254,7 +539,7 @@ func (p *papi) GetRuleTree(ctx context.Context, params GetRuleTreeRequest) (*Get
 	}
 
 	if params.RuleFormat != "" {
-		req.Header.Set("Accept", fmt.Sprintf("application/vnd.akamai.papirules.%s+json", params.RuleFormat))
+		req.Header.Set("Accept", RuleFormatMediaType(params.RuleFormat))
 	}
 
 	var rules GetRuleTreeResponse
@@ -280,7 +565,7 @@ func (p *papi) UpdateRuleTree(ctx context.Context, request UpdateRulesRequest) (
 
 	putURL := fmt.Sprintf(
 		"/papi/v1/properties/%s/versions/%d/rules?contractId=%s&groupId=%s",
-		request.PropertyID,
+		url.PathEscape(request.PropertyID),
 		request.PropertyVersion,
 		request.ContractID,
 		request.GroupID,
@@ -298,6 +583,14 @@ func (p *papi) UpdateRuleTree(ctx context.Context, request UpdateRulesRequest) (
 	if err != nil {
 		return nil, fmt.Errorf("%w: failed to create request: %s", ErrUpdateRuleTree, err)
 	}
+	// without an explicit format the body is sent as plain JSON, which keeps the rule format of the property version
+	if request.RuleFormat != "" {
+		req.Header.Set("Content-Type", RuleFormatMediaType(request.RuleFormat))
+		req.Header.Set("Accept", RuleFormatMediaType(request.RuleFormat))
+	}
+	if request.ETag != "" {
+		req.Header.Set("If-Match", request.ETag)
+	}
 
 	var versions UpdateRulesResponse
 	resp, err := p.Exec(req, &versions, request.Rules)
@@ -310,3 +603,289 @@ func (p *papi) UpdateRuleTree(ctx context.Context, request UpdateRulesRequest) (
 
 	return &versions, nil
 }
+
+func (p *papi) GetRuleTreeETag(ctx context.Context, params GetRuleTreeETagRequest) (string, error) {
+	if err := p.applyDefaultLocation(&params.ContractID, &params.GroupID); err != nil {
+		return "", fmt.Errorf("%s: %w: %s", ErrGetRuleTreeETag, ErrStructValidation, err)
+	}
+	if err := params.Validate(); err != nil {
+		return "", fmt.Errorf("%s: %w: %s", ErrGetRuleTreeETag, ErrStructValidation, err)
+	}
+
+	logger := p.Log(ctx)
+	logger.Debug("GetRuleTreeETag")
+
+	headURL := fmt.Sprintf(
+		"/papi/v1/properties/%s/versions/%d/rules?contractId=%s&groupId=%s",
+		url.PathEscape(params.PropertyID),
+		params.PropertyVersion,
+		params.ContractID,
+		params.GroupID,
+	)
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, headURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("%w: failed to create request: %s", ErrGetRuleTreeETag, err)
+	}
+
+	resp, err := p.Exec(req, nil)
+	if err != nil {
+		return "", fmt.Errorf("%w: request failed: %s", ErrGetRuleTreeETag, err)
+	}
+
+	// the response to a HEAD request has no body, so the status code is the only detail of the error
+	if resp.StatusCode == http.StatusNotFound {
+		return "", fmt.Errorf("%s: %w: %s", ErrGetRuleTreeETag, ErrNotFound, p.Error(resp))
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%s: %w", ErrGetRuleTreeETag, p.Error(resp))
+	}
+
+	// the header value may be quoted, while etags in response bodies are not
+	etag := strings.Trim(resp.Header.Get("ETag"), `"`)
+	if etag == "" {
+		return "", fmt.Errorf("%s: %w", ErrGetRuleTreeETag, ErrMissingETag)
+	}
+
+	return etag, nil
+}
+
+// UpdatePropertyVersionNote fetches the rule tree of the version and puts it back with the new note, in the same rule format.
+// The API has no separate endpoint for notes, so the rule tree is put back only if it has not changed in between the two requests,
+// otherwise the returned error matches ErrPreconditionFailed and the note can be updated again
+func (p *papi) UpdatePropertyVersionNote(ctx context.Context, params UpdatePropertyVersionNoteRequest) (*UpdateRulesResponse, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrUpdatePropertyVersionNote, ErrStructValidation, err)
+	}
+
+	logger := p.Log(ctx)
+	logger.Debug("UpdatePropertyVersionNote")
+
+	rules, err := p.GetRuleTree(ctx, GetRuleTreeRequest{
+		PropertyID:      params.PropertyID,
+		PropertyVersion: params.PropertyVersion,
+		ContractID:      params.ContractID,
+		GroupID:         params.GroupID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ErrUpdatePropertyVersionNote, err)
+	}
+
+	updated, err := p.UpdateRuleTree(ctx, UpdateRulesRequest{
+		PropertyID:      params.PropertyID,
+		PropertyVersion: params.PropertyVersion,
+		ContractID:      params.ContractID,
+		GroupID:         params.GroupID,
+		RuleFormat:      rules.RuleFormat,
+		Rules: RulesUpdate{
+			Comments: params.Note,
+			Rules:    rules.Rules,
+		},
+		ETag: rules.Etag,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ErrUpdatePropertyVersionNote, err)
+	}
+
+	return updated, nil
+}
+
+// ExportPropertyVersion fetches the property version and its rule tree, in the rule format of the version,
+// so that the rules in the bundle are not upgraded to the latest format.
+func (p *papi) ExportPropertyVersion(ctx context.Context, params ExportPropertyVersionRequest) (*PropertyVersionBundle, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrExportPropertyVersion, ErrStructValidation, err)
+	}
+
+	logger := p.Log(ctx)
+	logger.Debug("ExportPropertyVersion")
+
+	version, err := p.GetPropertyVersion(ctx, GetPropertyVersionRequest{
+		PropertyID:      params.PropertyID,
+		PropertyVersion: params.PropertyVersion,
+		ContractID:      params.ContractID,
+		GroupID:         params.GroupID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ErrExportPropertyVersion, err)
+	}
+
+	rules, err := p.GetRuleTree(ctx, GetRuleTreeRequest{
+		PropertyID:      params.PropertyID,
+		PropertyVersion: params.PropertyVersion,
+		ContractID:      params.ContractID,
+		GroupID:         params.GroupID,
+		RuleFormat:      version.Version.RuleFormat,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ErrExportPropertyVersion, err)
+	}
+
+	return &PropertyVersionBundle{
+		PropertyID:      version.PropertyID,
+		PropertyName:    version.PropertyName,
+		PropertyVersion: version.Version.PropertyVersion,
+		ContractID:      version.ContractID,
+		GroupID:         version.GroupID,
+		ProductID:       version.Version.ProductID,
+		RuleFormat:      rules.RuleFormat,
+		Note:            version.Version.Note,
+		Rules:           rules.Rules,
+	}, nil
+}
+
+// ImportPropertyVersion creates a new version from the latest version of the property and updates its rule tree with
+// the rules and note from the bundle. The bundle rule format is kept if it is still available, otherwise the rules are
+// sent without a format and the API interprets them in the format of the new version.
+// The rule errors reported by the API are returned in the response Errors.
+func (p *papi) ImportPropertyVersion(ctx context.Context, params ImportPropertyVersionRequest) (*UpdateRulesResponse, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrImportPropertyVersion, ErrStructValidation, err)
+	}
+
+	logger := p.Log(ctx)
+	logger.Debug("ImportPropertyVersion")
+
+	latest, err := p.GetLatestVersion(ctx, GetLatestVersionRequest{
+		PropertyID: params.PropertyID,
+		ContractID: params.ContractID,
+		GroupID:    params.GroupID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ErrImportPropertyVersion, err)
+	}
+
+	// rule formats are checked before the version is created, so that a failure does not leave an orphaned version behind
+	formats, err := p.GetRuleFormats(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ErrImportPropertyVersion, err)
+	}
+	var ruleFormat string
+	for _, format := range formats.RuleFormats.Items {
+		if format == params.Bundle.RuleFormat {
+			ruleFormat = format
+			break
+		}
+	}
+	if ruleFormat == "" {
+		logger.Warnf("rule format %s is not available, importing rules in the format of version %d", params.Bundle.RuleFormat, latest.Version.PropertyVersion)
+	}
+
+	created, err := p.CreatePropertyVersion(ctx, CreatePropertyVersionRequest{
+		PropertyID: params.PropertyID,
+		ContractID: params.ContractID,
+		GroupID:    params.GroupID,
+		Version: PropertyVersionCreate{
+			CreateFromVersion:     latest.Version.PropertyVersion,
+			CreateFromVersionEtag: latest.Version.Etag,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ErrImportPropertyVersion, err)
+	}
+
+	updated, err := p.UpdateRuleTree(ctx, UpdateRulesRequest{
+		PropertyID:      params.PropertyID,
+		PropertyVersion: created.PropertyVersion,
+		ContractID:      params.ContractID,
+		GroupID:         params.GroupID,
+		RuleFormat:      ruleFormat,
+		ValidateRules:   true,
+		Rules: RulesUpdate{
+			Comments: params.Bundle.Note,
+			Rules:    params.Bundle.Rules,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ErrImportPropertyVersion, err)
+	}
+
+	return updated, nil
+}
+
+func (p *papi) FindPropertiesUsingBehavior(ctx context.Context, params FindPropertiesUsingBehaviorRequest) (*FindPropertiesUsingBehaviorResponse, error) {
+	if err := params.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w: %s", ErrFindPropertiesUsingBehavior, ErrStructValidation, err)
+	}
+
+	logger := p.Log(ctx)
+	logger.Debug("FindPropertiesUsingBehavior")
+
+	properties, err := p.GetProperties(ctx, GetPropertiesRequest{
+		ContractID: params.ContractID,
+		GroupID:    params.GroupID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ErrFindPropertiesUsingBehavior, err)
+	}
+
+	concurrency := params.MaxConcurrency
+	if concurrency == 0 {
+		concurrency = DefaultBehaviorSearchConcurrency
+	}
+
+	count := len(properties.Properties.Items)
+	ids := make([]string, count)
+	for i, property := range properties.Properties.Items {
+		ids[i] = property.PropertyID
+	}
+	rules := make([]*GetRuleTreeResponse, count)
+	bulkErr, err := forEachConcurrently(ctx, ids, concurrency, func(i int) error {
+		res, err := p.GetRuleTree(ctx, GetRuleTreeRequest{
+			PropertyID:      ids[i],
+			PropertyVersion: properties.Properties.Items[i].LatestVersion,
+			ContractID:      params.ContractID,
+			GroupID:         params.GroupID,
+		})
+		if err != nil {
+			return err
+		}
+		rules[i] = res
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ErrFindPropertiesUsingBehavior, err)
+	}
+
+	result := FindPropertiesUsingBehaviorResponse{
+		Properties: make([]PropertyBehaviorUsage, 0),
+	}
+	for i, property := range properties.Properties.Items {
+		if rules[i] == nil {
+			continue
+		}
+		locations := rules[i].Rules.FindBehavior(params.BehaviorName)
+		if len(locations) == 0 {
+			continue
+		}
+		result.Properties = append(result.Properties, PropertyBehaviorUsage{
+			PropertyID:      property.PropertyID,
+			PropertyName:    property.PropertyName,
+			PropertyVersion: property.LatestVersion,
+			Locations:       locations,
+		})
+	}
+	if !bulkErr.AllSucceeded() {
+		result.Errors = bulkErr
+	}
+	return &result, nil
+}
+
+func (p *papi) FingerprintRuleTree(ctx context.Context, params GetRuleTreeRequest) (string, error) {
+	if err := params.Validate(); err != nil {
+		return "", fmt.Errorf("%s: %w: %s", ErrFingerprintRuleTree, ErrStructValidation, err)
+	}
+
+	logger := p.Log(ctx)
+	logger.Debug("FingerprintRuleTree")
+
+	rules, err := p.GetRuleTree(ctx, params)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", ErrFingerprintRuleTree, err)
+	}
+
+	fingerprint, err := rules.Rules.Fingerprint()
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", ErrFingerprintRuleTree, err)
+	}
+	return fingerprint, nil
+}
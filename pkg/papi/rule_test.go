@@ -2,6 +2,7 @@ package papi
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -626,6 +627,8 @@ func TestPapi_GetRuleTree(t *testing.T) {
 				_, err := w.Write([]byte(test.responseBody))
 				if test.params.RuleFormat != "" {
 					assert.Equal(t, r.Header.Get("Accept"), fmt.Sprintf("application/vnd.akamai.papirules.%s+json", test.params.RuleFormat))
+				} else {
+					assert.NotContains(t, r.Header.Get("Accept"), "application/vnd.akamai.papirules")
 				}
 				assert.NoError(t, err)
 			}))
@@ -1643,3 +1646,1004 @@ func TestPapi_UpdateRuleTree(t *testing.T) {
 		})
 	}
 }
+
+func TestPapi_GetRuleTreeETag(t *testing.T) {
+	tests := map[string]struct {
+		params         GetRuleTreeETagRequest
+		responseStatus int
+		responseETag   string
+		expectedPath   string
+		expectedETag   string
+		withError      func(*testing.T, error)
+	}{
+		"200 OK": {
+			params: GetRuleTreeETagRequest{
+				PropertyID:      "propertyID",
+				PropertyVersion: 2,
+				ContractID:      "contract",
+				GroupID:         "group",
+			},
+			responseStatus: http.StatusOK,
+			responseETag:   "etag",
+			expectedPath:   "/papi/v1/properties/propertyID/versions/2/rules?contractId=contract&groupId=group",
+			expectedETag:   "etag",
+		},
+		"200 OK - quoted etag": {
+			params: GetRuleTreeETagRequest{
+				PropertyID:      "propertyID",
+				PropertyVersion: 2,
+				ContractID:      "contract",
+				GroupID:         "group",
+			},
+			responseStatus: http.StatusOK,
+			responseETag:   `"etag"`,
+			expectedPath:   "/papi/v1/properties/propertyID/versions/2/rules?contractId=contract&groupId=group",
+			expectedETag:   "etag",
+		},
+		"200 OK - missing etag header": {
+			params: GetRuleTreeETagRequest{
+				PropertyID:      "propertyID",
+				PropertyVersion: 2,
+				ContractID:      "contract",
+				GroupID:         "group",
+			},
+			responseStatus: http.StatusOK,
+			expectedPath:   "/papi/v1/properties/propertyID/versions/2/rules?contractId=contract&groupId=group",
+			withError: func(t *testing.T, err error) {
+				want := ErrMissingETag
+				assert.True(t, errors.Is(err, want), "want: %s; got: %s", want, err)
+				assert.False(t, errors.Is(err, ErrNotFound), "unexpected: %s; got: %s", ErrNotFound, err)
+			},
+		},
+		"500 internal server error": {
+			params: GetRuleTreeETagRequest{
+				PropertyID:      "propertyID",
+				PropertyVersion: 2,
+				ContractID:      "contract",
+				GroupID:         "group",
+			},
+			responseStatus: http.StatusInternalServerError,
+			expectedPath:   "/papi/v1/properties/propertyID/versions/2/rules?contractId=contract&groupId=group",
+			withError: func(t *testing.T, err error) {
+				want := &Error{
+					Title:      "Failed to unmarshal error body",
+					Detail:     "unexpected end of JSON input",
+					StatusCode: http.StatusInternalServerError,
+				}
+				assert.True(t, errors.Is(err, want), "want: %s; got: %s", want, err)
+				assert.False(t, errors.Is(err, ErrNotFound), "unexpected: %s; got: %s", ErrNotFound, err)
+			},
+		},
+		"404 not found": {
+			params: GetRuleTreeETagRequest{
+				PropertyID:      "propertyID",
+				PropertyVersion: 2,
+				ContractID:      "contract",
+				GroupID:         "group",
+			},
+			responseStatus: http.StatusNotFound,
+			expectedPath:   "/papi/v1/properties/propertyID/versions/2/rules?contractId=contract&groupId=group",
+			withError: func(t *testing.T, err error) {
+				want := ErrNotFound
+				assert.True(t, errors.Is(err, want), "want: %s; got: %s", want, err)
+				assert.Contains(t, err.Error(), "Failed to unmarshal error body")
+			},
+		},
+		"empty property ID": {
+			params: GetRuleTreeETagRequest{
+				PropertyVersion: 2,
+				ContractID:      "contract",
+				GroupID:         "group",
+			},
+			withError: func(t *testing.T, err error) {
+				want := ErrStructValidation
+				assert.True(t, errors.Is(err, want), "want: %s; got: %s", want, err)
+				assert.Contains(t, err.Error(), "PropertyID")
+			},
+		},
+		"empty property version": {
+			params: GetRuleTreeETagRequest{
+				PropertyID: "propertyID",
+				ContractID: "contract",
+				GroupID:    "group",
+			},
+			withError: func(t *testing.T, err error) {
+				want := ErrStructValidation
+				assert.True(t, errors.Is(err, want), "want: %s; got: %s", want, err)
+				assert.Contains(t, err.Error(), "PropertyVersion")
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, test.expectedPath, r.URL.String())
+				assert.Equal(t, http.MethodHead, r.Method)
+				if test.responseETag != "" {
+					w.Header().Set("ETag", test.responseETag)
+				}
+				w.WriteHeader(test.responseStatus)
+			}))
+			client := mockAPIClient(t, mockServer)
+			result, err := client.GetRuleTreeETag(context.Background(), test.params)
+			if test.withError != nil {
+				test.withError(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedETag, result)
+		})
+	}
+}
+
+func TestPapi_RuleFormatMediaTypes(t *testing.T) {
+	ruleTree := `
+{
+    "propertyId": "prp_175780",
+    "propertyVersion": 3,
+    "ruleFormat": "v2021-09-22",
+    "rules": {"name": "default"}
+}`
+	tests := map[string]struct {
+		call                func(context.Context, PAPI) error
+		expectedContentType string
+		expectedAccept      string
+		withError           error
+	}{
+		"get rule tree in a specific format": {
+			call: func(ctx context.Context, client PAPI) error {
+				_, err := client.GetRuleTree(ctx, GetRuleTreeRequest{PropertyID: "prp_175780", PropertyVersion: 3, RuleFormat: "v2021-09-22"})
+				return err
+			},
+			expectedContentType: "application/json",
+			expectedAccept:      "application/vnd.akamai.papirules.v2021-09-22+json",
+		},
+		"get rule tree in the latest format": {
+			call: func(ctx context.Context, client PAPI) error {
+				_, err := client.GetRuleTree(ctx, GetRuleTreeRequest{PropertyID: "prp_175780", PropertyVersion: 3, RuleFormat: RuleFormatLatest})
+				return err
+			},
+			expectedContentType: "application/json",
+			expectedAccept:      "application/vnd.akamai.papirules.latest+json",
+		},
+		"update rule tree in a specific format": {
+			call: func(ctx context.Context, client PAPI) error {
+				_, err := client.UpdateRuleTree(ctx, UpdateRulesRequest{
+					PropertyID:      "prp_175780",
+					PropertyVersion: 3,
+					RuleFormat:      "v2021-09-22",
+					Rules:           RulesUpdate{Rules: Rules{Name: "default"}},
+				})
+				return err
+			},
+			expectedContentType: "application/vnd.akamai.papirules.v2021-09-22+json",
+			expectedAccept:      "application/vnd.akamai.papirules.v2021-09-22+json",
+		},
+		"update rule tree keeping the format of the version": {
+			call: func(ctx context.Context, client PAPI) error {
+				_, err := client.UpdateRuleTree(ctx, UpdateRulesRequest{
+					PropertyID:      "prp_175780",
+					PropertyVersion: 3,
+					Rules:           RulesUpdate{Rules: Rules{Name: "default"}},
+				})
+				return err
+			},
+			expectedContentType: "application/json",
+		},
+		"invalid rule format": {
+			call: func(ctx context.Context, client PAPI) error {
+				_, err := client.UpdateRuleTree(ctx, UpdateRulesRequest{
+					PropertyID:      "prp_175780",
+					PropertyVersion: 3,
+					RuleFormat:      "2021-09-22",
+					Rules:           RulesUpdate{Rules: Rules{Name: "default"}},
+				})
+				return err
+			},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, test.expectedContentType, r.Header.Get("Content-Type"))
+				assert.Equal(t, test.expectedAccept, r.Header.Get("Accept"))
+				w.WriteHeader(http.StatusOK)
+				_, err := w.Write([]byte(ruleTree))
+				assert.NoError(t, err)
+			}))
+			defer mockServer.Close()
+			client := mockAPIClient(t, mockServer)
+			err := test.call(context.Background(), client)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestPapi_UpdatePropertyVersionNote(t *testing.T) {
+	tests := map[string]struct {
+		params           UpdatePropertyVersionNoteRequest
+		responseStatus   int
+		expectedMethods  []string
+		expectedResponse *UpdateRulesResponse
+		withError        error
+	}{
+		"note updated": {
+			params: UpdatePropertyVersionNoteRequest{
+				PropertyID:      "prp_175780",
+				PropertyVersion: 3,
+				ContractID:      "ctr_1-1TJZFW",
+				GroupID:         "grp_15166",
+				Note:            "OPS-1234 enable http/2",
+			},
+			responseStatus:  http.StatusOK,
+			expectedMethods: []string{http.MethodGet, http.MethodPut},
+			expectedResponse: &UpdateRulesResponse{
+				PropertyID:      "prp_175780",
+				PropertyVersion: 3,
+				Comments:        "OPS-1234 enable http/2",
+				RuleFormat:      "v2021-09-22",
+				Rules: Rules{
+					Name:      "default",
+					Behaviors: []RuleBehavior{{Name: "http2", Options: RuleOptionsMap{"enabled": ""}}},
+				},
+			},
+		},
+		"rule tree changed in between": {
+			params: UpdatePropertyVersionNoteRequest{
+				PropertyID:      "prp_175780",
+				PropertyVersion: 3,
+				Note:            "OPS-1234 enable http/2",
+			},
+			responseStatus:  http.StatusPreconditionFailed,
+			expectedMethods: []string{http.MethodGet, http.MethodPut},
+			withError:       ErrPreconditionFailed,
+		},
+		"empty note": {
+			params: UpdatePropertyVersionNoteRequest{
+				PropertyID:      "prp_175780",
+				PropertyVersion: 3,
+			},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var methods []string
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				methods = append(methods, r.Method)
+				assert.Equal(t, "/papi/v1/properties/prp_175780/versions/3/rules", r.URL.Path)
+				w.Header().Set("Content-Type", "application/vnd.akamai.papirules.v2021-09-22+json")
+				if r.Method == http.MethodGet {
+					w.WriteHeader(http.StatusOK)
+					_, err := w.Write([]byte(`
+{
+    "propertyId": "prp_175780",
+    "propertyVersion": 3,
+    "ruleFormat": "v2021-09-22",
+    "etag": "a9dfe78cf93090516bde891d009eaf57",
+    "comments": "initial version",
+    "rules": {"name": "default", "behaviors": [{"name": "http2", "options": {"enabled": ""}}]}
+}`))
+					assert.NoError(t, err)
+					return
+				}
+				assert.Equal(t, "application/vnd.akamai.papirules.v2021-09-22+json", r.Header.Get("Content-Type"))
+				assert.Equal(t, "a9dfe78cf93090516bde891d009eaf57", r.Header.Get("If-Match"))
+				if test.responseStatus != http.StatusOK {
+					w.Header().Set("Content-Type", "application/problem+json")
+					w.WriteHeader(test.responseStatus)
+					_, err := w.Write([]byte(`
+{
+    "type": "https://problems.luna.akamaiapis.net/papi/v0/precondition-failed",
+    "title": "Precondition Failed",
+    "status": 412
+}`))
+					assert.NoError(t, err)
+					return
+				}
+				var body RulesUpdate
+				require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+				assert.Equal(t, RulesUpdate{
+					Comments: "OPS-1234 enable http/2",
+					Rules: Rules{
+						Name:      "default",
+						Behaviors: []RuleBehavior{{Name: "http2", Options: RuleOptionsMap{"enabled": ""}}},
+					},
+				}, body)
+				w.WriteHeader(http.StatusOK)
+				_, err := w.Write([]byte(`
+{
+    "propertyId": "prp_175780",
+    "propertyVersion": 3,
+    "ruleFormat": "v2021-09-22",
+    "comments": "OPS-1234 enable http/2",
+    "rules": {"name": "default", "behaviors": [{"name": "http2", "options": {"enabled": ""}}]}
+}`))
+				assert.NoError(t, err)
+			}))
+			defer mockServer.Close()
+			client := mockAPIClient(t, mockServer)
+			result, err := client.UpdatePropertyVersionNote(context.Background(), test.params)
+			assert.Equal(t, test.expectedMethods, methods)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestRuleFormatMediaType(t *testing.T) {
+	assert.Equal(t, "application/vnd.akamai.papirules.v2021-09-22+json", RuleFormatMediaType("v2021-09-22"))
+	assert.Equal(t, "application/vnd.akamai.papirules.latest+json", RuleFormatMediaType(""))
+}
+
+func TestRules_Locate(t *testing.T) {
+	rules := Rules{
+		Name: "default",
+		Behaviors: []RuleBehavior{
+			{Name: "origin", Options: RuleOptionsMap{"hostname": "origin.example.com"}},
+			{Name: "cpCode", Options: RuleOptionsMap{"value": map[string]interface{}{"id": 12345}}},
+		},
+		Variables: []RuleVariable{
+			{Name: "PMUSER_TEST", Value: "test"},
+		},
+		Children: []Rules{
+			{
+				Name: "Performance",
+				Children: []Rules{
+					{
+						Name:      "Compressible Objects",
+						Criteria:  []RuleBehavior{{Name: "contentType", Options: RuleOptionsMap{"matchOperator": "IS_ONE_OF"}}},
+						Behaviors: []RuleBehavior{{Name: "gzipResponse", Options: RuleOptionsMap{"behavior": "ALWAYS"}}},
+					},
+				},
+			},
+		},
+	}
+
+	tests := map[string]struct {
+		location  string
+		expected  interface{}
+		withError error
+	}{
+		"root rule": {
+			location: "#/rules",
+			expected: &rules,
+		},
+		"behavior of root rule": {
+			location: "#/rules/behaviors/1",
+			expected: &rules.Behaviors[1],
+		},
+		"behavior of nested rule": {
+			location: "#/rules/children/0/children/0/behaviors/0",
+			expected: &rules.Children[0].Children[0].Behaviors[0],
+		},
+		"criteria of nested rule": {
+			location: "#/rules/children/0/children/0/criteria/0",
+			expected: &rules.Children[0].Children[0].Criteria[0],
+		},
+		"behavior option": {
+			location: "#/rules/behaviors/0/options/hostname",
+			expected: "origin.example.com",
+		},
+		"variable": {
+			location: "#/rules/variables/0/value",
+			expected: &rules.Variables[0],
+		},
+		"field of a nested rule": {
+			location: "#/rules/children/0/name",
+			expected: &rules.Children[0],
+		},
+		"child out of range": {
+			location:  "#/rules/children/3",
+			withError: ErrRuleNotFound,
+		},
+		"behavior out of range": {
+			location:  "#/rules/children/0/behaviors/0",
+			withError: ErrRuleNotFound,
+		},
+		"invalid index": {
+			location:  "#/rules/behaviors/first",
+			withError: ErrRuleNotFound,
+		},
+		"missing option": {
+			location:  "#/rules/behaviors/0/options/port",
+			withError: ErrRuleNotFound,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			path, err := ParseErrorLocation(test.location)
+			require.NoError(t, err)
+			res, err := rules.Locate(path)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, res)
+			if ptr, ok := test.expected.(*RuleBehavior); ok {
+				assert.Same(t, ptr, res)
+			}
+		})
+	}
+}
+
+func TestPapi_ExportPropertyVersion(t *testing.T) {
+	tests := map[string]struct {
+		params           ExportPropertyVersionRequest
+		rulesStatus      int
+		expectedResponse *PropertyVersionBundle
+		withError        error
+	}{
+		"version exported": {
+			params: ExportPropertyVersionRequest{
+				PropertyID:      "prp_175780",
+				PropertyVersion: 3,
+				ContractID:      "ctr_1-1TJZFW",
+				GroupID:         "grp_15166",
+			},
+			rulesStatus: http.StatusOK,
+			expectedResponse: &PropertyVersionBundle{
+				PropertyID:      "prp_175780",
+				PropertyName:    "example.com",
+				PropertyVersion: 3,
+				ContractID:      "ctr_1-1TJZFW",
+				GroupID:         "grp_15166",
+				ProductID:       "prd_Web_Accel",
+				RuleFormat:      "v2021-09-22",
+				Note:            "enable http/2",
+				Rules: Rules{
+					Name:      "default",
+					Behaviors: []RuleBehavior{{Name: "http2", Options: RuleOptionsMap{"enabled": ""}}},
+				},
+			},
+		},
+		"missing property version": {
+			params:    ExportPropertyVersionRequest{PropertyID: "prp_175780"},
+			withError: ErrStructValidation,
+		},
+		"rule tree not found": {
+			params: ExportPropertyVersionRequest{
+				PropertyID:      "prp_175780",
+				PropertyVersion: 3,
+				ContractID:      "ctr_1-1TJZFW",
+				GroupID:         "grp_15166",
+			},
+			rulesStatus: http.StatusNotFound,
+			withError: &Error{
+				Type:       "not_found",
+				Title:      "Not Found",
+				StatusCode: http.StatusNotFound,
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodGet, r.Method)
+				switch r.URL.Path {
+				case "/papi/v1/properties/prp_175780/versions/3":
+					w.WriteHeader(http.StatusOK)
+					_, err := w.Write([]byte(`
+{
+    "propertyId": "prp_175780",
+    "propertyName": "example.com",
+    "contractId": "ctr_1-1TJZFW",
+    "groupId": "grp_15166",
+    "versions": {
+        "items": [
+            {
+                "propertyVersion": 3,
+                "productId": "prd_Web_Accel",
+                "ruleFormat": "v2021-09-22",
+                "note": "enable http/2"
+            }
+        ]
+    }
+}`))
+					assert.NoError(t, err)
+				case "/papi/v1/properties/prp_175780/versions/3/rules":
+					assert.Equal(t, "application/vnd.akamai.papirules.v2021-09-22+json", r.Header.Get("Accept"))
+					w.WriteHeader(test.rulesStatus)
+					body := `{"type": "not_found", "title": "Not Found"}`
+					if test.rulesStatus == http.StatusOK {
+						body = `
+{
+    "propertyId": "prp_175780",
+    "propertyVersion": 3,
+    "ruleFormat": "v2021-09-22",
+    "rules": {"name": "default", "behaviors": [{"name": "http2", "options": {"enabled": ""}}]}
+}`
+					}
+					_, err := w.Write([]byte(body))
+					assert.NoError(t, err)
+				default:
+					t.Fatalf("unexpected request: %s", r.URL)
+				}
+			}))
+			defer mockServer.Close()
+			client := mockAPIClient(t, mockServer)
+			result, err := client.ExportPropertyVersion(context.Background(), test.params)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+
+			body, err := json.Marshal(result)
+			require.NoError(t, err)
+			var bundle PropertyVersionBundle
+			require.NoError(t, json.Unmarshal(body, &bundle))
+			assert.Equal(t, *test.expectedResponse, bundle)
+		})
+	}
+}
+
+func TestPapi_ImportPropertyVersion(t *testing.T) {
+	bundle := PropertyVersionBundle{
+		PropertyID:      "prp_175780",
+		PropertyName:    "example.com",
+		PropertyVersion: 3,
+		ContractID:      "ctr_1-1TJZFW",
+		GroupID:         "grp_15166",
+		ProductID:       "prd_Web_Accel",
+		RuleFormat:      "v2021-09-22",
+		Note:            "enable http/2",
+		Rules: Rules{
+			Name:      "default",
+			Behaviors: []RuleBehavior{{Name: "http2", Options: RuleOptionsMap{"enabled": ""}}},
+		},
+	}
+	tests := map[string]struct {
+		params              ImportPropertyVersionRequest
+		ruleFormats         string
+		ruleFormatsStatus   int
+		expectedContentType string
+		expectedResponse    *UpdateRulesResponse
+		withError           error
+	}{
+		"bundle imported in its rule format": {
+			params:              ImportPropertyVersionRequest{PropertyID: "prp_200000", ContractID: "ctr_1-1TJZFW", GroupID: "grp_15166", Bundle: bundle},
+			ruleFormats:         `{"ruleFormats": {"items": ["latest", "v2021-09-22"]}}`,
+			expectedContentType: "application/vnd.akamai.papirules.v2021-09-22+json",
+			expectedResponse: &UpdateRulesResponse{
+				PropertyID:      "prp_200000",
+				PropertyVersion: 8,
+				Comments:        "enable http/2",
+				RuleFormat:      "v2021-09-22",
+				Rules:           bundle.Rules,
+				Errors: []RuleError{
+					{
+						Type:          "https://problems.example.net/papi/v0/validation/incompatible_condition",
+						Title:         "Unsupported product",
+						ErrorLocation: "#/rules/behaviors/0",
+					},
+				},
+			},
+		},
+		"rule format no longer available": {
+			params:              ImportPropertyVersionRequest{PropertyID: "prp_200000", ContractID: "ctr_1-1TJZFW", GroupID: "grp_15166", Bundle: bundle},
+			ruleFormats:         `{"ruleFormats": {"items": ["latest", "v2023-01-05"]}}`,
+			expectedContentType: "application/json",
+			expectedResponse: &UpdateRulesResponse{
+				PropertyID:      "prp_200000",
+				PropertyVersion: 8,
+				Comments:        "enable http/2",
+				RuleFormat:      "v2021-09-22",
+				Rules:           bundle.Rules,
+				Errors: []RuleError{
+					{
+						Type:          "https://problems.example.net/papi/v0/validation/incompatible_condition",
+						Title:         "Unsupported product",
+						ErrorLocation: "#/rules/behaviors/0",
+					},
+				},
+			},
+		},
+		"rule formats request fails, no version created": {
+			params:            ImportPropertyVersionRequest{PropertyID: "prp_200000", ContractID: "ctr_1-1TJZFW", GroupID: "grp_15166", Bundle: bundle},
+			ruleFormats:       `{"type": "internal_error", "title": "Internal Server Error", "status": 500}`,
+			ruleFormatsStatus: http.StatusInternalServerError,
+			withError:         &Error{Type: "internal_error", Title: "Internal Server Error", StatusCode: http.StatusInternalServerError},
+		},
+		"bundle without rules": {
+			params:    ImportPropertyVersionRequest{PropertyID: "prp_200000", Bundle: PropertyVersionBundle{RuleFormat: "v2021-09-22"}},
+			withError: ErrStructValidation,
+		},
+		"missing property ID": {
+			params:    ImportPropertyVersionRequest{Bundle: bundle},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.Method + " " + r.URL.Path {
+				case "GET /papi/v1/properties/prp_200000/versions/latest":
+					w.WriteHeader(http.StatusOK)
+					_, err := w.Write([]byte(`{"propertyId": "prp_200000", "versions": {"items": [{"propertyVersion": 7, "etag": "etag7"}]}}`))
+					assert.NoError(t, err)
+				case "POST /papi/v1/properties/prp_200000/versions":
+					assert.Zero(t, test.ruleFormatsStatus, "version created although rule formats are not available")
+					var body PropertyVersionCreate
+					assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+					assert.Equal(t, PropertyVersionCreate{CreateFromVersion: 7, CreateFromVersionEtag: "etag7"}, body)
+					w.WriteHeader(http.StatusCreated)
+					_, err := w.Write([]byte(`{"versionLink": "/papi/v1/properties/prp_200000/versions/8?contractId=ctr_1-1TJZFW&groupId=grp_15166"}`))
+					assert.NoError(t, err)
+				case "GET /papi/v1/rule-formats":
+					if test.ruleFormatsStatus != 0 {
+						w.WriteHeader(test.ruleFormatsStatus)
+					} else {
+						w.WriteHeader(http.StatusOK)
+					}
+					_, err := w.Write([]byte(test.ruleFormats))
+					assert.NoError(t, err)
+				case "PUT /papi/v1/properties/prp_200000/versions/8/rules":
+					assert.Equal(t, test.expectedContentType, r.Header.Get("Content-Type"))
+					assert.Equal(t, "", r.URL.Query().Get("validateRules"))
+					var body RulesUpdate
+					assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+					assert.Equal(t, RulesUpdate{Comments: bundle.Note, Rules: bundle.Rules}, body)
+					w.WriteHeader(http.StatusOK)
+					_, err := w.Write([]byte(`
+{
+    "propertyId": "prp_200000",
+    "propertyVersion": 8,
+    "ruleFormat": "v2021-09-22",
+    "comments": "enable http/2",
+    "rules": {"name": "default", "behaviors": [{"name": "http2", "options": {"enabled": ""}}]},
+    "errors": [
+        {
+            "type": "https://problems.example.net/papi/v0/validation/incompatible_condition",
+            "title": "Unsupported product",
+            "errorLocation": "#/rules/behaviors/0"
+        }
+    ]
+}`))
+					assert.NoError(t, err)
+				default:
+					t.Fatalf("unexpected request: %s %s", r.Method, r.URL)
+				}
+			}))
+			defer mockServer.Close()
+			client := mockAPIClient(t, mockServer)
+			result, err := client.ImportPropertyVersion(context.Background(), test.params)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestRules_FindBehavior(t *testing.T) {
+	rules := Rules{
+		Name:      "default",
+		Behaviors: []RuleBehavior{{Name: "origin"}, {Name: "caching"}},
+		Children: []Rules{
+			{
+				Name:      "Static",
+				Criteria:  []RuleBehavior{{Name: "caching"}},
+				Behaviors: []RuleBehavior{{Name: "gzipResponse"}},
+			},
+			{
+				Name: "API",
+				Children: []Rules{
+					{Name: "No store", Behaviors: []RuleBehavior{{Name: "downstreamCache"}, {Name: "caching"}}},
+				},
+			},
+		},
+	}
+
+	tests := map[string]struct {
+		name     string
+		expected []string
+	}{
+		"behavior in root and nested child": {
+			name:     "caching",
+			expected: []string{"#/rules/behaviors/1", "#/rules/children/1/children/0/behaviors/1"},
+		},
+		"behavior in child": {
+			name:     "gzipResponse",
+			expected: []string{"#/rules/children/0/behaviors/0"},
+		},
+		"behavior not used": {
+			name: "sureRoute",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			locations := rules.FindBehavior(test.name)
+			assert.Equal(t, test.expected, locations)
+			for _, location := range locations {
+				path, err := ParseErrorLocation(location)
+				require.NoError(t, err)
+				node, err := rules.Locate(path)
+				require.NoError(t, err)
+				assert.Equal(t, test.name, node.(*RuleBehavior).Name)
+			}
+		})
+	}
+}
+
+func TestPapi_FindPropertiesUsingBehavior(t *testing.T) {
+	propertiesBody := `
+{
+    "properties": {
+        "items": [
+            {"propertyId": "prp_175780", "propertyName": "example.com", "latestVersion": 2},
+            {"propertyId": "prp_175781", "propertyName": "www.example.com", "latestVersion": 5},
+            {"propertyId": "prp_175782", "propertyName": "api.example.com", "latestVersion": 1}
+        ]
+    }
+}`
+	withBehavior := `
+{
+    "rules": {
+        "name": "default",
+        "behaviors": [{"name": "origin", "options": {}}],
+        "children": [
+            {"name": "Images", "behaviors": [{"name": "imageManager", "options": {}}]}
+        ]
+    }
+}`
+	withoutBehavior := `
+{
+    "rules": {
+        "name": "default",
+        "behaviors": [{"name": "origin", "options": {}}, {"name": "caching", "options": {}}]
+    }
+}`
+
+	tests := map[string]struct {
+		params           FindPropertiesUsingBehaviorRequest
+		propertiesStatus int
+		failingProperty  string
+		expectedResponse *FindPropertiesUsingBehaviorResponse
+		expectedErrors   map[string]error
+		withError        error
+	}{
+		"behavior used by one property": {
+			params:           FindPropertiesUsingBehaviorRequest{ContractID: "ctr_1-1TJZH5", GroupID: "grp_15225", BehaviorName: "imageManager"},
+			propertiesStatus: http.StatusOK,
+			expectedResponse: &FindPropertiesUsingBehaviorResponse{
+				Properties: []PropertyBehaviorUsage{
+					{
+						PropertyID:      "prp_175780",
+						PropertyName:    "example.com",
+						PropertyVersion: 2,
+						Locations:       []string{"#/rules/children/0/behaviors/0"},
+					},
+				},
+			},
+		},
+		"behavior used by all properties": {
+			params:           FindPropertiesUsingBehaviorRequest{ContractID: "ctr_1-1TJZH5", GroupID: "grp_15225", BehaviorName: "origin", MaxConcurrency: 1},
+			propertiesStatus: http.StatusOK,
+			expectedResponse: &FindPropertiesUsingBehaviorResponse{
+				Properties: []PropertyBehaviorUsage{
+					{PropertyID: "prp_175780", PropertyName: "example.com", PropertyVersion: 2, Locations: []string{"#/rules/behaviors/0"}},
+					{PropertyID: "prp_175781", PropertyName: "www.example.com", PropertyVersion: 5, Locations: []string{"#/rules/behaviors/0"}},
+					{PropertyID: "prp_175782", PropertyName: "api.example.com", PropertyVersion: 1, Locations: []string{"#/rules/behaviors/0"}},
+				},
+			},
+		},
+		"behavior not used": {
+			params:           FindPropertiesUsingBehaviorRequest{ContractID: "ctr_1-1TJZH5", GroupID: "grp_15225", BehaviorName: "sureRoute"},
+			propertiesStatus: http.StatusOK,
+			expectedResponse: &FindPropertiesUsingBehaviorResponse{
+				Properties: []PropertyBehaviorUsage{},
+			},
+		},
+		"rule tree of one property fails": {
+			params:           FindPropertiesUsingBehaviorRequest{ContractID: "ctr_1-1TJZH5", GroupID: "grp_15225", BehaviorName: "caching"},
+			propertiesStatus: http.StatusOK,
+			failingProperty:  "prp_175781",
+			expectedResponse: &FindPropertiesUsingBehaviorResponse{
+				Properties: []PropertyBehaviorUsage{
+					{PropertyID: "prp_175782", PropertyName: "api.example.com", PropertyVersion: 1, Locations: []string{"#/rules/behaviors/1"}},
+				},
+			},
+			expectedErrors: map[string]error{"prp_175781": &Error{
+				Type:       "internal_error",
+				Title:      "Internal Server Error",
+				StatusCode: http.StatusInternalServerError,
+			}},
+		},
+		"listing properties fails": {
+			params:           FindPropertiesUsingBehaviorRequest{ContractID: "ctr_1-1TJZH5", GroupID: "grp_15225", BehaviorName: "origin"},
+			propertiesStatus: http.StatusInternalServerError,
+			withError: &Error{
+				Type:       "internal_error",
+				Title:      "Internal Server Error",
+				StatusCode: http.StatusInternalServerError,
+			},
+		},
+		"validation error": {
+			params:    FindPropertiesUsingBehaviorRequest{ContractID: "ctr_1-1TJZH5", GroupID: "grp_15225"},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodGet, r.Method)
+				assert.Equal(t, "ctr_1-1TJZH5", r.URL.Query().Get("contractId"))
+				assert.Equal(t, "grp_15225", r.URL.Query().Get("groupId"))
+				var err error
+				switch r.URL.Path {
+				case "/papi/v1/properties":
+					w.WriteHeader(test.propertiesStatus)
+					if test.propertiesStatus != http.StatusOK {
+						_, err = w.Write([]byte(`{"type": "internal_error", "title": "Internal Server Error", "status": 500}`))
+						break
+					}
+					_, err = w.Write([]byte(propertiesBody))
+				case "/papi/v1/properties/" + test.failingProperty + "/versions/5/rules":
+					w.WriteHeader(http.StatusInternalServerError)
+					_, err = w.Write([]byte(`{"type": "internal_error", "title": "Internal Server Error", "status": 500}`))
+				case "/papi/v1/properties/prp_175780/versions/2/rules":
+					w.WriteHeader(http.StatusOK)
+					_, err = w.Write([]byte(withBehavior))
+				case "/papi/v1/properties/prp_175781/versions/5/rules":
+					w.WriteHeader(http.StatusOK)
+					_, err = w.Write([]byte(withoutBehavior))
+				case "/papi/v1/properties/prp_175782/versions/1/rules":
+					w.WriteHeader(http.StatusOK)
+					_, err = w.Write([]byte(withoutBehavior))
+				default:
+					t.Errorf("unexpected path: %s", r.URL.Path)
+				}
+				assert.NoError(t, err)
+			}))
+			defer mockServer.Close()
+			client := mockAPIClient(t, mockServer)
+			result, err := client.FindPropertiesUsingBehavior(context.Background(), test.params)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse.Properties, result.Properties)
+			if test.expectedErrors == nil {
+				assert.Nil(t, result.Errors)
+				return
+			}
+			require.NotNil(t, result.Errors)
+			assert.Len(t, result.Errors.Failures, len(test.expectedErrors))
+			result.Errors.Each(func(failure BulkItemError) {
+				expected := test.expectedErrors[failure.ID]
+				assert.True(t, errors.Is(failure, expected), "want: %s; got: %s", expected, failure)
+			})
+		})
+	}
+}
+
+func TestPapi_FindPropertiesUsingBehavior_ContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/papi/v1/properties" {
+			w.WriteHeader(http.StatusOK)
+			_, err := w.Write([]byte(`{"properties": {"items": [{"propertyId": "prp_1", "latestVersion": 1}, {"propertyId": "prp_2", "latestVersion": 1}]}}`))
+			assert.NoError(t, err)
+			return
+		}
+		cancel()
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"rules": {"name": "default"}}`))
+		assert.NoError(t, err)
+	}))
+	defer mockServer.Close()
+	client := mockAPIClient(t, mockServer)
+
+	_, err := client.FindPropertiesUsingBehavior(ctx, FindPropertiesUsingBehaviorRequest{
+		ContractID:     "ctr_1-1TJZH5",
+		GroupID:        "grp_15225",
+		BehaviorName:   "origin",
+		MaxConcurrency: 1,
+	})
+	assert.True(t, errors.Is(err, context.Canceled), "want: %s; got: %s", context.Canceled, err)
+}
+
+func TestPapi_FingerprintRuleTree(t *testing.T) {
+	tree := `
+{
+    "propertyId": "prp_175780",
+    "propertyVersion": 3,
+    "etag": "a872a3f0",
+    "rules": {
+        "name": "default",
+        "behaviors": [
+            {"name": "origin", "options": {"hostname": "origin.example.com", "httpPort": 80, "httpsPort": 443}},
+            {"name": "caching", "options": {"behavior": "MAX_AGE", "ttl": "1d"}}
+        ]
+    }
+}`
+	reordered := `
+{
+    "etag": "b91c04de",
+    "propertyVersion": 4,
+    "propertyId": "prp_175780",
+    "rules": {"behaviors": [
+        {"options": {"httpsPort": 443, "hostname": "origin.example.com", "httpPort": 80.0}, "name": "origin"},
+        {"options": {"ttl": "1d", "behavior": "MAX_AGE"}, "name": "caching"}
+    ], "name": "default"}
+}`
+	changed := `
+{
+    "propertyId": "prp_175780",
+    "propertyVersion": 5,
+    "rules": {
+        "name": "default",
+        "behaviors": [
+            {"name": "origin", "options": {"hostname": "origin.example.com", "httpPort": 80, "httpsPort": 443}},
+            {"name": "caching", "options": {"behavior": "MAX_AGE", "ttl": "7d"}}
+        ]
+    }
+}`
+	bodies := map[int]string{3: tree, 4: reordered, 5: changed}
+
+	mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		for version, body := range bodies {
+			if r.URL.Path == fmt.Sprintf("/papi/v1/properties/prp_175780/versions/%d/rules", version) {
+				w.WriteHeader(http.StatusOK)
+				_, err := w.Write([]byte(body))
+				assert.NoError(t, err)
+				return
+			}
+		}
+		w.WriteHeader(http.StatusNotFound)
+		_, err := w.Write([]byte(`{"type": "not_found", "title": "Not Found", "status": 404}`))
+		assert.NoError(t, err)
+	}))
+	defer mockServer.Close()
+	client := mockAPIClient(t, mockServer)
+
+	fingerprint := func(version int) (string, error) {
+		return client.FingerprintRuleTree(context.Background(), GetRuleTreeRequest{
+			PropertyID:      "prp_175780",
+			PropertyVersion: version,
+			ContractID:      "ctr_1-1TJZFW",
+			GroupID:         "grp_15166",
+		})
+	}
+
+	original, err := fingerprint(3)
+	require.NoError(t, err)
+	assert.Len(t, original, 64)
+
+	same, err := fingerprint(4)
+	require.NoError(t, err)
+	assert.Equal(t, original, same, "trees differing only in key order and formatting should have the same fingerprint")
+
+	different, err := fingerprint(5)
+	require.NoError(t, err)
+	assert.NotEqual(t, original, different)
+
+	_, err = fingerprint(6)
+	want := &Error{Type: "not_found", Title: "Not Found", StatusCode: http.StatusNotFound}
+	assert.True(t, errors.Is(err, want), "want: %s; got: %s", want, err)
+
+	_, err = client.FingerprintRuleTree(context.Background(), GetRuleTreeRequest{PropertyID: "prp_175780"})
+	assert.True(t, errors.Is(err, ErrStructValidation), "want: %s; got: %s", ErrStructValidation, err)
+}
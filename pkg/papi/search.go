@@ -5,6 +5,8 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"strings"
+	"sync"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 )
@@ -16,6 +18,10 @@ type (
 		// Search earches properties by name, or by the hostname or edge hostname for which it’s currently active
 		// https://developer.akamai.com/api/core_features/property_manager/v1.html#postfindbyvalue
 		SearchProperties(context.Context, SearchRequest) (*SearchResponse, error)
+
+		// SearchPropertiesMulti runs several searches concurrently and merges their results,
+		// keeping a single item for every property version
+		SearchPropertiesMulti(context.Context, []SearchRequest) (*SearchResponse, error)
 	}
 
 	// SearchResponse contains response body of POST /search request
@@ -72,9 +78,49 @@ func (s SearchRequest) Validate() error {
 	}.Filter()
 }
 
+// Count returns the number of property versions matched by the search
+// The API does not return any paging information, so this is the only indication of how broad the search was
+func (r SearchResponse) Count() int {
+	return len(r.Versions.Items)
+}
+
+// Equal reports whether both items describe the same search result, regardless of whether their IDs
+// were returned with prefixes (e.g. "prp_175780" and "175780" are considered equal)
+func (i SearchItem) Equal(other SearchItem) bool {
+	return i.withoutPrefixes() == other.withoutPrefixes()
+}
+
+// withoutPrefixes returns a copy of the item with the prefixes removed from all of its IDs
+func (i SearchItem) withoutPrefixes() SearchItem {
+	i.AccountID = strings.TrimPrefix(i.AccountID, "act_")
+	i.AssetID = strings.TrimPrefix(i.AssetID, "aid_")
+	i.ContractID = strings.TrimPrefix(i.ContractID, "ctr_")
+	i.GroupID = strings.TrimPrefix(i.GroupID, "grp_")
+	i.PropertyID = strings.TrimPrefix(i.PropertyID, "prp_")
+	return i
+}
+
+// Dedupe returns the items without duplicates, compared with SearchItem.Equal
+// The first occurrence of every item is kept, in the original order
+func (s SearchItems) Dedupe() SearchItems {
+	seen := make(map[SearchItem]bool, len(s.Items))
+	result := SearchItems{Items: make([]SearchItem, 0, len(s.Items))}
+	for _, item := range s.Items {
+		key := item.withoutPrefixes()
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		result.Items = append(result.Items, item)
+	}
+	return result
+}
+
 var (
 	// ErrSearchProperties represents error when searching for properties fails
 	ErrSearchProperties = errors.New("searching for properties")
+	// ErrSearchPropertiesMulti represents error when running multiple property searches fails
+	ErrSearchPropertiesMulti = errors.New("searching for properties with multiple requests")
 )
 
 func (p *papi) SearchProperties(ctx context.Context, request SearchRequest) (*SearchResponse, error) {
@@ -103,3 +149,72 @@ func (p *papi) SearchProperties(ctx context.Context, request SearchRequest) (*Se
 
 	return &search, nil
 }
+
+// SearchPropertiesMulti runs all searches concurrently and fails if any of them fails.
+// The merged items are in the order of the requests, and for every property version only the first item is kept,
+// regardless of the ID prefixes, as the same version is returned once per matching hostname
+func (p *papi) SearchPropertiesMulti(ctx context.Context, requests []SearchRequest) (*SearchResponse, error) {
+	for i, request := range requests {
+		if err := request.Validate(); err != nil {
+			return nil, fmt.Errorf("%s: %w: request %d: %s", ErrSearchPropertiesMulti, ErrStructValidation, i, err)
+		}
+	}
+
+	logger := p.Log(ctx)
+	logger.Debug("SearchPropertiesMulti")
+
+	searchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make([]*SearchResponse, len(requests))
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+	for i, request := range requests {
+		wg.Add(1)
+		go func(i int, request SearchRequest) {
+			defer wg.Done()
+			result, err := p.SearchProperties(searchCtx, request)
+			if err != nil {
+				// keep the error which caused the cancellation rather than the ones caused by it
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = err
+				}
+				mu.Unlock()
+				cancel()
+				return
+			}
+			results[i] = result
+		}(i, request)
+	}
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", ErrSearchPropertiesMulti, err)
+	}
+	if firstErr != nil {
+		return nil, fmt.Errorf("%s: %w", ErrSearchPropertiesMulti, firstErr)
+	}
+
+	type propertyVersion struct {
+		propertyID string
+		version    int
+	}
+	seen := make(map[propertyVersion]bool)
+	var merged SearchResponse
+	merged.Versions.Items = make([]SearchItem, 0)
+	for _, result := range results {
+		for _, item := range result.Versions.Items {
+			key := propertyVersion{propertyID: strings.TrimPrefix(item.PropertyID, "prp_"), version: item.PropertyVersion}
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			merged.Versions.Items = append(merged.Versions.Items, item)
+		}
+	}
+	return &merged, nil
+}
@@ -190,3 +190,261 @@ func TestPapi_SearchProperties(t *testing.T) {
 		})
 	}
 }
+
+func TestSearchResponse_Count(t *testing.T) {
+	tests := map[string]struct {
+		responseBody string
+		expected     int
+	}{
+		"multiple versions": {
+			responseBody: `
+{
+    "versions": {
+        "items": [
+            {
+                "accountId": "act_1-1TJZFB",
+                "contractId": "ctr_1-1TJZH5",
+                "groupId": "grp_15225",
+                "propertyId": "prp_175780",
+                "propertyName": "example.com",
+                "propertyVersion": 1,
+                "productionStatus": "INACTIVE",
+                "stagingStatus": "ACTIVE"
+            },
+            {
+                "accountId": "act_1-1TJZFB",
+                "contractId": "ctr_1-1TJZH5",
+                "groupId": "grp_15225",
+                "propertyId": "prp_175780",
+                "propertyName": "example.com",
+                "propertyVersion": 2,
+                "productionStatus": "ACTIVE",
+                "stagingStatus": "INACTIVE"
+            },
+            {
+                "accountId": "act_1-1TJZFB",
+                "contractId": "ctr_1-1TJZH5",
+                "groupId": "grp_15225",
+                "propertyId": "prp_175781",
+                "propertyName": "www.example.com",
+                "propertyVersion": 5,
+                "productionStatus": "INACTIVE",
+                "stagingStatus": "ACTIVE"
+            }
+        ]
+    }
+}`,
+			expected: 3,
+		},
+		"no results": {
+			responseBody: `{"versions": {"items": []}}`,
+			expected:     0,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var res SearchResponse
+			require.NoError(t, json.Unmarshal([]byte(test.responseBody), &res))
+			assert.Equal(t, test.expected, res.Count())
+		})
+	}
+}
+
+func TestSearchItem_Equal(t *testing.T) {
+	prefixed := SearchItem{
+		AccountID:       "act_1-1TJZFB",
+		AssetID:         "aid_101",
+		ContractID:      "ctr_1-1TJZH5",
+		GroupID:         "grp_15225",
+		PropertyID:      "prp_175780",
+		PropertyName:    "example.com",
+		PropertyVersion: 2,
+	}
+	unprefixed := SearchItem{
+		AccountID:       "1-1TJZFB",
+		AssetID:         "101",
+		ContractID:      "1-1TJZH5",
+		GroupID:         "15225",
+		PropertyID:      "175780",
+		PropertyName:    "example.com",
+		PropertyVersion: 2,
+	}
+	otherVersion := prefixed
+	otherVersion.PropertyVersion = 3
+
+	tests := map[string]struct {
+		a, b     SearchItem
+		expected bool
+	}{
+		"same item": {
+			a:        prefixed,
+			b:        prefixed,
+			expected: true,
+		},
+		"prefixed and unprefixed": {
+			a:        prefixed,
+			b:        unprefixed,
+			expected: true,
+		},
+		"different version": {
+			a: prefixed,
+			b: otherVersion,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, test.a.Equal(test.b))
+			assert.Equal(t, test.expected, test.b.Equal(test.a))
+		})
+	}
+}
+
+func TestSearchItems_Dedupe(t *testing.T) {
+	items := SearchItems{Items: []SearchItem{
+		{ContractID: "ctr_1-1TJZH5", GroupID: "grp_15225", PropertyID: "prp_175780", PropertyVersion: 2},
+		{ContractID: "ctr_1-1TJZH5", GroupID: "grp_15225", PropertyID: "prp_175781", PropertyVersion: 5},
+		{ContractID: "1-1TJZH5", GroupID: "15225", PropertyID: "175780", PropertyVersion: 2},
+		{ContractID: "ctr_1-1TJZH5", GroupID: "grp_15225", PropertyID: "prp_175780", PropertyVersion: 1},
+		{ContractID: "ctr_1-1TJZH5", GroupID: "grp_15225", PropertyID: "prp_175781", PropertyVersion: 5},
+	}}
+
+	assert.Equal(t, SearchItems{Items: []SearchItem{
+		{ContractID: "ctr_1-1TJZH5", GroupID: "grp_15225", PropertyID: "prp_175780", PropertyVersion: 2},
+		{ContractID: "ctr_1-1TJZH5", GroupID: "grp_15225", PropertyID: "prp_175781", PropertyVersion: 5},
+		{ContractID: "ctr_1-1TJZH5", GroupID: "grp_15225", PropertyID: "prp_175780", PropertyVersion: 1},
+	}}, items.Dedupe())
+	assert.Equal(t, SearchItems{Items: []SearchItem{}}, SearchItems{}.Dedupe())
+}
+
+func TestPapi_SearchPropertiesMulti(t *testing.T) {
+	responses := map[string]string{
+		`{"hostname":"www.example.com"}`: `
+{
+    "versions": {
+        "items": [
+            {
+                "propertyId": "prp_175780",
+                "propertyVersion": 2,
+                "hostname": "www.example.com",
+                "productionStatus": "ACTIVE",
+                "stagingStatus": "INACTIVE"
+            },
+            {
+                "propertyId": "prp_175780",
+                "propertyVersion": 3,
+                "hostname": "www.example.com",
+                "productionStatus": "INACTIVE",
+                "stagingStatus": "ACTIVE"
+            }
+        ]
+    }
+}`,
+		`{"edgeHostname":"www.example.com.edgekey.net"}`: `
+{
+    "versions": {
+        "items": [
+            {
+                "propertyId": "175780",
+                "propertyVersion": 3,
+                "edgeHostname": "www.example.com.edgekey.net",
+                "productionStatus": "INACTIVE",
+                "stagingStatus": "ACTIVE"
+            },
+            {
+                "propertyId": "prp_200000",
+                "propertyVersion": 1,
+                "edgeHostname": "www.example.com.edgekey.net",
+                "productionStatus": "ACTIVE",
+                "stagingStatus": "ACTIVE"
+            }
+        ]
+    }
+}`,
+	}
+	tests := map[string]struct {
+		requests         []SearchRequest
+		expectedResponse *SearchResponse
+		withError        error
+	}{
+		"overlapping results are merged": {
+			requests: []SearchRequest{
+				{Key: SearchKeyHostname, Value: "www.example.com"},
+				{Key: SearchKeyEdgeHostname, Value: "www.example.com.edgekey.net"},
+			},
+			expectedResponse: &SearchResponse{
+				Versions: SearchItems{
+					Items: []SearchItem{
+						{PropertyID: "prp_175780", PropertyVersion: 2, Hostname: "www.example.com", ProductionStatus: "ACTIVE", StagingStatus: "INACTIVE"},
+						{PropertyID: "prp_175780", PropertyVersion: 3, Hostname: "www.example.com", ProductionStatus: "INACTIVE", StagingStatus: "ACTIVE"},
+						{PropertyID: "prp_200000", PropertyVersion: 1, EdgeHostname: "www.example.com.edgekey.net", ProductionStatus: "ACTIVE", StagingStatus: "ACTIVE"},
+					},
+				},
+			},
+		},
+		"no requests": {
+			expectedResponse: &SearchResponse{Versions: SearchItems{Items: []SearchItem{}}},
+		},
+		"one search fails": {
+			requests: []SearchRequest{
+				{Key: SearchKeyHostname, Value: "www.example.com"},
+				{Key: SearchKeyPropertyName, Value: "missing"},
+			},
+			withError: &Error{
+				Type:       "internal_error",
+				Title:      "Internal Server Error",
+				StatusCode: http.StatusInternalServerError,
+			},
+		},
+		"invalid request": {
+			requests: []SearchRequest{
+				{Key: SearchKeyHostname, Value: "www.example.com"},
+				{Key: "cpcode", Value: "1234"},
+			},
+			withError: ErrStructValidation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/papi/v1/search/find-by-value", r.URL.String())
+				body, err := ioutil.ReadAll(r.Body)
+				assert.NoError(t, err)
+				response, ok := responses[string(bytes.TrimSpace(body))]
+				if !ok {
+					w.WriteHeader(http.StatusInternalServerError)
+					_, err = w.Write([]byte(`{"type": "internal_error", "title": "Internal Server Error"}`))
+					assert.NoError(t, err)
+					return
+				}
+				w.WriteHeader(http.StatusOK)
+				_, err = w.Write([]byte(response))
+				assert.NoError(t, err)
+			}))
+			defer mockServer.Close()
+			client := mockAPIClient(t, mockServer)
+			result, err := client.SearchPropertiesMulti(context.Background(), test.requests)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResponse, result)
+		})
+	}
+}
+
+func TestPapi_SearchPropertiesMulti_ContextCanceled(t *testing.T) {
+	mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Fatalf("unexpected request: %s", r.URL)
+	}))
+	defer mockServer.Close()
+	client := mockAPIClient(t, mockServer)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := client.SearchPropertiesMulti(ctx, []SearchRequest{{Key: SearchKeyHostname, Value: "www.example.com"}})
+	assert.True(t, errors.Is(err, context.Canceled), "want: %s; got: %s", context.Canceled, err)
+}
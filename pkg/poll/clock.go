@@ -0,0 +1,102 @@
+// Package poll provides primitives used by the helpers which poll Akamai APIs until a resource reaches an expected state
+package poll
+
+import (
+	"sync"
+	"time"
+)
+
+type (
+	// Clock provides the current time and timers, it allows to replace the system clock in tests
+	Clock interface {
+		// Now returns the current time
+		Now() time.Time
+
+		// After waits for the duration to elapse and then sends the current time on the returned channel
+		After(d time.Duration) <-chan time.Time
+	}
+
+	systemClock struct{}
+
+	// FakeClock is a Clock which only moves forward when advanced manually, useful for testing polling logic
+	FakeClock struct {
+		mu      sync.Mutex
+		cond    *sync.Cond
+		now     time.Time
+		waiters []fakeWaiter
+	}
+
+	fakeWaiter struct {
+		until time.Time
+		ch    chan time.Time
+	}
+)
+
+// SystemClock returns a Clock backed by the system time
+func SystemClock() Clock {
+	return systemClock{}
+}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// NewFakeClock returns a FakeClock set to the provided time
+func NewFakeClock(now time.Time) *FakeClock {
+	c := &FakeClock{now: now}
+	c.cond = sync.NewCond(&c.mu)
+	return c
+}
+
+// Now returns the current time of the fake clock
+func (c *FakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// After returns a channel which receives the time once the clock is advanced by at least d
+func (c *FakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ch := make(chan time.Time, 1)
+	if d <= 0 {
+		ch <- c.now
+		return ch
+	}
+	c.waiters = append(c.waiters, fakeWaiter{until: c.now.Add(d), ch: ch})
+	c.cond.Broadcast()
+	return ch
+}
+
+// Advance moves the clock forward, firing all timers which are due
+func (c *FakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.now = c.now.Add(d)
+	pending := c.waiters[:0]
+	for _, w := range c.waiters {
+		if w.until.After(c.now) {
+			pending = append(pending, w)
+			continue
+		}
+		w.ch <- c.now
+	}
+	c.waiters = pending
+}
+
+// BlockUntil blocks until at least n timers are waiting on the clock
+func (c *FakeClock) BlockUntil(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for len(c.waiters) < n {
+		c.cond.Wait()
+	}
+}
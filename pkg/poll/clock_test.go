@@ -0,0 +1,74 @@
+package poll
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSystemClock(t *testing.T) {
+	clock := SystemClock()
+	before := time.Now()
+	assert.False(t, clock.Now().Before(before))
+
+	select {
+	case <-clock.After(time.Millisecond):
+	case <-time.After(time.Second):
+		t.Fatal("timer did not fire")
+	}
+}
+
+func TestFakeClock(t *testing.T) {
+	start := time.Date(2022, 10, 27, 12, 0, 0, 0, time.UTC)
+
+	t.Run("timer fires only after advancing past its deadline", func(t *testing.T) {
+		clock := NewFakeClock(start)
+		ch := clock.After(time.Minute)
+
+		clock.Advance(30 * time.Second)
+		assertNotFired(t, ch)
+
+		clock.Advance(30 * time.Second)
+		assert.Equal(t, start.Add(time.Minute), <-ch)
+		assert.Equal(t, start.Add(time.Minute), clock.Now())
+	})
+
+	t.Run("non-positive duration fires immediately", func(t *testing.T) {
+		clock := NewFakeClock(start)
+		assert.Equal(t, start, <-clock.After(0))
+	})
+
+	t.Run("multiple timers", func(t *testing.T) {
+		clock := NewFakeClock(start)
+		short := clock.After(time.Second)
+		long := clock.After(time.Hour)
+
+		clock.Advance(time.Minute)
+		assert.Equal(t, start.Add(time.Minute), <-short)
+		assertNotFired(t, long)
+
+		clock.Advance(time.Hour)
+		assert.Equal(t, start.Add(time.Hour+time.Minute), <-long)
+	})
+
+	t.Run("block until timer is registered", func(t *testing.T) {
+		clock := NewFakeClock(start)
+		fired := make(chan time.Time)
+		go func() {
+			fired <- <-clock.After(time.Second)
+		}()
+
+		clock.BlockUntil(1)
+		clock.Advance(time.Second)
+		assert.Equal(t, start.Add(time.Second), <-fired)
+	})
+}
+
+func assertNotFired(t *testing.T, ch <-chan time.Time) {
+	select {
+	case <-ch:
+		t.Fatal("timer should not have fired")
+	default:
+	}
+}
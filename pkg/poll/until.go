@@ -0,0 +1,121 @@
+package poll
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+type (
+	// ConditionFunc checks whether polling is done, e.g. by fetching a resource and inspecting its state
+	// Returning an error stops polling immediately
+	ConditionFunc func(ctx context.Context) (done bool, err error)
+
+	// IntervalFunc returns the time to wait after the given attempt, counted from 1
+	IntervalFunc func(attempt int) time.Duration
+
+	// Option configures Until
+	Option func(*config)
+
+	config struct {
+		interval     time.Duration
+		backoff      float64
+		maxInterval  time.Duration
+		intervalFunc IntervalFunc
+		maxAttempts  int
+		clock        Clock
+	}
+)
+
+const (
+	// DefaultInterval is the time between attempts used by Until unless configured otherwise
+	DefaultInterval = 10 * time.Second
+)
+
+var (
+	// ErrMaxAttempts is returned by Until when the condition is not met within the maximum number of attempts
+	ErrMaxAttempts = errors.New("condition not met within maximum number of attempts")
+)
+
+// WithInterval sets the time between attempts
+func WithInterval(interval time.Duration) Option {
+	return func(c *config) {
+		c.interval = interval
+	}
+}
+
+// WithBackoff multiplies the time between attempts by factor after every attempt, up to maxInterval
+// A maxInterval of 0 means the time between attempts is not limited
+func WithBackoff(factor float64, maxInterval time.Duration) Option {
+	return func(c *config) {
+		c.backoff = factor
+		c.maxInterval = maxInterval
+	}
+}
+
+// WithIntervalFunc computes the time between attempts with f, e.g. based on a Retry-After value returned by the API
+// It takes precedence over WithInterval and WithBackoff
+func WithIntervalFunc(f IntervalFunc) Option {
+	return func(c *config) {
+		c.intervalFunc = f
+	}
+}
+
+// WithMaxAttempts limits the number of times the condition is checked, 0 means no limit
+func WithMaxAttempts(attempts int) Option {
+	return func(c *config) {
+		c.maxAttempts = attempts
+	}
+}
+
+// WithClock sets the clock used to wait between attempts, e.g. a FakeClock in tests
+func WithClock(clock Clock) Option {
+	return func(c *config) {
+		c.clock = clock
+	}
+}
+
+// Until checks the condition immediately and then after every interval, until it is met, it returns an error,
+// the maximum number of attempts is reached or the context is done.
+// It returns nil once the condition is met, ErrMaxAttempts if attempts run out, or the error of the condition or the context.
+func Until(ctx context.Context, condition ConditionFunc, opts ...Option) error {
+	c := config{
+		interval: DefaultInterval,
+		clock:    SystemClock(),
+	}
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	interval := c.interval
+	for attempt := 1; ; attempt++ {
+		done, err := condition(ctx)
+		if err != nil {
+			return err
+		}
+		if done {
+			return nil
+		}
+		if c.maxAttempts > 0 && attempt >= c.maxAttempts {
+			return ErrMaxAttempts
+		}
+
+		wait := interval
+		if c.intervalFunc != nil {
+			wait = c.intervalFunc(attempt)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-c.clock.After(wait):
+		}
+
+		if c.backoff > 1 {
+			interval = time.Duration(float64(interval) * c.backoff)
+			if c.maxInterval > 0 && interval > c.maxInterval {
+				interval = c.maxInterval
+			}
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package poll
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUntil(t *testing.T) {
+	errFetch := errors.New("fetch failed")
+
+	tests := map[string]struct {
+		results       []bool
+		failAt        int
+		opts          []Option
+		expectedCalls int
+		withError     error
+	}{
+		"condition met on first attempt": {
+			results:       []bool{true},
+			expectedCalls: 1,
+		},
+		"condition met after a few attempts": {
+			results:       []bool{false, false, true},
+			expectedCalls: 3,
+		},
+		"maximum attempts reached": {
+			results:       []bool{false, false, false, true},
+			opts:          []Option{WithMaxAttempts(3)},
+			expectedCalls: 3,
+			withError:     ErrMaxAttempts,
+		},
+		"condition error stops polling": {
+			results:       []bool{false, false, true},
+			failAt:        2,
+			expectedCalls: 2,
+			withError:     errFetch,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var calls int
+			condition := func(context.Context) (bool, error) {
+				calls++
+				if calls == test.failAt {
+					return false, errFetch
+				}
+				return test.results[calls-1], nil
+			}
+			opts := append([]Option{WithInterval(0)}, test.opts...)
+
+			err := Until(context.Background(), condition, opts...)
+			assert.Equal(t, test.expectedCalls, calls)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestUntil_Backoff(t *testing.T) {
+	clock := NewFakeClock(time.Date(2022, 10, 27, 12, 0, 0, 0, time.UTC))
+	start := clock.Now()
+	var checked []time.Duration
+	condition := func(context.Context) (bool, error) {
+		checked = append(checked, clock.Now().Sub(start))
+		return len(checked) == 5, nil
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- Until(context.Background(), condition, WithClock(clock), WithInterval(time.Second), WithBackoff(2, 5*time.Second))
+	}()
+	for _, interval := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second} {
+		clock.BlockUntil(1)
+		clock.Advance(interval)
+	}
+
+	assert.NoError(t, <-done)
+	assert.Equal(t, []time.Duration{0, time.Second, 3 * time.Second, 7 * time.Second, 12 * time.Second}, checked)
+}
+
+func TestUntil_IntervalFunc(t *testing.T) {
+	clock := NewFakeClock(time.Date(2022, 10, 27, 12, 0, 0, 0, time.UTC))
+	var attempts []int
+	interval := func(attempt int) time.Duration {
+		attempts = append(attempts, attempt)
+		return time.Duration(attempt) * time.Minute
+	}
+	var calls int
+	condition := func(context.Context) (bool, error) {
+		calls++
+		return calls == 3, nil
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- Until(context.Background(), condition, WithClock(clock), WithInterval(time.Second), WithIntervalFunc(interval))
+	}()
+	clock.BlockUntil(1)
+	clock.Advance(time.Minute)
+	clock.BlockUntil(1)
+	clock.Advance(2 * time.Minute)
+
+	assert.NoError(t, <-done)
+	assert.Equal(t, []int{1, 2}, attempts)
+}
+
+func TestUntil_ContextCanceled(t *testing.T) {
+	clock := NewFakeClock(time.Date(2022, 10, 27, 12, 0, 0, 0, time.UTC))
+	ctx, cancel := context.WithCancel(context.Background())
+	var calls int
+	condition := func(context.Context) (bool, error) {
+		calls++
+		return false, nil
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- Until(ctx, condition, WithClock(clock), WithInterval(time.Minute))
+	}()
+	clock.BlockUntil(1)
+	cancel()
+
+	err := <-done
+	assert.True(t, errors.Is(err, context.Canceled), "want: %s; got: %s", context.Canceled, err)
+	assert.Equal(t, 1, calls)
+}
@@ -0,0 +1,92 @@
+package session
+
+import (
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/apex/log"
+)
+
+// Deprecation is the deprecation notice reported by the Deprecation and Sunset response headers of an endpoint
+type Deprecation struct {
+	// Endpoint is the method and path of the request, e.g. "GET /papi/v1/contracts"
+	Endpoint string
+	// Deprecation is the value of the Deprecation header, e.g. "true" or the date of the deprecation, empty if not reported
+	Deprecation string
+	// Sunset is the time after which the endpoint may stop responding, zero if not reported
+	Sunset time.Time
+}
+
+// DeprecationReporter is implemented by sessions which track the deprecation notices reported by responses
+// Callers type-assert the session to check if it is available
+type DeprecationReporter interface {
+	// Deprecations returns the deprecation notices reported by the Deprecation and Sunset response headers,
+	// one per endpoint, e.g. for tooling to alert users
+	Deprecations() []Deprecation
+}
+
+var _ DeprecationReporter = (*session)(nil)
+
+const (
+	headerDeprecation = "Deprecation"
+	headerSunset      = "Sunset"
+)
+
+// ParseDeprecation reads the deprecation notice from response headers
+// It returns false if neither the Deprecation nor the Sunset header is present
+func ParseDeprecation(h http.Header) (Deprecation, bool) {
+	deprecation, sunset := h.Get(headerDeprecation), h.Get(headerSunset)
+	if deprecation == "" && sunset == "" {
+		return Deprecation{}, false
+	}
+
+	d := Deprecation{
+		Deprecation: deprecation,
+	}
+	if t, err := http.ParseTime(sunset); err == nil {
+		d.Sunset = t.UTC()
+	}
+
+	return d, true
+}
+
+// Deprecations returns the deprecation notices reported so far, one per endpoint, ordered by endpoint
+func (s *session) Deprecations() []Deprecation {
+	s.deprecationsMu.Lock()
+	defer s.deprecationsMu.Unlock()
+
+	deprecations := make([]Deprecation, 0, len(s.deprecations))
+	for _, d := range s.deprecations {
+		deprecations = append(deprecations, d)
+	}
+	sort.Slice(deprecations, func(i, j int) bool {
+		return deprecations[i].Endpoint < deprecations[j].Endpoint
+	})
+	return deprecations
+}
+
+// updateDeprecations records the deprecation notice of the response, the warning is logged only the first time
+// an endpoint is reported as deprecated
+func (s *session) updateDeprecations(r *http.Request, h http.Header, log log.Interface) {
+	d, ok := ParseDeprecation(h)
+	if !ok {
+		return
+	}
+	d.Endpoint = r.Method + " " + r.URL.Path
+
+	s.deprecationsMu.Lock()
+	defer s.deprecationsMu.Unlock()
+
+	if _, seen := s.deprecations[d.Endpoint]; !seen {
+		if d.Sunset.IsZero() {
+			log.Warnf("%s is deprecated", d.Endpoint)
+		} else {
+			log.Warnf("%s is deprecated and may stop responding after %s", d.Endpoint, d.Sunset.Format(time.RFC3339))
+		}
+	}
+	if s.deprecations == nil {
+		s.deprecations = make(map[string]Deprecation)
+	}
+	s.deprecations[d.Endpoint] = d
+}
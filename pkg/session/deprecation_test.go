@@ -0,0 +1,100 @@
+package session
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/edgegrid"
+	"github.com/apex/log"
+	"github.com/apex/log/handlers/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDeprecation(t *testing.T) {
+	tests := map[string]struct {
+		headers  map[string]string
+		expected Deprecation
+		ok       bool
+	}{
+		"deprecation and sunset": {
+			headers:  map[string]string{"Deprecation": "true", "Sunset": "Sat, 01 Jul 2023 00:00:00 GMT"},
+			expected: Deprecation{Deprecation: "true", Sunset: time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC)},
+			ok:       true,
+		},
+		"deprecation only": {
+			headers:  map[string]string{"Deprecation": "@1688169600"},
+			expected: Deprecation{Deprecation: "@1688169600"},
+			ok:       true,
+		},
+		"invalid sunset": {
+			headers:  map[string]string{"Sunset": "next year"},
+			expected: Deprecation{},
+			ok:       true,
+		},
+		"no headers": {
+			headers: map[string]string{},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			h := http.Header{}
+			for k, v := range test.headers {
+				h.Set(k, v)
+			}
+			d, ok := ParseDeprecation(h)
+			assert.Equal(t, test.ok, ok)
+			assert.Equal(t, test.expected, d)
+		})
+	}
+}
+
+func TestSession_Deprecations(t *testing.T) {
+	mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/papi/v0/contracts" {
+			w.Header().Set("Deprecation", "true")
+			w.Header().Set("Sunset", "Sat, 01 Jul 2023 00:00:00 GMT")
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer mockServer.Close()
+
+	certPool := x509.NewCertPool()
+	certPool.AddCert(mockServer.Certificate())
+	httpClient := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				RootCAs: certPool,
+			},
+		},
+	}
+	handler := memory.New()
+	s, err := New(WithSigner(&edgegrid.Config{}), WithClient(httpClient), WithBaseURL(mockServer.URL), WithLog(&log.Logger{Handler: handler, Level: log.DebugLevel}))
+	require.NoError(t, err)
+
+	reporter, ok := s.(DeprecationReporter)
+	require.True(t, ok)
+	assert.Empty(t, reporter.Deprecations())
+	for _, path := range []string{"/papi/v0/contracts", "/papi/v1/groups", "/papi/v0/contracts?accountSwitchKey=1-ABCDE"} {
+		req, err := http.NewRequest(http.MethodGet, path, nil)
+		require.NoError(t, err)
+		_, err = s.Exec(req, nil)
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, []Deprecation{
+		{Endpoint: "GET /papi/v0/contracts", Deprecation: "true", Sunset: time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC)},
+	}, reporter.Deprecations())
+
+	var warnings []string
+	for _, entry := range handler.Entries {
+		if entry.Level == log.WarnLevel {
+			warnings = append(warnings, entry.Message)
+		}
+	}
+	assert.Equal(t, []string{"GET /papi/v0/contracts is deprecated and may stop responding after 2023-07-01T00:00:00Z"}, warnings)
+}
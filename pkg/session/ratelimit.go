@@ -0,0 +1,82 @@
+package session
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RateLimit is the account rate limit state reported by the X-RateLimit-* response headers
+type RateLimit struct {
+	// Limit is the number of requests allowed in the current window
+	Limit int
+	// Remaining is the number of requests left in the current window
+	Remaining int
+	// Reset is the time at which the window resets, zero if not reported
+	Reset time.Time
+}
+
+// RateLimiter is implemented by sessions which track the rate limit state reported by responses
+// It is kept apart from Session so that existing implementations of Session are not affected,
+// callers type-assert the session to check if it is available
+type RateLimiter interface {
+	// RateLimit returns the rate limit state reported by the most recent response
+	// The second value is false if no response has reported it yet
+	RateLimit() (RateLimit, bool)
+}
+
+var _ RateLimiter = (*session)(nil)
+
+const (
+	headerRateLimitLimit     = "X-RateLimit-Limit"
+	headerRateLimitRemaining = "X-RateLimit-Remaining"
+	headerRateLimitReset     = "X-RateLimit-Reset"
+	headerRateLimitNext      = "X-RateLimit-Next"
+)
+
+// ParseRateLimit reads the rate limit state from response headers
+// It returns false if the limit and remaining headers are not both present and valid
+// The reset time is read from X-RateLimit-Reset as unix seconds or, if missing, from X-RateLimit-Next as RFC3339 timestamp
+func ParseRateLimit(h http.Header) (RateLimit, bool) {
+	limit, err := strconv.Atoi(h.Get(headerRateLimitLimit))
+	if err != nil {
+		return RateLimit{}, false
+	}
+	remaining, err := strconv.Atoi(h.Get(headerRateLimitRemaining))
+	if err != nil {
+		return RateLimit{}, false
+	}
+
+	rl := RateLimit{
+		Limit:     limit,
+		Remaining: remaining,
+	}
+	if reset, err := strconv.ParseInt(h.Get(headerRateLimitReset), 10, 64); err == nil {
+		rl.Reset = time.Unix(reset, 0).UTC()
+	} else if next, err := time.Parse(time.RFC3339, h.Get(headerRateLimitNext)); err == nil {
+		rl.Reset = next
+	}
+
+	return rl, true
+}
+
+// RateLimit returns the rate limit state from the most recent response which reported it
+func (s *session) RateLimit() (RateLimit, bool) {
+	s.rateLimitMu.Lock()
+	defer s.rateLimitMu.Unlock()
+
+	return s.rateLimit, s.rateLimitSet
+}
+
+func (s *session) updateRateLimit(h http.Header) {
+	rl, ok := ParseRateLimit(h)
+	if !ok {
+		return
+	}
+
+	s.rateLimitMu.Lock()
+	defer s.rateLimitMu.Unlock()
+
+	s.rateLimit = rl
+	s.rateLimitSet = true
+}
@@ -0,0 +1,135 @@
+package session
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/edgegrid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRateLimit(t *testing.T) {
+	tests := map[string]struct {
+		headers    map[string]string
+		expected   RateLimit
+		expectedOK bool
+	}{
+		"limit, remaining and reset": {
+			headers: map[string]string{
+				"X-RateLimit-Limit":     "100",
+				"X-RateLimit-Remaining": "42",
+				"X-RateLimit-Reset":     "1666872000",
+			},
+			expected: RateLimit{
+				Limit:     100,
+				Remaining: 42,
+				Reset:     time.Date(2022, 10, 27, 12, 0, 0, 0, time.UTC),
+			},
+			expectedOK: true,
+		},
+		"reset from next": {
+			headers: map[string]string{
+				"X-RateLimit-Limit":     "20",
+				"X-RateLimit-Remaining": "0",
+				"X-RateLimit-Next":      "2022-10-27T12:00:05Z",
+			},
+			expected: RateLimit{
+				Limit:     20,
+				Remaining: 0,
+				Reset:     time.Date(2022, 10, 27, 12, 0, 5, 0, time.UTC),
+			},
+			expectedOK: true,
+		},
+		"no reset": {
+			headers: map[string]string{
+				"X-RateLimit-Limit":     "20",
+				"X-RateLimit-Remaining": "19",
+			},
+			expected: RateLimit{
+				Limit:     20,
+				Remaining: 19,
+			},
+			expectedOK: true,
+		},
+		"missing remaining": {
+			headers: map[string]string{
+				"X-RateLimit-Limit": "20",
+			},
+		},
+		"invalid limit": {
+			headers: map[string]string{
+				"X-RateLimit-Limit":     "many",
+				"X-RateLimit-Remaining": "19",
+			},
+		},
+		"no headers": {},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			h := http.Header{}
+			for k, v := range test.headers {
+				h.Set(k, v)
+			}
+			res, ok := ParseRateLimit(h)
+			assert.Equal(t, test.expectedOK, ok)
+			assert.Equal(t, test.expected, res)
+		})
+	}
+}
+
+func TestSession_RateLimit(t *testing.T) {
+	responses := []map[string]string{
+		{"X-RateLimit-Limit": "100", "X-RateLimit-Remaining": "99"},
+		{},
+		{"X-RateLimit-Limit": "100", "X-RateLimit-Remaining": "98", "X-RateLimit-Reset": "1666872000"},
+	}
+	var call int
+	mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for k, v := range responses[call] {
+			w.Header().Set(k, v)
+		}
+		call++
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer mockServer.Close()
+
+	certPool := x509.NewCertPool()
+	certPool.AddCert(mockServer.Certificate())
+	httpClient := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				RootCAs: certPool,
+			},
+		},
+	}
+	s, err := New(WithSigner(&edgegrid.Config{}), WithClient(httpClient))
+	require.NoError(t, err)
+
+	rateLimiter, ok := s.(RateLimiter)
+	require.True(t, ok)
+	_, ok = rateLimiter.RateLimit()
+	assert.False(t, ok)
+
+	expected := []RateLimit{
+		{Limit: 100, Remaining: 99},
+		// response without headers keeps the previous state
+		{Limit: 100, Remaining: 99},
+		{Limit: 100, Remaining: 98, Reset: time.Date(2022, 10, 27, 12, 0, 0, 0, time.UTC)},
+	}
+	for _, exp := range expected {
+		req, err := http.NewRequest(http.MethodGet, mockServer.URL, nil)
+		require.NoError(t, err)
+		_, err = s.Exec(req, nil)
+		require.NoError(t, err)
+
+		rl, ok := rateLimiter.RateLimit()
+		assert.True(t, ok)
+		assert.Equal(t, exp, rl)
+	}
+}
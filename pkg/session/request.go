@@ -2,12 +2,15 @@ package session
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"net/http/httputil"
+	"time"
 )
 
 var (
@@ -17,8 +20,16 @@ var (
 	ErrMarshaling = errors.New("marshaling input")
 	// ErrUnmarshaling represents unmarshaling error
 	ErrUnmarshaling = errors.New("unmarshaling output")
+	// ErrResponseBodyTooLarge is returned when reading a response body exceeding the size set with WithMaxResponseBodySize
+	ErrResponseBodyTooLarge = errors.New("response body too large")
 )
 
+// protectedHeaders are the headers required for request signing, which cannot be set through context headers
+var protectedHeaders = map[string]bool{
+	"Authorization": true,
+	"Host":          true,
+}
+
 // Exec will sign and execute the request using the client edgegrid.Config
 func (s *session) Exec(r *http.Request, out interface{}, in ...interface{}) (*http.Response, error) {
 	if len(in) > 1 {
@@ -29,6 +40,11 @@ func (s *session) Exec(r *http.Request, out interface{}, in ...interface{}) (*ht
 	// Apply any context header overrides
 	if o, ok := r.Context().Value(contextOptionKey).(*contextOptions); ok {
 		for k, v := range o.header {
+			k = http.CanonicalHeaderKey(k)
+			if protectedHeaders[k] {
+				log.Warnf("context header %q cannot be overridden, ignoring it", k)
+				continue
+			}
 			r.Header[k] = v
 		}
 	}
@@ -46,6 +62,12 @@ func (s *session) Exec(r *http.Request, out interface{}, in ...interface{}) (*ht
 		r.URL.Scheme = "https"
 	}
 
+	if s.baseURL != nil {
+		r.URL.Scheme = s.baseURL.Scheme
+		r.URL.Host = s.baseURL.Host
+		r.Host = ""
+	}
+
 	if len(in) > 0 {
 		data, err := json.Marshal(in[0])
 		if err != nil {
@@ -56,13 +78,24 @@ func (s *session) Exec(r *http.Request, out interface{}, in ...interface{}) (*ht
 		r.ContentLength = int64(len(data))
 	}
 
-	s.client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
-		return s.Sign(req)
+	// the client is shared by all requests of the session, so set the redirect handler only once
+	s.checkRedirectOnce.Do(func() {
+		s.client.CheckRedirect = func(req *http.Request, via []*http.Request) error {
+			return s.Sign(req)
+		}
+	})
+
+	// the hedged request is cloned before signing, as signing modifies the query, e.g. adding the account switch key
+	var hedge *http.Request
+	if s.hedgeDelay > 0 && r.Method == http.MethodGet {
+		hedge = r.Clone(r.Context())
 	}
 
 	if err := s.Sign(r); err != nil {
 		return nil, err
 	}
+	// logged after signing, which may add query parameters, so that it is the exact URL sent
+	log.Debugf("%s %s", r.Method, r.URL)
 
 	if s.trace {
 		data, err := httputil.DumpRequestOut(r, true)
@@ -73,10 +106,15 @@ func (s *session) Exec(r *http.Request, out interface{}, in ...interface{}) (*ht
 		}
 	}
 
-	resp, err := s.client.Do(r)
+	resp, err := s.doTraced(r, hedge)
 	if err != nil {
 		return nil, err
 	}
+	s.updateRateLimit(resp.Header)
+	s.updateDeprecations(r, resp.Header, log)
+	if s.maxResponseBodySize > 0 {
+		resp.Body = &limitedBody{ReadCloser: resp.Body, remaining: s.maxResponseBodySize, limit: s.maxResponseBodySize}
+	}
 
 	if s.trace {
 		data, err := httputil.DumpResponse(resp, true)
@@ -96,7 +134,7 @@ func (s *session) Exec(r *http.Request, out interface{}, in ...interface{}) (*ht
 			return nil, err
 		}
 
-		if err := json.Unmarshal(data, out); err != nil {
+		if err := s.decode(data, out); err != nil {
 			return nil, fmt.Errorf("%w: %s", ErrUnmarshaling, err)
 		}
 	}
@@ -104,6 +142,136 @@ func (s *session) Exec(r *http.Request, out interface{}, in ...interface{}) (*ht
 	return resp, nil
 }
 
+// decode unmarshals the response body, using json.Number for untyped numbers if enabled with WithUseNumber
+func (s *session) decode(data []byte, out interface{}) error {
+	if !s.useNumber {
+		return json.Unmarshal(data, out)
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(out); err != nil {
+		return err
+	}
+	// match json.Unmarshal, which rejects data following the value
+	if _, err := dec.Token(); err != io.EOF {
+		return errors.New("invalid character after top-level value")
+	}
+	return nil
+}
+
+// do sends the request, hedging it with the unsigned hedge request if there is one
+func (s *session) do(r, hedge *http.Request) (*http.Response, error) {
+	if hedge == nil {
+		return s.client.Do(r)
+	}
+	return s.doHedged(r, hedge)
+}
+
+type hedgedResult struct {
+	resp    *http.Response
+	err     error
+	attempt int
+}
+
+// doHedged sends the request and, if there is no response within the hedge delay, sends the hedge request,
+// which is an unsigned copy of it. The first successful response wins and the other request is canceled.
+func (s *session) doHedged(r, hedge *http.Request) (*http.Response, error) {
+	if err := s.Sign(hedge); err != nil {
+		return nil, err
+	}
+
+	results := make(chan hedgedResult, 2)
+	var cancels []context.CancelFunc
+	send := func(req *http.Request) {
+		ctx, cancel := context.WithCancel(req.Context())
+		cancels = append(cancels, cancel)
+		attempt := len(cancels) - 1
+		go func() {
+			resp, err := s.client.Do(req.WithContext(ctx))
+			results <- hedgedResult{resp: resp, err: err, attempt: attempt}
+		}()
+	}
+
+	send(r)
+	timer := time.NewTimer(s.hedgeDelay)
+	defer timer.Stop()
+	hedgeTimer := timer.C
+
+	var err error
+	for received := 0; received < len(cancels); {
+		select {
+		case <-hedgeTimer:
+			hedgeTimer = nil
+			s.Log(r.Context()).Debugf("no response within %s, sending hedged request", s.hedgeDelay)
+			send(hedge)
+		case res := <-results:
+			received++
+			if res.err != nil {
+				err = res.err
+				cancels[res.attempt]()
+				continue
+			}
+			for i, cancel := range cancels {
+				if i != res.attempt {
+					cancel()
+				}
+			}
+			go discardHedged(results, len(cancels)-received)
+			res.resp.Body = &cancelOnClose{ReadCloser: res.resp.Body, cancel: cancels[res.attempt]}
+			return res.resp, nil
+		}
+	}
+	return nil, err
+}
+
+// discardHedged closes the responses of the requests which lost the race
+func discardHedged(results <-chan hedgedResult, n int) {
+	for i := 0; i < n; i++ {
+		res := <-results
+		if res.resp != nil {
+			res.resp.Body.Close() // nolint:errcheck
+		}
+	}
+}
+
+// cancelOnClose releases the request context once the response body is closed
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c *cancelOnClose) Close() error {
+	defer c.cancel()
+	return c.ReadCloser.Close()
+}
+
+// limitedBody fails reads once more than limit bytes are read from the body, the error is kept for subsequent reads
+type limitedBody struct {
+	io.ReadCloser
+	remaining int64
+	limit     int64
+	err       error
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	if b.err != nil {
+		return 0, b.err
+	}
+	// read one byte more than allowed, to tell a body of exactly the limit from a larger one
+	if int64(len(p)) > b.remaining+1 {
+		p = p[:b.remaining+1]
+	}
+	n, err := b.ReadCloser.Read(p)
+	if int64(n) <= b.remaining {
+		b.remaining -= int64(n)
+		return n, err
+	}
+	n = int(b.remaining)
+	b.remaining = 0
+	b.err = fmt.Errorf("%w: exceeds %d bytes", ErrResponseBodyTooLarge, b.limit)
+	return n, b.err
+}
+
 // Sign will only sign a request
 func (s *session) Sign(r *http.Request) error {
 	s.signer.SignRequest(r)
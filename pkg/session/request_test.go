@@ -1,15 +1,24 @@
 package session
 
 import (
+	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/edgegrid"
+	"github.com/apex/log"
+	"github.com/apex/log/handlers/memory"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -198,3 +207,397 @@ func TestSession_Exec(t *testing.T) {
 		})
 	}
 }
+
+func TestSession_Exec_WithBaseURL(t *testing.T) {
+	mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/test/path?param=value", r.URL.String())
+		assert.NotEmpty(t, r.Header.Get("Authorization"))
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"a":"text","b":1}`))
+		assert.NoError(t, err)
+	}))
+	defer mockServer.Close()
+
+	certPool := x509.NewCertPool()
+	certPool.AddCert(mockServer.Certificate())
+	httpClient := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				RootCAs: certPool,
+			},
+		},
+	}
+	s, err := New(WithSigner(&edgegrid.Config{
+		Host: "akab-host.luna.akamaiapis.net",
+	}), WithClient(httpClient), WithBaseURL(mockServer.URL))
+	require.NoError(t, err)
+
+	req, err := http.NewRequest(http.MethodGet, "/test/path?param=value", nil)
+	require.NoError(t, err)
+
+	var out testStruct
+	_, err = s.Exec(req, &out)
+	require.NoError(t, err)
+	assert.Equal(t, testStruct{A: "text", B: 1}, out)
+	assert.Equal(t, mockServer.URL, "https://"+req.URL.Host)
+}
+
+func TestSession_Exec_LogsRequestURL(t *testing.T) {
+	mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"a":"text","b":1}`))
+		assert.NoError(t, err)
+	}))
+	defer mockServer.Close()
+
+	certPool := x509.NewCertPool()
+	certPool.AddCert(mockServer.Certificate())
+	httpClient := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				RootCAs: certPool,
+			},
+		},
+	}
+	handler := memory.New()
+	s, err := New(WithSigner(&edgegrid.Config{
+		Host:       "akab-host.luna.akamaiapis.net",
+		AccountKey: "1-ABCDE",
+	}), WithClient(httpClient), WithBaseURL(mockServer.URL), WithLog(&log.Logger{Handler: handler, Level: log.DebugLevel}))
+	require.NoError(t, err)
+
+	req, err := http.NewRequest(http.MethodGet, "/papi/v1/properties?groupId=grp_15225&contractId=ctr_1-1TJZH5", nil)
+	require.NoError(t, err)
+	_, err = s.Exec(req, &testStruct{})
+	require.NoError(t, err)
+
+	var messages []string
+	for _, entry := range handler.Entries {
+		messages = append(messages, entry.Message)
+	}
+	assert.Contains(t, messages, fmt.Sprintf("GET %s/papi/v1/properties?accountSwitchKey=1-ABCDE&contractId=ctr_1-1TJZH5&groupId=grp_15225", mockServer.URL))
+}
+
+func TestSession_Exec_WithRequestHedging(t *testing.T) {
+	tests := map[string]struct {
+		method           string
+		firstDelay       time.Duration
+		expectedRequests int32
+		expectedBody     testStruct
+		maxDuration      time.Duration
+	}{
+		"slow first GET, hedged request wins": {
+			method:           http.MethodGet,
+			firstDelay:       2 * time.Second,
+			expectedRequests: 2,
+			expectedBody:     testStruct{A: "attempt", B: 2},
+			maxDuration:      time.Second,
+		},
+		"fast GET, no hedged request": {
+			method:           http.MethodGet,
+			expectedRequests: 1,
+			expectedBody:     testStruct{A: "attempt", B: 1},
+			maxDuration:      time.Second,
+		},
+		"slow POST is never hedged": {
+			method:           http.MethodPost,
+			firstDelay:       200 * time.Millisecond,
+			expectedRequests: 1,
+			expectedBody:     testStruct{A: "attempt", B: 1},
+			maxDuration:      time.Second,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var requests int32
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempt := atomic.AddInt32(&requests, 1)
+				assert.Equal(t, test.method, r.Method)
+				// the account switch key is added once by signing, also to the hedged request
+				assert.Equal(t, "accountSwitchKey=1-ABCDE", r.URL.RawQuery)
+				if attempt == 1 && test.firstDelay > 0 {
+					select {
+					case <-time.After(test.firstDelay):
+					case <-r.Context().Done():
+						return
+					}
+				}
+				w.WriteHeader(http.StatusOK)
+				_, err := w.Write([]byte(fmt.Sprintf(`{"a":"attempt","b":%d}`, attempt)))
+				assert.NoError(t, err)
+			}))
+			defer mockServer.Close()
+
+			certPool := x509.NewCertPool()
+			certPool.AddCert(mockServer.Certificate())
+			httpClient := &http.Client{
+				Transport: &http.Transport{
+					TLSClientConfig: &tls.Config{
+						RootCAs: certPool,
+					},
+				},
+			}
+			serverURL, err := url.Parse(mockServer.URL)
+			require.NoError(t, err)
+			s, err := New(WithSigner(&edgegrid.Config{
+				Host:       serverURL.Host,
+				AccountKey: "1-ABCDE",
+			}), WithClient(httpClient), WithRequestHedging(50*time.Millisecond))
+			require.NoError(t, err)
+
+			req, err := http.NewRequest(test.method, "/test/path", nil)
+			require.NoError(t, err)
+
+			var out testStruct
+			start := time.Now()
+			_, err = s.Exec(req, &out)
+			require.NoError(t, err)
+			assert.Less(t, int64(time.Since(start)), int64(test.maxDuration))
+			assert.Equal(t, test.expectedBody, out)
+			assert.Equal(t, test.expectedRequests, atomic.LoadInt32(&requests))
+		})
+	}
+}
+
+func TestSession_Exec_WithRequestHedging_ContextDeadline(t *testing.T) {
+	mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(2 * time.Second):
+		case <-r.Context().Done():
+		}
+	}))
+	defer mockServer.Close()
+
+	certPool := x509.NewCertPool()
+	certPool.AddCert(mockServer.Certificate())
+	httpClient := &http.Client{
+		Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{
+				RootCAs: certPool,
+			},
+		},
+	}
+	serverURL, err := url.Parse(mockServer.URL)
+	require.NoError(t, err)
+	s, err := New(WithSigner(&edgegrid.Config{
+		Host: serverURL.Host,
+	}), WithClient(httpClient), WithRequestHedging(20*time.Millisecond))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "/test/path", nil)
+	require.NoError(t, err)
+
+	start := time.Now()
+	_, err = s.Exec(req, nil)
+	assert.True(t, errors.Is(err, context.DeadlineExceeded), "want: %s; got: %s", context.DeadlineExceeded, err)
+	assert.Less(t, int64(time.Since(start)), int64(time.Second))
+}
+
+func TestSession_Exec_WithContextHeaders(t *testing.T) {
+	tests := map[string]struct {
+		headers         http.Header
+		expectedHeaders map[string]string
+	}{
+		"custom header is added": {
+			headers: http.Header{
+				"X-Akamai-Debug": []string{"true"},
+			},
+			expectedHeaders: map[string]string{
+				"X-Akamai-Debug": "true",
+			},
+		},
+		"request headers can be overridden": {
+			headers: http.Header{
+				"Content-Type": []string{"text/plain"},
+			},
+			expectedHeaders: map[string]string{
+				"Content-Type": "text/plain",
+			},
+		},
+		"authorization cannot be overridden": {
+			headers: http.Header{
+				"Authorization":  []string{"Bearer token"},
+				"authorization":  []string{"Basic abc"},
+				"X-Akamai-Debug": []string{"true"},
+			},
+			expectedHeaders: map[string]string{
+				"X-Akamai-Debug": "true",
+			},
+		},
+		"host cannot be overridden": {
+			headers: http.Header{
+				"Host": []string{"evil.example.com"},
+			},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				for k, v := range test.expectedHeaders {
+					assert.Equal(t, v, r.Header.Get(k))
+				}
+				assert.True(t, strings.HasPrefix(r.Header.Get("Authorization"), "EG1-HMAC-SHA256 "), r.Header.Get("Authorization"))
+				assert.Len(t, r.Header.Values("Authorization"), 1)
+				assert.NotEqual(t, "evil.example.com", r.Host)
+				w.WriteHeader(http.StatusOK)
+			}))
+			defer mockServer.Close()
+
+			certPool := x509.NewCertPool()
+			certPool.AddCert(mockServer.Certificate())
+			httpClient := &http.Client{
+				Transport: &http.Transport{
+					TLSClientConfig: &tls.Config{
+						RootCAs: certPool,
+					},
+				},
+			}
+			s, err := New(WithSigner(&edgegrid.Config{}), WithClient(httpClient))
+			require.NoError(t, err)
+
+			ctx := ContextWithOptions(context.Background(), WithContextHeaders(test.headers))
+			req, err := http.NewRequestWithContext(ctx, http.MethodGet, mockServer.URL, nil)
+			require.NoError(t, err)
+			_, err = s.Exec(req, nil)
+			require.NoError(t, err)
+			for k := range test.headers {
+				if protectedHeaders[http.CanonicalHeaderKey(k)] {
+					continue
+				}
+				assert.Equal(t, test.headers[k], req.Header[k])
+			}
+		})
+	}
+}
+
+func TestSession_Exec_WithMaxResponseBodySize(t *testing.T) {
+	body := `{"a":"text","b":1}`
+	tests := map[string]struct {
+		maxSize        int64
+		responseStatus int
+		expected       testStruct
+		withError      error
+	}{
+		"body within the limit": {
+			maxSize:        int64(len(body)),
+			responseStatus: http.StatusOK,
+			expected:       testStruct{A: "text", B: 1},
+		},
+		"body exceeding the limit": {
+			maxSize:        int64(len(body)) - 1,
+			responseStatus: http.StatusOK,
+			withError:      ErrResponseBodyTooLarge,
+		},
+		"error body exceeding the limit": {
+			maxSize:        8,
+			responseStatus: http.StatusInternalServerError,
+			withError:      ErrResponseBodyTooLarge,
+		},
+		"no limit": {
+			responseStatus: http.StatusOK,
+			expected:       testStruct{A: "text", B: 1},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(test.responseStatus)
+				_, err := w.Write([]byte(body))
+				assert.NoError(t, err)
+			}))
+			defer mockServer.Close()
+			certPool := x509.NewCertPool()
+			certPool.AddCert(mockServer.Certificate())
+			httpClient := &http.Client{
+				Transport: &http.Transport{
+					TLSClientConfig: &tls.Config{
+						RootCAs: certPool,
+					},
+				},
+			}
+			s, err := New(WithSigner(&edgegrid.Config{}), WithClient(httpClient), WithBaseURL(mockServer.URL), WithMaxResponseBodySize(test.maxSize))
+			require.NoError(t, err)
+
+			req, err := http.NewRequest(http.MethodGet, "/papi/v1/contracts", nil)
+			require.NoError(t, err)
+			var out testStruct
+			resp, err := s.Exec(req, &out)
+			if err == nil && resp.StatusCode != http.StatusOK {
+				// error bodies are read by the caller
+				_, err = ioutil.ReadAll(resp.Body)
+			}
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, test.expected, out)
+		})
+	}
+}
+
+func TestSession_Exec_WithUseNumber(t *testing.T) {
+	body := `{"epoch":1700000000000000123,"attributes":{"epoch":1700000000000000123}}`
+	type epochStruct struct {
+		Epoch      int64                  `json:"epoch"`
+		Attributes map[string]interface{} `json:"attributes"`
+	}
+	tests := map[string]struct {
+		useNumber     bool
+		responseBody  string
+		expectedValue interface{}
+		withError     error
+	}{
+		"untyped number decoded as json.Number": {
+			useNumber:     true,
+			responseBody:  body,
+			expectedValue: json.Number("1700000000000000123"),
+		},
+		"untyped number decoded as float64 by default": {
+			responseBody:  body,
+			expectedValue: float64(1700000000000000123),
+		},
+		"data after the value": {
+			useNumber:    true,
+			responseBody: body + `{}`,
+			withError:    ErrUnmarshaling,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+				_, err := w.Write([]byte(test.responseBody))
+				assert.NoError(t, err)
+			}))
+			defer mockServer.Close()
+			certPool := x509.NewCertPool()
+			certPool.AddCert(mockServer.Certificate())
+			httpClient := &http.Client{
+				Transport: &http.Transport{
+					TLSClientConfig: &tls.Config{
+						RootCAs: certPool,
+					},
+				},
+			}
+			s, err := New(WithSigner(&edgegrid.Config{}), WithClient(httpClient), WithBaseURL(mockServer.URL), WithUseNumber(test.useNumber))
+			require.NoError(t, err)
+
+			req, err := http.NewRequest(http.MethodGet, "/papi/v1/contracts", nil)
+			require.NoError(t, err)
+			var out epochStruct
+			_, err = s.Exec(req, &out)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, int64(1700000000000000123), out.Epoch)
+			assert.Equal(t, test.expectedValue, out.Attributes["epoch"])
+		})
+	}
+}
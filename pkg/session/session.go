@@ -3,9 +3,15 @@ package session
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net"
 	"net/http"
+	"net/url"
 	"runtime"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/edgegrid"
 	"github.com/apex/log"
@@ -40,6 +46,40 @@ type (
 		log       log.Interface
 		trace     bool
 		userAgent string
+		baseURL   *url.URL
+
+		hedgeDelay time.Duration
+
+		maxResponseBodySize int64
+
+		useNumber bool
+
+		tracer Tracer
+
+		checkRedirectOnce sync.Once
+
+		rateLimitMu  sync.Mutex
+		rateLimit    RateLimit
+		rateLimitSet bool
+
+		deprecationsMu sync.Mutex
+		deprecations   map[string]Deprecation
+
+		dialer *DialerConfig
+	}
+
+	// DialerConfig configures how connections to the API are established, see WithDialer
+	DialerConfig struct {
+		// Timeout is the maximum time to wait for a connection to be established, DefaultDialTimeout if not set
+		Timeout time.Duration
+		// KeepAlive is the interval between TCP keep-alive probes, DefaultDialKeepAlive if not set.
+		// A negative value disables keep-alive probes
+		KeepAlive time.Duration
+		// FallbackDelay is the time to wait for an IPv6 connection to be established before falling back to IPv4
+		// when the host has both addresses, 300 milliseconds if not set. A negative value disables the fallback
+		FallbackDelay time.Duration
+		// Network restricts connections to IPv4 with "tcp4" or to IPv6 with "tcp6", both are used if not set
+		Network string
 	}
 
 	contextOptions struct {
@@ -58,11 +98,23 @@ type (
 
 var (
 	contextOptionKey = contextKey("sessionContext")
+
+	// ErrInvalidBaseURL is returned when the base URL override is not a valid https URL
+	ErrInvalidBaseURL = errors.New("invalid base URL")
+
+	// ErrInvalidDialer is returned when the dialer configuration is invalid or cannot be applied to the client transport
+	ErrInvalidDialer = errors.New("invalid dialer")
 )
 
 const (
 	// Version is the client version
 	Version = "2.0.0"
+
+	// DefaultDialTimeout is the connection timeout used by WithDialer if the configuration does not set one
+	DefaultDialTimeout = 30 * time.Second
+
+	// DefaultDialKeepAlive is the keep-alive interval used by WithDialer if the configuration does not set one
+	DefaultDialKeepAlive = 30 * time.Second
 )
 
 // New returns a new session
@@ -82,6 +134,18 @@ func New(opts ...Option) (Session, error) {
 		opt(s)
 	}
 
+	if s.baseURL != nil {
+		if s.baseURL.Scheme != "https" || s.baseURL.Host == "" || (s.baseURL.Path != "" && s.baseURL.Path != "/") {
+			return nil, fmt.Errorf("%w: %q must be in the form of https://host[:port]", ErrInvalidBaseURL, s.baseURL)
+		}
+	}
+
+	if s.dialer != nil {
+		if err := s.applyDialer(); err != nil {
+			return nil, err
+		}
+	}
+
 	if s.signer == nil {
 		config, err := edgegrid.New()
 		if err != nil {
@@ -139,6 +203,97 @@ func WithHTTPTracing(trace bool) Option {
 	}
 }
 
+// WithBaseURL overrides the host of all requests, e.g. to run against sandbox endpoints or a test server
+// Requests are still signed using the configured credentials
+func WithBaseURL(baseURL string) Option {
+	return func(s *session) {
+		u, err := url.Parse(baseURL)
+		if err != nil {
+			// keep the raw value so that New reports it as invalid
+			u = &url.URL{Opaque: baseURL}
+		}
+		s.baseURL = u
+	}
+}
+
+// WithRequestHedging enables hedged GET requests: when a GET request has not been responded to within the delay,
+// an identical request is sent and the first response is used, while the other request is canceled.
+// Only GET requests are hedged, as they are idempotent. Disabled by default.
+func WithRequestHedging(delay time.Duration) Option {
+	return func(s *session) {
+		s.hedgeDelay = delay
+	}
+}
+
+// WithMaxResponseBodySize limits the number of bytes read from a response body, protecting the caller from
+// exhausting memory on an enormous response. Reading past the limit, both when decoding a successful response
+// and when reading an error body, fails with ErrResponseBodyTooLarge. A size of 0 means no limit, which is the default.
+func WithMaxResponseBodySize(size int64) Option {
+	return func(s *session) {
+		s.maxResponseBodySize = size
+	}
+}
+
+// WithUseNumber decodes numbers into interface{} values of the response as json.Number instead of float64,
+// so that large values, such as epoch timestamps in nanoseconds, are preserved exactly.
+// Typed integer fields are decoded exactly regardless of this option. Disabled by default.
+func WithUseNumber(useNumber bool) Option {
+	return func(s *session) {
+		s.useNumber = useNumber
+	}
+}
+
+// WithDialer configures the dialer of the client transport, e.g. to shorten the connection timeout or to avoid IPv6
+// on hosts where it is unreliable. It is applied to a copy of the client, so that a shared client, such as http.DefaultClient,
+// is not modified. The client transport must be an *http.Transport, or nil to use a copy of http.DefaultTransport
+func WithDialer(config DialerConfig) Option {
+	return func(s *session) {
+		s.dialer = &config
+	}
+}
+
+// applyDialer replaces the client with a copy using a transport which dials with the dialer configuration
+func (s *session) applyDialer() error {
+	network := s.dialer.Network
+	switch network {
+	case "":
+		network = "tcp"
+	case "tcp", "tcp4", "tcp6":
+	default:
+		return fmt.Errorf("%w: unsupported network %q, must be one of tcp, tcp4 or tcp6", ErrInvalidDialer, network)
+	}
+
+	var transport *http.Transport
+	switch t := s.client.Transport.(type) {
+	case nil:
+		transport = http.DefaultTransport.(*http.Transport).Clone()
+	case *http.Transport:
+		transport = t.Clone()
+	default:
+		return fmt.Errorf("%w: the client transport %T is not an *http.Transport", ErrInvalidDialer, t)
+	}
+
+	dialer := &net.Dialer{
+		Timeout:       s.dialer.Timeout,
+		KeepAlive:     s.dialer.KeepAlive,
+		FallbackDelay: s.dialer.FallbackDelay,
+	}
+	if dialer.Timeout == 0 {
+		dialer.Timeout = DefaultDialTimeout
+	}
+	if dialer.KeepAlive == 0 {
+		dialer.KeepAlive = DefaultDialKeepAlive
+	}
+	transport.DialContext = func(ctx context.Context, _, addr string) (net.Conn, error) {
+		return dialer.DialContext(ctx, network, addr)
+	}
+
+	client := *s.client
+	client.Transport = transport
+	s.client = &client
+	return nil
+}
+
 // Log will return the context logger, or the session log
 func (s *session) Log(ctx context.Context) log.Interface {
 	if o := ctx.Value(contextOptionKey); o != nil {
@@ -178,7 +333,8 @@ func WithContextLog(l log.Interface) ContextOption {
 	}
 }
 
-// WithContextHeaders sets the context headers
+// WithContextHeaders sets the context headers, which are added to the request, e.g. a debug header requested by support
+// The Authorization and Host headers cannot be overridden, as they are required for signing the request
 func WithContextHeaders(h http.Header) ContextOption {
 	return func(o *contextOptions) {
 		o.header = h
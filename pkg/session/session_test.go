@@ -2,10 +2,13 @@ package session
 
 import (
 	"context"
+	"errors"
 	"net/http"
+	"net/http/httptest"
 	"runtime"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/edgegrid"
 	"github.com/apex/log"
@@ -121,3 +124,124 @@ func TestSession_Log(t *testing.T) {
 		})
 	}
 }
+
+func TestNew_WithBaseURL(t *testing.T) {
+	tests := map[string]struct {
+		baseURL   string
+		withError error
+	}{
+		"valid https URL": {
+			baseURL: "https://sandbox.example.com",
+		},
+		"valid https URL with port and trailing slash": {
+			baseURL: "https://sandbox.example.com:8443/",
+		},
+		"http URL": {
+			baseURL:   "http://sandbox.example.com",
+			withError: ErrInvalidBaseURL,
+		},
+		"missing scheme": {
+			baseURL:   "sandbox.example.com",
+			withError: ErrInvalidBaseURL,
+		},
+		"URL with path": {
+			baseURL:   "https://sandbox.example.com/papi",
+			withError: ErrInvalidBaseURL,
+		},
+		"malformed URL": {
+			baseURL:   "https://sandbox example.com:port",
+			withError: ErrInvalidBaseURL,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := New(WithSigner(&edgegrid.Config{}), WithBaseURL(test.baseURL))
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestNew_WithDialer(t *testing.T) {
+	mockServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer mockServer.Close()
+
+	tests := map[string]struct {
+		client        *http.Client
+		config        DialerConfig
+		withError     error
+		withDialError string
+	}{
+		"default configuration": {
+			config: DialerConfig{},
+		},
+		"IPv4 only": {
+			config: DialerConfig{Network: "tcp4", Timeout: 5 * time.Second, KeepAlive: -1, FallbackDelay: -1},
+		},
+		"IPv6 only": {
+			config:        DialerConfig{Network: "tcp6"},
+			withDialError: "no suitable address",
+		},
+		"connect timeout is applied": {
+			config:        DialerConfig{Timeout: time.Nanosecond},
+			withDialError: "i/o timeout",
+		},
+		"client with custom transport": {
+			client: &http.Client{Transport: &http.Transport{MaxIdleConns: 3}},
+			config: DialerConfig{Timeout: 5 * time.Second},
+		},
+		"unsupported network": {
+			config:    DialerConfig{Network: "udp"},
+			withError: ErrInvalidDialer,
+		},
+		"client with unsupported transport": {
+			client:    &http.Client{Transport: roundTripperFunc(http.DefaultTransport.RoundTrip)},
+			withError: ErrInvalidDialer,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			options := []Option{WithSigner(&edgegrid.Config{}), WithDialer(test.config)}
+			if test.client != nil {
+				options = append(options, WithClient(test.client))
+			}
+			s, err := New(options...)
+			if test.withError != nil {
+				assert.True(t, errors.Is(err, test.withError), "want: %s; got: %s", test.withError, err)
+				return
+			}
+			require.NoError(t, err)
+
+			client := s.Client()
+			assert.NotSame(t, http.DefaultClient, client)
+			assert.Nil(t, http.DefaultClient.Transport)
+			if test.client != nil {
+				assert.Nil(t, test.client.Transport.(*http.Transport).DialContext)
+				assert.Equal(t, 3, client.Transport.(*http.Transport).MaxIdleConns)
+			}
+
+			resp, err := client.Get(mockServer.URL)
+			if test.withDialError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.withDialError)
+				return
+			}
+			require.NoError(t, err)
+			assert.NoError(t, resp.Body.Close())
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+		})
+	}
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
@@ -0,0 +1,72 @@
+package session
+
+import (
+	"context"
+	"net/http"
+)
+
+type (
+	// Tracer starts spans for the requests sent by the session, see WithTracer.
+	// It is implemented by wrapping a tracing library, e.g. with OpenTelemetry:
+	//
+	//	func (t otelTracer) Start(ctx context.Context, name string) (context.Context, session.Span) {
+	//		ctx, span := t.tracer.Start(ctx, name, trace.WithSpanKind(trace.SpanKindClient))
+	//		return ctx, otelSpan{span}
+	//	}
+	Tracer interface {
+		// Start starts a span with the given name, as a child of the span in the context if there is one,
+		// and returns the context holding the new span
+		Start(ctx context.Context, name string) (context.Context, Span)
+	}
+
+	// Span is a span started by a Tracer for a single request
+	Span interface {
+		// SetAttribute sets an attribute of the span, the value is a string or an int
+		SetAttribute(key string, value interface{})
+		// RecordError records that the request failed
+		RecordError(err error)
+		// End ends the span
+		End()
+	}
+)
+
+const (
+	// SpanAttributeMethod is the span attribute holding the HTTP method of the request
+	SpanAttributeMethod = "http.method"
+	// SpanAttributePath is the span attribute holding the URL path of the request
+	SpanAttributePath = "url.path"
+	// SpanAttributeStatusCode is the span attribute holding the HTTP status code of the response
+	SpanAttributeStatusCode = "http.status_code"
+)
+
+// WithTracer starts a span for each request sent by the session, using the span in the request context as the parent.
+// The request is sent with the context holding the new span, so that a tracing transport can propagate it.
+// No spans are started by default
+func WithTracer(tracer Tracer) Option {
+	return func(s *session) {
+		s.tracer = tracer
+	}
+}
+
+// doTraced sends the request within a span if a tracer is set
+func (s *session) doTraced(r, hedge *http.Request) (*http.Response, error) {
+	if s.tracer == nil {
+		return s.do(r, hedge)
+	}
+
+	ctx, span := s.tracer.Start(r.Context(), "HTTP "+r.Method)
+	defer span.End()
+	span.SetAttribute(SpanAttributeMethod, r.Method)
+	span.SetAttribute(SpanAttributePath, r.URL.Path)
+
+	if hedge != nil {
+		hedge = hedge.WithContext(ctx)
+	}
+	resp, err := s.do(r.WithContext(ctx), hedge)
+	if err != nil {
+		span.RecordError(err)
+		return nil, err
+	}
+	span.SetAttribute(SpanAttributeStatusCode, resp.StatusCode)
+	return resp, nil
+}
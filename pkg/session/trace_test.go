@@ -0,0 +1,141 @@
+package session
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/akamai/AkamaiOPEN-edgegrid-golang/v3/pkg/edgegrid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type spanKey struct{}
+
+// recordingTracer records the spans it starts
+type recordingTracer struct {
+	mu    sync.Mutex
+	spans []*recordingSpan
+}
+
+type recordingSpan struct {
+	name       string
+	parent     *recordingSpan
+	attributes map[string]interface{}
+	err        error
+	ended      bool
+}
+
+func (t *recordingTracer) Start(ctx context.Context, name string) (context.Context, Span) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	parent, _ := ctx.Value(spanKey{}).(*recordingSpan)
+	span := &recordingSpan{name: name, parent: parent, attributes: map[string]interface{}{}}
+	t.spans = append(t.spans, span)
+	return context.WithValue(ctx, spanKey{}, span), span
+}
+
+func (s *recordingSpan) SetAttribute(key string, value interface{}) {
+	s.attributes[key] = value
+}
+
+func (s *recordingSpan) RecordError(err error) {
+	s.err = err
+}
+
+func (s *recordingSpan) End() {
+	s.ended = true
+}
+
+func TestSession_Exec_WithTracer(t *testing.T) {
+	mockServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/papi/v1/missing" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		_, err := w.Write([]byte(`{"a":"text","b":1}`))
+		assert.NoError(t, err)
+	}))
+	defer mockServer.Close()
+	certPool := x509.NewCertPool()
+	certPool.AddCert(mockServer.Certificate())
+	transport := &http.Transport{
+		TLSClientConfig: &tls.Config{
+			RootCAs: certPool,
+		},
+	}
+
+	tracer := &recordingTracer{}
+	var transportSpans []*recordingSpan
+	httpClient := &http.Client{
+		Transport: roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+			span, _ := r.Context().Value(spanKey{}).(*recordingSpan)
+			transportSpans = append(transportSpans, span)
+			if r.URL.Path == "/papi/v1/unreachable" {
+				return nil, errors.New("connection refused")
+			}
+			return transport.RoundTrip(r)
+		}),
+	}
+	s, err := New(WithSigner(&edgegrid.Config{}), WithClient(httpClient), WithBaseURL(mockServer.URL), WithTracer(tracer))
+	require.NoError(t, err)
+
+	parent := &recordingSpan{name: "parent"}
+	ctx := context.WithValue(context.Background(), spanKey{}, parent)
+	exec := func(method, path string) error {
+		req, err := http.NewRequestWithContext(ctx, method, path, nil)
+		require.NoError(t, err)
+		var out testStruct
+		_, err = s.Exec(req, &out)
+		return err
+	}
+	require.NoError(t, exec(http.MethodGet, "/papi/v1/contracts"))
+	require.NoError(t, exec(http.MethodDelete, "/papi/v1/missing"))
+	require.Error(t, exec(http.MethodGet, "/papi/v1/unreachable"))
+
+	require.Len(t, tracer.spans, 3)
+	assert.Equal(t, tracer.spans, transportSpans)
+	expected := []struct {
+		name       string
+		attributes map[string]interface{}
+		withError  bool
+	}{
+		{
+			name: "HTTP GET",
+			attributes: map[string]interface{}{
+				SpanAttributeMethod:     http.MethodGet,
+				SpanAttributePath:       "/papi/v1/contracts",
+				SpanAttributeStatusCode: http.StatusOK,
+			},
+		},
+		{
+			name: "HTTP DELETE",
+			attributes: map[string]interface{}{
+				SpanAttributeMethod:     http.MethodDelete,
+				SpanAttributePath:       "/papi/v1/missing",
+				SpanAttributeStatusCode: http.StatusNotFound,
+			},
+		},
+		{
+			name: "HTTP GET",
+			attributes: map[string]interface{}{
+				SpanAttributeMethod: http.MethodGet,
+				SpanAttributePath:   "/papi/v1/unreachable",
+			},
+			withError: true,
+		},
+	}
+	for i, span := range tracer.spans {
+		assert.Equal(t, expected[i].name, span.name)
+		assert.Equal(t, expected[i].attributes, span.attributes)
+		assert.Equal(t, expected[i].withError, span.err != nil)
+		assert.Same(t, parent, span.parent)
+		assert.True(t, span.ended)
+	}
+}